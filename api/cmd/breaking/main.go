@@ -0,0 +1,258 @@
+// breaking 检查proto定义是否有不兼容的修改
+//
+// 生成代码在init时把proto文件注册到protoregistry，这里和提交在仓库里的基线比较：
+//
+//	go run ./cmd/breaking          // 检查，有不兼容的修改时返回非0
+//	go run ./cmd/breaking -update  // 确认修改后更新基线
+//
+// 不兼容的修改包括：删除消息、字段、枚举值、服务和方法，修改字段编号对应的名称、类型和标签，
+// 修改方法的请求响应类型和流式，修改go_package。删除的字段编号和枚举值要用reserved保留。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	_ "github.com/idMiFeng/api/shop/auth/v1"
+	_ "github.com/idMiFeng/api/shop/deadletter/v1"
+	_ "github.com/idMiFeng/api/shop/goods/v1"
+	_ "github.com/idMiFeng/api/shop/order/v1"
+	_ "github.com/idMiFeng/api/shop/stock/v1"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// filePrefix 只检查本项目的proto
+const filePrefix = "shop/"
+
+func main() {
+	var (
+		baseline string
+		update   bool
+	)
+	flag.StringVar(&baseline, "baseline", "breaking.binpb", "基线文件路径")
+	flag.BoolVar(&update, "update", false, "用当前的定义覆盖基线")
+	flag.Parse()
+
+	if update {
+		if err := writeBaseline(baseline); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	old, err := readBaseline(baseline)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	problems := check(old, protoregistry.GlobalFiles)
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+}
+
+// writeBaseline 把当前的proto文件及其依赖写成FileDescriptorSet
+func writeBaseline(path string) error {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(fd))
+	}
+	var files []protoreflect.FileDescriptor
+	protoregistry.GlobalFiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		if strings.HasPrefix(fd.Path(), filePrefix) {
+			files = append(files, fd)
+		}
+		return true
+	})
+	// 按路径排序，保证基线文件的内容稳定
+	sort.Slice(files, func(i, j int) bool { return files[i].Path() < files[j].Path() })
+	for _, fd := range files {
+		add(fd)
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(set)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+func readBaseline(path string) (*protoregistry.Files, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(b, set); err != nil {
+		return nil, err
+	}
+	return protodesc.NewFiles(set)
+}
+
+// check 找出基线中有、但当前定义不兼容的地方
+func check(old, cur *protoregistry.Files) []string {
+	var problems []string
+	report := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+	old.RangeFiles(func(ofd protoreflect.FileDescriptor) bool {
+		if !strings.HasPrefix(ofd.Path(), filePrefix) {
+			return true
+		}
+		cfd, err := cur.FindFileByPath(ofd.Path())
+		if err != nil {
+			report("%s: file removed", ofd.Path())
+			return true
+		}
+		if ogo, cgo := goPackage(ofd), goPackage(cfd); ogo != cgo {
+			report("%s: go_package changed from %q to %q", ofd.Path(), ogo, cgo)
+		}
+		if ofd.Package() != cfd.Package() {
+			report("%s: package changed from %q to %q", ofd.Path(), ofd.Package(), cfd.Package())
+		}
+		checkMessages(ofd.Messages(), cur, report)
+		checkEnums(ofd.Enums(), cur, report)
+		checkServices(ofd.Services(), cur, report)
+		return true
+	})
+	return problems
+}
+
+func goPackage(fd protoreflect.FileDescriptor) string {
+	opts, ok := fd.Options().(*descriptorpb.FileOptions)
+	if !ok || opts == nil {
+		return ""
+	}
+	return opts.GetGoPackage()
+}
+
+func checkMessages(msgs protoreflect.MessageDescriptors, cur *protoregistry.Files, report func(string, ...interface{})) {
+	for i := 0; i < msgs.Len(); i++ {
+		om := msgs.Get(i)
+		d, err := cur.FindDescriptorByName(om.FullName())
+		cm, ok := d.(protoreflect.MessageDescriptor)
+		if err != nil || !ok {
+			report("%s: message removed", om.FullName())
+			continue
+		}
+		ofields := om.Fields()
+		for j := 0; j < ofields.Len(); j++ {
+			of := ofields.Get(j)
+			cf := cm.Fields().ByNumber(of.Number())
+			if cf == nil {
+				if !cm.ReservedRanges().Has(of.Number()) {
+					report("%s: field %d %q removed without reserving", om.FullName(), of.Number(), of.Name())
+				}
+				continue
+			}
+			// 字段名会影响JSON和gateway的映射
+			if of.Name() != cf.Name() {
+				report("%s: field %d renamed from %q to %q", om.FullName(), of.Number(), of.Name(), cf.Name())
+			}
+			if of.Kind() != cf.Kind() || typeName(of) != typeName(cf) {
+				report("%s: field %q type changed from %s to %s", om.FullName(), of.Name(), fieldType(of), fieldType(cf))
+			}
+			if of.Cardinality() != cf.Cardinality() {
+				report("%s: field %q label changed from %s to %s", om.FullName(), of.Name(), of.Cardinality(), cf.Cardinality())
+			}
+			if oneofName(of) != oneofName(cf) {
+				report("%s: field %q moved from oneof %q to %q", om.FullName(), of.Name(), oneofName(of), oneofName(cf))
+			}
+		}
+		checkMessages(om.Messages(), cur, report)
+		checkEnums(om.Enums(), cur, report)
+	}
+}
+
+func checkEnums(enums protoreflect.EnumDescriptors, cur *protoregistry.Files, report func(string, ...interface{})) {
+	for i := 0; i < enums.Len(); i++ {
+		oe := enums.Get(i)
+		d, err := cur.FindDescriptorByName(oe.FullName())
+		ce, ok := d.(protoreflect.EnumDescriptor)
+		if err != nil || !ok {
+			report("%s: enum removed", oe.FullName())
+			continue
+		}
+		ovalues := oe.Values()
+		for j := 0; j < ovalues.Len(); j++ {
+			ov := ovalues.Get(j)
+			if ce.Values().ByNumber(ov.Number()) == nil && !ce.ReservedRanges().Has(ov.Number()) {
+				report("%s: enum value %d %q removed without reserving", oe.FullName(), ov.Number(), ov.Name())
+			}
+		}
+	}
+}
+
+func checkServices(srvs protoreflect.ServiceDescriptors, cur *protoregistry.Files, report func(string, ...interface{})) {
+	for i := 0; i < srvs.Len(); i++ {
+		osd := srvs.Get(i)
+		d, err := cur.FindDescriptorByName(osd.FullName())
+		cs, ok := d.(protoreflect.ServiceDescriptor)
+		if err != nil || !ok {
+			report("%s: service removed", osd.FullName())
+			continue
+		}
+		omethods := osd.Methods()
+		for j := 0; j < omethods.Len(); j++ {
+			om := omethods.Get(j)
+			cm := cs.Methods().ByName(om.Name())
+			if cm == nil {
+				report("%s: method %q removed", osd.FullName(), om.Name())
+				continue
+			}
+			if om.Input().FullName() != cm.Input().FullName() {
+				report("%s.%s: request changed from %s to %s", osd.FullName(), om.Name(), om.Input().FullName(), cm.Input().FullName())
+			}
+			if om.Output().FullName() != cm.Output().FullName() {
+				report("%s.%s: response changed from %s to %s", osd.FullName(), om.Name(), om.Output().FullName(), cm.Output().FullName())
+			}
+			if om.IsStreamingClient() != cm.IsStreamingClient() || om.IsStreamingServer() != cm.IsStreamingServer() {
+				report("%s.%s: streaming changed", osd.FullName(), om.Name())
+			}
+		}
+	}
+}
+
+func typeName(fd protoreflect.FieldDescriptor) protoreflect.FullName {
+	switch {
+	case fd.Message() != nil:
+		return fd.Message().FullName()
+	case fd.Enum() != nil:
+		return fd.Enum().FullName()
+	}
+	return ""
+}
+
+func fieldType(fd protoreflect.FieldDescriptor) string {
+	if name := typeName(fd); name != "" {
+		return string(name)
+	}
+	return fd.Kind().String()
+}
+
+func oneofName(fd protoreflect.FieldDescriptor) protoreflect.Name {
+	if o := fd.ContainingOneof(); o != nil && !o.IsSynthetic() {
+		return o.Name()
+	}
+	return ""
+}
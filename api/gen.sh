@@ -0,0 +1,16 @@
+#!/bin/sh
+# 在api目录下执行（或 make gen），根据proto生成Go代码，生成后检查是否有不兼容的修改
+set -e
+cd "$(dirname "$0")"
+
+protoc -I . \
+  --go_out=. --go_opt=paths=source_relative \
+  --go-grpc_out=. --go-grpc_opt=paths=source_relative \
+  --grpc-gateway_out=. --grpc-gateway_opt=paths=source_relative \
+  shop/auth/v1/auth.proto \
+  shop/deadletter/v1/dead_letter.proto \
+  shop/goods/v1/goods.proto \
+  shop/stock/v1/stock.proto \
+  shop/order/v1/order.proto
+
+go run ./cmd/breaking
@@ -0,0 +1,351 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.20.1
+// source: shop/auth/v1/auth.proto
+
+package authv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 用户的角色，对应用户token中roles的 shopper、merchant、live-ops、warehouse、admin
+type Role int32
+
+const (
+	Role_ROLE_UNSPECIFIED Role = 0
+	Role_ROLE_SHOPPER     Role = 1 // 买家
+	Role_ROLE_MERCHANT    Role = 2 // 商家，只能操作自己的商品和订单
+	Role_ROLE_LIVE_OPS    Role = 3 // 直播运营
+	Role_ROLE_WAREHOUSE   Role = 4 // 仓库
+	Role_ROLE_ADMIN       Role = 5 // 管理员，可以调用所有的方法
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "ROLE_UNSPECIFIED",
+		1: "ROLE_SHOPPER",
+		2: "ROLE_MERCHANT",
+		3: "ROLE_LIVE_OPS",
+		4: "ROLE_WAREHOUSE",
+		5: "ROLE_ADMIN",
+	}
+	Role_value = map[string]int32{
+		"ROLE_UNSPECIFIED": 0,
+		"ROLE_SHOPPER":     1,
+		"ROLE_MERCHANT":    2,
+		"ROLE_LIVE_OPS":    3,
+		"ROLE_WAREHOUSE":   4,
+		"ROLE_ADMIN":       5,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_shop_auth_v1_auth_proto_enumTypes[0].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_shop_auth_v1_auth_proto_enumTypes[0]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_shop_auth_v1_auth_proto_rawDescGZIP(), []int{0}
+}
+
+type Policy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Public        bool           `protobuf:"varint,1,opt,name=public,proto3" json:"public,omitempty"`                                   // 允许匿名调用
+	Internal      bool           `protobuf:"varint,2,opt,name=internal,proto3" json:"internal,omitempty"`                               // 只允许服务间调用
+	Roles         []Role         `protobuf:"varint,3,rep,packed,name=roles,proto3,enum=shop.auth.v1.Role" json:"roles,omitempty"`       // 允许调用的角色，为空时所有登录用户都可以调用
+	MerchantScope *MerchantScope `protobuf:"bytes,4,opt,name=merchant_scope,json=merchantScope,proto3" json:"merchant_scope,omitempty"` // 商家调用时检查操作的资源是否属于该商家
+}
+
+func (x *Policy) Reset() {
+	*x = Policy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_shop_auth_v1_auth_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Policy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Policy) ProtoMessage() {}
+
+func (x *Policy) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_auth_v1_auth_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Policy.ProtoReflect.Descriptor instead.
+func (*Policy) Descriptor() ([]byte, []int) {
+	return file_shop_auth_v1_auth_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Policy) GetPublic() bool {
+	if x != nil {
+		return x.Public
+	}
+	return false
+}
+
+func (x *Policy) GetInternal() bool {
+	if x != nil {
+		return x.Internal
+	}
+	return false
+}
+
+func (x *Policy) GetRoles() []Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *Policy) GetMerchantScope() *MerchantScope {
+	if x != nil {
+		return x.MerchantScope
+	}
+	return nil
+}
+
+// 商家的数据范围，请求中 field 字段的值为 resource 类型资源的id
+type MerchantScope struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Resource string `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"` // 资源类型，如 goods、order、refund，由服务注册查询资源所属商家的方法
+	Field    string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`       // 请求中资源id的字段名
+}
+
+func (x *MerchantScope) Reset() {
+	*x = MerchantScope{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_shop_auth_v1_auth_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MerchantScope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MerchantScope) ProtoMessage() {}
+
+func (x *MerchantScope) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_auth_v1_auth_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MerchantScope.ProtoReflect.Descriptor instead.
+func (*MerchantScope) Descriptor() ([]byte, []int) {
+	return file_shop_auth_v1_auth_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MerchantScope) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *MerchantScope) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+var file_shop_auth_v1_auth_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*Policy)(nil),
+		Field:         50100,
+		Name:          "shop.auth.v1.policy",
+		Tag:           "bytes,50100,opt,name=policy",
+		Filename:      "shop/auth/v1/auth.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// optional shop.auth.v1.Policy policy = 50100;
+	E_Policy = &file_shop_auth_v1_auth_proto_extTypes[0]
+)
+
+var File_shop_auth_v1_auth_proto protoreflect.FileDescriptor
+
+var file_shop_auth_v1_auth_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x73, 0x68, 0x6f, 0x70, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x2f, 0x76, 0x31, 0x2f, 0x61,
+	0x75, 0x74, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x73, 0x68, 0x6f, 0x70, 0x2e,
+	0x61, 0x75, 0x74, 0x68, 0x2e, 0x76, 0x31, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xaa, 0x01, 0x0a, 0x06, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x12, 0x1a, 0x0a, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x12, 0x28, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x61,
+	0x75, 0x74, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x6f, 0x6c,
+	0x65, 0x73, 0x12, 0x42, 0x0a, 0x0e, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x5f, 0x73,
+	0x63, 0x6f, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x68, 0x6f,
+	0x70, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x72, 0x63, 0x68, 0x61,
+	0x6e, 0x74, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x0d, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e,
+	0x74, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x22, 0x41, 0x0a, 0x0d, 0x4d, 0x65, 0x72, 0x63, 0x68, 0x61,
+	0x6e, 0x74, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x2a, 0x78, 0x0a, 0x04, 0x52, 0x6f, 0x6c,
+	0x65, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x4f, 0x4c, 0x45, 0x5f,
+	0x53, 0x48, 0x4f, 0x50, 0x50, 0x45, 0x52, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x52, 0x4f, 0x4c,
+	0x45, 0x5f, 0x4d, 0x45, 0x52, 0x43, 0x48, 0x41, 0x4e, 0x54, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d,
+	0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x4c, 0x49, 0x56, 0x45, 0x5f, 0x4f, 0x50, 0x53, 0x10, 0x03, 0x12,
+	0x12, 0x0a, 0x0e, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x57, 0x41, 0x52, 0x45, 0x48, 0x4f, 0x55, 0x53,
+	0x45, 0x10, 0x04, 0x12, 0x0e, 0x0a, 0x0a, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x41, 0x44, 0x4d, 0x49,
+	0x4e, 0x10, 0x05, 0x3a, 0x4e, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1e, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xb4, 0x87,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x61, 0x75, 0x74,
+	0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x06, 0x70, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x69, 0x64, 0x4d, 0x69, 0x46, 0x65, 0x6e, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73,
+	0x68, 0x6f, 0x70, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x75, 0x74, 0x68,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_shop_auth_v1_auth_proto_rawDescOnce sync.Once
+	file_shop_auth_v1_auth_proto_rawDescData = file_shop_auth_v1_auth_proto_rawDesc
+)
+
+func file_shop_auth_v1_auth_proto_rawDescGZIP() []byte {
+	file_shop_auth_v1_auth_proto_rawDescOnce.Do(func() {
+		file_shop_auth_v1_auth_proto_rawDescData = protoimpl.X.CompressGZIP(file_shop_auth_v1_auth_proto_rawDescData)
+	})
+	return file_shop_auth_v1_auth_proto_rawDescData
+}
+
+var file_shop_auth_v1_auth_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_shop_auth_v1_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_shop_auth_v1_auth_proto_goTypes = []interface{}{
+	(Role)(0),                          // 0: shop.auth.v1.Role
+	(*Policy)(nil),                     // 1: shop.auth.v1.Policy
+	(*MerchantScope)(nil),              // 2: shop.auth.v1.MerchantScope
+	(*descriptorpb.MethodOptions)(nil), // 3: google.protobuf.MethodOptions
+}
+var file_shop_auth_v1_auth_proto_depIdxs = []int32{
+	0, // 0: shop.auth.v1.Policy.roles:type_name -> shop.auth.v1.Role
+	2, // 1: shop.auth.v1.Policy.merchant_scope:type_name -> shop.auth.v1.MerchantScope
+	3, // 2: shop.auth.v1.policy:extendee -> google.protobuf.MethodOptions
+	1, // 3: shop.auth.v1.policy:type_name -> shop.auth.v1.Policy
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	3, // [3:4] is the sub-list for extension type_name
+	2, // [2:3] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_shop_auth_v1_auth_proto_init() }
+func file_shop_auth_v1_auth_proto_init() {
+	if File_shop_auth_v1_auth_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_shop_auth_v1_auth_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Policy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_shop_auth_v1_auth_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MerchantScope); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_shop_auth_v1_auth_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_shop_auth_v1_auth_proto_goTypes,
+		DependencyIndexes: file_shop_auth_v1_auth_proto_depIdxs,
+		EnumInfos:         file_shop_auth_v1_auth_proto_enumTypes,
+		MessageInfos:      file_shop_auth_v1_auth_proto_msgTypes,
+		ExtensionInfos:    file_shop_auth_v1_auth_proto_extTypes,
+	}.Build()
+	File_shop_auth_v1_auth_proto = out.File
+	file_shop_auth_v1_auth_proto_rawDesc = nil
+	file_shop_auth_v1_auth_proto_goTypes = nil
+	file_shop_auth_v1_auth_proto_depIdxs = nil
+}
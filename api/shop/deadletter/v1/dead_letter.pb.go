@@ -0,0 +1,443 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.20.1
+// source: shop/deadletter/v1/dead_letter.proto
+
+package deadletterv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListDeadLetterReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Topic    string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`    // 原始topic，为空时查询全部
+	Status   int32  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"` // 0待处理 1已重放
+	PageNum  int32  `protobuf:"varint,3,opt,name=pageNum,proto3" json:"pageNum,omitempty"`
+	PageSize int32  `protobuf:"varint,4,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+}
+
+func (x *ListDeadLetterReq) Reset() {
+	*x = ListDeadLetterReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_shop_deadletter_v1_dead_letter_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDeadLetterReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLetterReq) ProtoMessage() {}
+
+func (x *ListDeadLetterReq) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_deadletter_v1_dead_letter_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLetterReq.ProtoReflect.Descriptor instead.
+func (*ListDeadLetterReq) Descriptor() ([]byte, []int) {
+	return file_shop_deadletter_v1_dead_letter_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListDeadLetterReq) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *ListDeadLetterReq) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ListDeadLetterReq) GetPageNum() int32 {
+	if x != nil {
+		return x.PageNum
+	}
+	return 0
+}
+
+func (x *ListDeadLetterReq) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type DeadLetterInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Topic          string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	MsgId          string `protobuf:"bytes,3,opt,name=msgId,proto3" json:"msgId,omitempty"`
+	Body           string `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	ReconsumeTimes int32  `protobuf:"varint,5,opt,name=reconsumeTimes,proto3" json:"reconsumeTimes,omitempty"`
+	Reason         string `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Status         int32  `protobuf:"varint,7,opt,name=status,proto3" json:"status,omitempty"`
+	CreateAt       int64  `protobuf:"varint,8,opt,name=createAt,proto3" json:"createAt,omitempty"` // 毫秒时间戳
+}
+
+func (x *DeadLetterInfo) Reset() {
+	*x = DeadLetterInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_shop_deadletter_v1_dead_letter_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeadLetterInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetterInfo) ProtoMessage() {}
+
+func (x *DeadLetterInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_deadletter_v1_dead_letter_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetterInfo.ProtoReflect.Descriptor instead.
+func (*DeadLetterInfo) Descriptor() ([]byte, []int) {
+	return file_shop_deadletter_v1_dead_letter_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DeadLetterInfo) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeadLetterInfo) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *DeadLetterInfo) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+func (x *DeadLetterInfo) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *DeadLetterInfo) GetReconsumeTimes() int32 {
+	if x != nil {
+		return x.ReconsumeTimes
+	}
+	return 0
+}
+
+func (x *DeadLetterInfo) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DeadLetterInfo) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *DeadLetterInfo) GetCreateAt() int64 {
+	if x != nil {
+		return x.CreateAt
+	}
+	return 0
+}
+
+type DeadLetterList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total int32             `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Data  []*DeadLetterInfo `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *DeadLetterList) Reset() {
+	*x = DeadLetterList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_shop_deadletter_v1_dead_letter_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeadLetterList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetterList) ProtoMessage() {}
+
+func (x *DeadLetterList) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_deadletter_v1_dead_letter_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetterList.ProtoReflect.Descriptor instead.
+func (*DeadLetterList) Descriptor() ([]byte, []int) {
+	return file_shop_deadletter_v1_dead_letter_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DeadLetterList) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *DeadLetterList) GetData() []*DeadLetterInfo {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ReplayDeadLetterReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *ReplayDeadLetterReq) Reset() {
+	*x = ReplayDeadLetterReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_shop_deadletter_v1_dead_letter_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplayDeadLetterReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayDeadLetterReq) ProtoMessage() {}
+
+func (x *ReplayDeadLetterReq) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_deadletter_v1_dead_letter_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayDeadLetterReq.ProtoReflect.Descriptor instead.
+func (*ReplayDeadLetterReq) Descriptor() ([]byte, []int) {
+	return file_shop_deadletter_v1_dead_letter_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReplayDeadLetterReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+var File_shop_deadletter_v1_dead_letter_proto protoreflect.FileDescriptor
+
+var file_shop_deadletter_v1_dead_letter_proto_rawDesc = []byte{
+	0x0a, 0x24, 0x73, 0x68, 0x6f, 0x70, 0x2f, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x65, 0x74, 0x74, 0x65,
+	0x72, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x65, 0x61, 0x64, 0x5f, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x64, 0x65, 0x61,
+	0x64, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x22, 0x77, 0x0a, 0x11, 0x4c, 0x69,
+	0x73, 0x74, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x70, 0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07,
+	0x70, 0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x22, 0xd4, 0x01, 0x0a, 0x0e, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74,
+	0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x14, 0x0a, 0x05,
+	0x6d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x67,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x73,
+	0x75, 0x6d, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e,
+	0x72, 0x65, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x74, 0x22, 0x5e, 0x0a, 0x0e, 0x44, 0x65,
+	0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x12, 0x36, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x65, 0x74, 0x74,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x25, 0x0a, 0x13, 0x52, 0x65,
+	0x70, 0x6c, 0x61, 0x79, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
+	0x64, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x69, 0x64, 0x4d, 0x69, 0x46, 0x65, 0x6e, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x68, 0x6f,
+	0x70, 0x2f, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x3b,
+	0x64, 0x65, 0x61, 0x64, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_shop_deadletter_v1_dead_letter_proto_rawDescOnce sync.Once
+	file_shop_deadletter_v1_dead_letter_proto_rawDescData = file_shop_deadletter_v1_dead_letter_proto_rawDesc
+)
+
+func file_shop_deadletter_v1_dead_letter_proto_rawDescGZIP() []byte {
+	file_shop_deadletter_v1_dead_letter_proto_rawDescOnce.Do(func() {
+		file_shop_deadletter_v1_dead_letter_proto_rawDescData = protoimpl.X.CompressGZIP(file_shop_deadletter_v1_dead_letter_proto_rawDescData)
+	})
+	return file_shop_deadletter_v1_dead_letter_proto_rawDescData
+}
+
+var file_shop_deadletter_v1_dead_letter_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_shop_deadletter_v1_dead_letter_proto_goTypes = []interface{}{
+	(*ListDeadLetterReq)(nil),   // 0: shop.deadletter.v1.ListDeadLetterReq
+	(*DeadLetterInfo)(nil),      // 1: shop.deadletter.v1.DeadLetterInfo
+	(*DeadLetterList)(nil),      // 2: shop.deadletter.v1.DeadLetterList
+	(*ReplayDeadLetterReq)(nil), // 3: shop.deadletter.v1.ReplayDeadLetterReq
+}
+var file_shop_deadletter_v1_dead_letter_proto_depIdxs = []int32{
+	1, // 0: shop.deadletter.v1.DeadLetterList.data:type_name -> shop.deadletter.v1.DeadLetterInfo
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_shop_deadletter_v1_dead_letter_proto_init() }
+func file_shop_deadletter_v1_dead_letter_proto_init() {
+	if File_shop_deadletter_v1_dead_letter_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_shop_deadletter_v1_dead_letter_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDeadLetterReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_shop_deadletter_v1_dead_letter_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeadLetterInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_shop_deadletter_v1_dead_letter_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeadLetterList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_shop_deadletter_v1_dead_letter_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplayDeadLetterReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_shop_deadletter_v1_dead_letter_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_shop_deadletter_v1_dead_letter_proto_goTypes,
+		DependencyIndexes: file_shop_deadletter_v1_dead_letter_proto_depIdxs,
+		MessageInfos:      file_shop_deadletter_v1_dead_letter_proto_msgTypes,
+	}.Build()
+	File_shop_deadletter_v1_dead_letter_proto = out.File
+	file_shop_deadletter_v1_dead_letter_proto_rawDesc = nil
+	file_shop_deadletter_v1_dead_letter_proto_goTypes = nil
+	file_shop_deadletter_v1_dead_letter_proto_depIdxs = nil
+}
@@ -2,9 +2,9 @@
 // versions:
 // 	protoc-gen-go v1.28.0
 // 	protoc        v3.20.1
-// source: goods.proto
+// source: shop/goods/v1/goods.proto
 
-package proto
+package goodsv1
 
 import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
@@ -33,7 +33,7 @@ type GetGoodsByRoomReq struct {
 func (x *GetGoodsByRoomReq) Reset() {
 	*x = GetGoodsByRoomReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goods_proto_msgTypes[0]
+		mi := &file_shop_goods_v1_goods_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -46,7 +46,7 @@ func (x *GetGoodsByRoomReq) String() string {
 func (*GetGoodsByRoomReq) ProtoMessage() {}
 
 func (x *GetGoodsByRoomReq) ProtoReflect() protoreflect.Message {
-	mi := &file_goods_proto_msgTypes[0]
+	mi := &file_shop_goods_v1_goods_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -59,7 +59,7 @@ func (x *GetGoodsByRoomReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetGoodsByRoomReq.ProtoReflect.Descriptor instead.
 func (*GetGoodsByRoomReq) Descriptor() ([]byte, []int) {
-	return file_goods_proto_rawDescGZIP(), []int{0}
+	return file_shop_goods_v1_goods_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *GetGoodsByRoomReq) GetUserId() int64 {
@@ -88,7 +88,7 @@ type GoodsListResp struct {
 func (x *GoodsListResp) Reset() {
 	*x = GoodsListResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goods_proto_msgTypes[1]
+		mi := &file_shop_goods_v1_goods_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -101,7 +101,7 @@ func (x *GoodsListResp) String() string {
 func (*GoodsListResp) ProtoMessage() {}
 
 func (x *GoodsListResp) ProtoReflect() protoreflect.Message {
-	mi := &file_goods_proto_msgTypes[1]
+	mi := &file_shop_goods_v1_goods_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -114,7 +114,7 @@ func (x *GoodsListResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GoodsListResp.ProtoReflect.Descriptor instead.
 func (*GoodsListResp) Descriptor() ([]byte, []int) {
-	return file_goods_proto_rawDescGZIP(), []int{1}
+	return file_shop_goods_v1_goods_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *GoodsListResp) GetCurrentGoodsId() int64 {
@@ -150,7 +150,7 @@ type GoodsInfo struct {
 func (x *GoodsInfo) Reset() {
 	*x = GoodsInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goods_proto_msgTypes[2]
+		mi := &file_shop_goods_v1_goods_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -163,7 +163,7 @@ func (x *GoodsInfo) String() string {
 func (*GoodsInfo) ProtoMessage() {}
 
 func (x *GoodsInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_goods_proto_msgTypes[2]
+	mi := &file_shop_goods_v1_goods_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -176,7 +176,7 @@ func (x *GoodsInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GoodsInfo.ProtoReflect.Descriptor instead.
 func (*GoodsInfo) Descriptor() ([]byte, []int) {
-	return file_goods_proto_rawDescGZIP(), []int{2}
+	return file_shop_goods_v1_goods_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GoodsInfo) GetGoodsId() int64 {
@@ -247,7 +247,7 @@ type GetGoodsDetailReq struct {
 func (x *GetGoodsDetailReq) Reset() {
 	*x = GetGoodsDetailReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goods_proto_msgTypes[3]
+		mi := &file_shop_goods_v1_goods_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -260,7 +260,7 @@ func (x *GetGoodsDetailReq) String() string {
 func (*GetGoodsDetailReq) ProtoMessage() {}
 
 func (x *GetGoodsDetailReq) ProtoReflect() protoreflect.Message {
-	mi := &file_goods_proto_msgTypes[3]
+	mi := &file_shop_goods_v1_goods_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -273,7 +273,7 @@ func (x *GetGoodsDetailReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetGoodsDetailReq.ProtoReflect.Descriptor instead.
 func (*GetGoodsDetailReq) Descriptor() ([]byte, []int) {
-	return file_goods_proto_rawDescGZIP(), []int{3}
+	return file_shop_goods_v1_goods_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetGoodsDetailReq) GetGoodsId() int64 {
@@ -307,12 +307,14 @@ type GoodsDetail struct {
 	HeadImgs    []string `protobuf:"bytes,10,rep,name=HeadImgs,proto3" json:"HeadImgs,omitempty"`
 	Videos      []string `protobuf:"bytes,11,rep,name=Videos,proto3" json:"Videos,omitempty"`
 	Detail      []string `protobuf:"bytes,12,rep,name=Detail,proto3" json:"Detail,omitempty"`
+	MerchantId  int64    `protobuf:"varint,13,opt,name=MerchantId,proto3" json:"MerchantId,omitempty"` // 商品所属的商家id
+	OrderType   int32    `protobuf:"varint,14,opt,name=OrderType,proto3" json:"OrderType,omitempty"`   // 购买该商品的订单类型：0普通 1秒杀，由商品的售卖方式决定
 }
 
 func (x *GoodsDetail) Reset() {
 	*x = GoodsDetail{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_goods_proto_msgTypes[4]
+		mi := &file_shop_goods_v1_goods_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -325,7 +327,7 @@ func (x *GoodsDetail) String() string {
 func (*GoodsDetail) ProtoMessage() {}
 
 func (x *GoodsDetail) ProtoReflect() protoreflect.Message {
-	mi := &file_goods_proto_msgTypes[4]
+	mi := &file_shop_goods_v1_goods_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -338,7 +340,7 @@ func (x *GoodsDetail) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GoodsDetail.ProtoReflect.Descriptor instead.
 func (*GoodsDetail) Descriptor() ([]byte, []int) {
-	return file_goods_proto_rawDescGZIP(), []int{4}
+	return file_shop_goods_v1_goods_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GoodsDetail) GetGoodsId() int64 {
@@ -425,101 +427,125 @@ func (x *GoodsDetail) GetDetail() []string {
 	return nil
 }
 
-var File_goods_proto protoreflect.FileDescriptor
-
-var file_goods_proto_rawDesc = []byte{
-	0x0a, 0x0b, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69,
-	0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x22, 0x43, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x42, 0x79,
-	0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
-	0x16, 0x0a, 0x06, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x06, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x22, 0x5d, 0x0a, 0x0d, 0x47, 0x6f, 0x6f, 0x64, 0x73,
-	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x26, 0x0a, 0x0e, 0x43, 0x75, 0x72, 0x72,
-	0x65, 0x6e, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x0e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64,
-	0x12, 0x24, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x6e, 0x66, 0x6f,
-	0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x22, 0xdd, 0x01, 0x0a, 0x09, 0x47, 0x6f, 0x6f, 0x64, 0x73,
-	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x1e,
-	0x0a, 0x0a, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0a, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x49, 0x64, 0x12, 0x16,
-	0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b,
-	0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0b, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x14,
-	0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x50,
-	0x72, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x42, 0x72, 0x69, 0x65, 0x66, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x42, 0x72, 0x69, 0x65, 0x66, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x65,
-	0x61, 0x64, 0x49, 0x6d, 0x67, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x48, 0x65,
-	0x61, 0x64, 0x49, 0x6d, 0x67, 0x73, 0x22, 0x45, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f,
-	0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x47,
+func (x *GoodsDetail) GetMerchantId() int64 {
+	if x != nil {
+		return x.MerchantId
+	}
+	return 0
+}
+
+func (x *GoodsDetail) GetOrderType() int32 {
+	if x != nil {
+		return x.OrderType
+	}
+	return 0
+}
+
+var File_shop_goods_v1_goods_proto protoreflect.FileDescriptor
+
+var file_shop_goods_v1_goods_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x73, 0x68, 0x6f, 0x70, 0x2f, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x2f, 0x76, 0x31, 0x2f,
+	0x67, 0x6f, 0x6f, 0x64, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x73, 0x68, 0x6f,
+	0x70, 0x2e, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x43, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x47,
+	0x6f, 0x6f, 0x64, 0x73, 0x42, 0x79, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a,
+	0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x55,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x22, 0x65, 0x0a,
+	0x0d, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x26,
+	0x0a, 0x0e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x47,
+	0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x67, 0x6f, 0x6f, 0x64,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04,
+	0x44, 0x61, 0x74, 0x61, 0x22, 0xdd, 0x01, 0x0a, 0x09, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a,
+	0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x61,
+	0x72, 0x6b, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x42, 0x72, 0x69, 0x65, 0x66, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x42, 0x72, 0x69, 0x65, 0x66, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x65, 0x61, 0x64,
+	0x49, 0x6d, 0x67, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x48, 0x65, 0x61, 0x64,
+	0x49, 0x6d, 0x67, 0x73, 0x22, 0x45, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x6f, 0x6f,
+	0x64, 0x73, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x6f, 0x6f, 0x64,
+	0x73, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xff, 0x02, 0x0a, 0x0b,
+	0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x47,
 	0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x6f,
-	0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xc1, 0x02,
-	0x0a, 0x0b, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x18, 0x0a,
-	0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
-	0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x61, 0x74, 0x65, 0x67,
-	0x6f, 0x72, 0x79, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x43, 0x61, 0x74,
-	0x65, 0x67, 0x6f, 0x72, 0x79, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x14, 0x0a, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x42, 0x72, 0x61,
-	0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x42, 0x72,
-	0x61, 0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x61, 0x72, 0x6b, 0x65,
-	0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4d, 0x61,
-	0x72, 0x6b, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x72, 0x69,
-	0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12,
-	0x14, 0x0a, 0x05, 0x42, 0x72, 0x69, 0x65, 0x66, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x42, 0x72, 0x69, 0x65, 0x66, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x65, 0x61, 0x64, 0x49, 0x6d, 0x67,
-	0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x48, 0x65, 0x61, 0x64, 0x49, 0x6d, 0x67,
-	0x73, 0x12, 0x16, 0x0a, 0x06, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x06, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x44, 0x65, 0x74,
-	0x61, 0x69, 0x6c, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x44, 0x65, 0x74, 0x61, 0x69,
-	0x6c, 0x32, 0x9f, 0x01, 0x0a, 0x05, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x12, 0x56, 0x0a, 0x0e, 0x47,
-	0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x42, 0x79, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x18, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x42, 0x79,
-	0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x47, 0x6f, 0x6f, 0x64, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x14, 0x82,
-	0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x22, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x6f, 0x6f, 0x64, 0x73,
-	0x3a, 0x01, 0x2a, 0x12, 0x3e, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44,
-	0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65,
-	0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x1a,
-	0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74,
-	0x61, 0x69, 0x6c, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
+	0x79, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x43, 0x61, 0x74, 0x65, 0x67,
+	0x6f, 0x72, 0x79, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x69,
+	0x74, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x42, 0x72, 0x61, 0x6e, 0x64,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x42, 0x72, 0x61, 0x6e,
+	0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4d, 0x61, 0x72, 0x6b,
+	0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x42, 0x72, 0x69, 0x65, 0x66, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x42, 0x72,
+	0x69, 0x65, 0x66, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x65, 0x61, 0x64, 0x49, 0x6d, 0x67, 0x73, 0x18,
+	0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x48, 0x65, 0x61, 0x64, 0x49, 0x6d, 0x67, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12,
+	0x1e, 0x0a, 0x0a, 0x4d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0a, 0x4d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12,
+	0x1c, 0x0a, 0x09, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x32, 0xbf, 0x01,
+	0x0a, 0x05, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x12, 0x66, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x47, 0x6f,
+	0x6f, 0x64, 0x73, 0x42, 0x79, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x20, 0x2e, 0x73, 0x68, 0x6f, 0x70,
+	0x2e, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f,
+	0x64, 0x73, 0x42, 0x79, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x1a, 0x1c, 0x2e, 0x73, 0x68,
+	0x6f, 0x70, 0x2e, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x6f, 0x64,
+	0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x14, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x0e, 0x3a, 0x01, 0x2a, 0x22, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x12,
+	0x4e, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x12, 0x20, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x67, 0x6f, 0x6f, 0x64, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x42,
+	0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x64,
+	0x4d, 0x69, 0x46, 0x65, 0x6e, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x68, 0x6f, 0x70, 0x2f,
+	0x67, 0x6f, 0x6f, 0x64, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x76, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
-	file_goods_proto_rawDescOnce sync.Once
-	file_goods_proto_rawDescData = file_goods_proto_rawDesc
+	file_shop_goods_v1_goods_proto_rawDescOnce sync.Once
+	file_shop_goods_v1_goods_proto_rawDescData = file_shop_goods_v1_goods_proto_rawDesc
 )
 
-func file_goods_proto_rawDescGZIP() []byte {
-	file_goods_proto_rawDescOnce.Do(func() {
-		file_goods_proto_rawDescData = protoimpl.X.CompressGZIP(file_goods_proto_rawDescData)
+func file_shop_goods_v1_goods_proto_rawDescGZIP() []byte {
+	file_shop_goods_v1_goods_proto_rawDescOnce.Do(func() {
+		file_shop_goods_v1_goods_proto_rawDescData = protoimpl.X.CompressGZIP(file_shop_goods_v1_goods_proto_rawDescData)
 	})
-	return file_goods_proto_rawDescData
-}
-
-var file_goods_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
-var file_goods_proto_goTypes = []interface{}{
-	(*GetGoodsByRoomReq)(nil), // 0: proto.GetGoodsByRoomReq
-	(*GoodsListResp)(nil),     // 1: proto.GoodsListResp
-	(*GoodsInfo)(nil),         // 2: proto.GoodsInfo
-	(*GetGoodsDetailReq)(nil), // 3: proto.GetGoodsDetailReq
-	(*GoodsDetail)(nil),       // 4: proto.GoodsDetail
-}
-var file_goods_proto_depIdxs = []int32{
-	2, // 0: proto.GoodsListResp.Data:type_name -> proto.GoodsInfo
-	0, // 1: proto.Goods.GetGoodsByRoom:input_type -> proto.GetGoodsByRoomReq
-	3, // 2: proto.Goods.GetGoodsDetail:input_type -> proto.GetGoodsDetailReq
-	1, // 3: proto.Goods.GetGoodsByRoom:output_type -> proto.GoodsListResp
-	4, // 4: proto.Goods.GetGoodsDetail:output_type -> proto.GoodsDetail
+	return file_shop_goods_v1_goods_proto_rawDescData
+}
+
+var file_shop_goods_v1_goods_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_shop_goods_v1_goods_proto_goTypes = []interface{}{
+	(*GetGoodsByRoomReq)(nil), // 0: shop.goods.v1.GetGoodsByRoomReq
+	(*GoodsListResp)(nil),     // 1: shop.goods.v1.GoodsListResp
+	(*GoodsInfo)(nil),         // 2: shop.goods.v1.GoodsInfo
+	(*GetGoodsDetailReq)(nil), // 3: shop.goods.v1.GetGoodsDetailReq
+	(*GoodsDetail)(nil),       // 4: shop.goods.v1.GoodsDetail
+}
+var file_shop_goods_v1_goods_proto_depIdxs = []int32{
+	2, // 0: shop.goods.v1.GoodsListResp.Data:type_name -> shop.goods.v1.GoodsInfo
+	0, // 1: shop.goods.v1.Goods.GetGoodsByRoom:input_type -> shop.goods.v1.GetGoodsByRoomReq
+	3, // 2: shop.goods.v1.Goods.GetGoodsDetail:input_type -> shop.goods.v1.GetGoodsDetailReq
+	1, // 3: shop.goods.v1.Goods.GetGoodsByRoom:output_type -> shop.goods.v1.GoodsListResp
+	4, // 4: shop.goods.v1.Goods.GetGoodsDetail:output_type -> shop.goods.v1.GoodsDetail
 	3, // [3:5] is the sub-list for method output_type
 	1, // [1:3] is the sub-list for method input_type
 	1, // [1:1] is the sub-list for extension type_name
@@ -527,13 +553,13 @@ var file_goods_proto_depIdxs = []int32{
 	0, // [0:1] is the sub-list for field type_name
 }
 
-func init() { file_goods_proto_init() }
-func file_goods_proto_init() {
-	if File_goods_proto != nil {
+func init() { file_shop_goods_v1_goods_proto_init() }
+func file_shop_goods_v1_goods_proto_init() {
+	if File_shop_goods_v1_goods_proto != nil {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_goods_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_shop_goods_v1_goods_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetGoodsByRoomReq); i {
 			case 0:
 				return &v.state
@@ -545,7 +571,7 @@ func file_goods_proto_init() {
 				return nil
 			}
 		}
-		file_goods_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_shop_goods_v1_goods_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GoodsListResp); i {
 			case 0:
 				return &v.state
@@ -557,7 +583,7 @@ func file_goods_proto_init() {
 				return nil
 			}
 		}
-		file_goods_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_shop_goods_v1_goods_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GoodsInfo); i {
 			case 0:
 				return &v.state
@@ -569,7 +595,7 @@ func file_goods_proto_init() {
 				return nil
 			}
 		}
-		file_goods_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_shop_goods_v1_goods_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetGoodsDetailReq); i {
 			case 0:
 				return &v.state
@@ -581,7 +607,7 @@ func file_goods_proto_init() {
 				return nil
 			}
 		}
-		file_goods_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_shop_goods_v1_goods_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GoodsDetail); i {
 			case 0:
 				return &v.state
@@ -598,18 +624,18 @@ func file_goods_proto_init() {
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_goods_proto_rawDesc,
+			RawDescriptor: file_shop_goods_v1_goods_proto_rawDesc,
 			NumEnums:      0,
 			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
-		GoTypes:           file_goods_proto_goTypes,
-		DependencyIndexes: file_goods_proto_depIdxs,
-		MessageInfos:      file_goods_proto_msgTypes,
+		GoTypes:           file_shop_goods_v1_goods_proto_goTypes,
+		DependencyIndexes: file_shop_goods_v1_goods_proto_depIdxs,
+		MessageInfos:      file_shop_goods_v1_goods_proto_msgTypes,
 	}.Build()
-	File_goods_proto = out.File
-	file_goods_proto_rawDesc = nil
-	file_goods_proto_goTypes = nil
-	file_goods_proto_depIdxs = nil
+	File_shop_goods_v1_goods_proto = out.File
+	file_shop_goods_v1_goods_proto_rawDesc = nil
+	file_shop_goods_v1_goods_proto_goTypes = nil
+	file_shop_goods_v1_goods_proto_depIdxs = nil
 }
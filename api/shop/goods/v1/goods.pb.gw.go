@@ -1,12 +1,12 @@
 // Code generated by protoc-gen-grpc-gateway. DO NOT EDIT.
-// source: goods.proto
+// source: shop/goods/v1/goods.proto
 
 /*
-Package proto is a reverse proxy.
+Package goodsv1 is a reverse proxy.
 
 It translates gRPC into RESTful JSON APIs.
 */
-package proto
+package goodsv1
 
 import (
 	"context"
@@ -78,7 +78,7 @@ func RegisterGoodsHandlerServer(ctx context.Context, mux *runtime.ServeMux, serv
 		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		var err error
-		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/proto.Goods/GetGoodsByRoom", runtime.WithHTTPPathPattern("/v1/goods"))
+		ctx, err = runtime.AnnotateIncomingContext(ctx, mux, req, "/shop.goods.v1.Goods/GetGoodsByRoom", runtime.WithHTTPPathPattern("/v1/goods"))
 		if err != nil {
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
@@ -141,7 +141,7 @@ func RegisterGoodsHandlerClient(ctx context.Context, mux *runtime.ServeMux, clie
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		var err error
-		ctx, err = runtime.AnnotateContext(ctx, mux, req, "/proto.Goods/GetGoodsByRoom", runtime.WithHTTPPathPattern("/v1/goods"))
+		ctx, err = runtime.AnnotateContext(ctx, mux, req, "/shop.goods.v1.Goods/GetGoodsByRoom", runtime.WithHTTPPathPattern("/v1/goods"))
 		if err != nil {
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
@@ -2,9 +2,9 @@
 // versions:
 // - protoc-gen-go-grpc v1.2.0
 // - protoc             v3.20.1
-// source: goods.proto
+// source: shop/goods/v1/goods.proto
 
-package proto
+package goodsv1
 
 import (
 	context "context"
@@ -36,7 +36,7 @@ func NewGoodsClient(cc grpc.ClientConnInterface) GoodsClient {
 
 func (c *goodsClient) GetGoodsByRoom(ctx context.Context, in *GetGoodsByRoomReq, opts ...grpc.CallOption) (*GoodsListResp, error) {
 	out := new(GoodsListResp)
-	err := c.cc.Invoke(ctx, "/proto.Goods/GetGoodsByRoom", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/shop.goods.v1.Goods/GetGoodsByRoom", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +45,7 @@ func (c *goodsClient) GetGoodsByRoom(ctx context.Context, in *GetGoodsByRoomReq,
 
 func (c *goodsClient) GetGoodsDetail(ctx context.Context, in *GetGoodsDetailReq, opts ...grpc.CallOption) (*GoodsDetail, error) {
 	out := new(GoodsDetail)
-	err := c.cc.Invoke(ctx, "/proto.Goods/GetGoodsDetail", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/shop.goods.v1.Goods/GetGoodsDetail", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +94,7 @@ func _Goods_GetGoodsByRoom_Handler(srv interface{}, ctx context.Context, dec fun
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/proto.Goods/GetGoodsByRoom",
+		FullMethod: "/shop.goods.v1.Goods/GetGoodsByRoom",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GoodsServer).GetGoodsByRoom(ctx, req.(*GetGoodsByRoomReq))
@@ -112,7 +112,7 @@ func _Goods_GetGoodsDetail_Handler(srv interface{}, ctx context.Context, dec fun
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/proto.Goods/GetGoodsDetail",
+		FullMethod: "/shop.goods.v1.Goods/GetGoodsDetail",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(GoodsServer).GetGoodsDetail(ctx, req.(*GetGoodsDetailReq))
@@ -124,7 +124,7 @@ func _Goods_GetGoodsDetail_Handler(srv interface{}, ctx context.Context, dec fun
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var Goods_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "proto.Goods",
+	ServiceName: "shop.goods.v1.Goods",
 	HandlerType: (*GoodsServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
@@ -137,5 +137,5 @@ var Goods_ServiceDesc = grpc.ServiceDesc{
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "goods.proto",
+	Metadata: "shop/goods/v1/goods.proto",
 }
@@ -0,0 +1,575 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.20.1
+// source: shop/order/v1/order.proto
+
+package orderv1
+
+import (
+	context "context"
+	v1 "github.com/idMiFeng/api/shop/deadletter/v1"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// OrderClient is the client API for Order service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OrderClient interface {
+	CreateOrder(ctx context.Context, in *OrderReq, opts ...grpc.CallOption) (*OrderResp, error)
+	OrderList(ctx context.Context, in *OrderListReq, opts ...grpc.CallOption) (*OrderListResp, error)
+	OrderDetail(ctx context.Context, in *OrderDetailReq, opts ...grpc.CallOption) (*OrderDetailInfo, error)
+	UpdateOrderStatus(ctx context.Context, in *OrderStatus, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CancelOrder(ctx context.Context, in *CancelOrderReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Pay(ctx context.Context, in *PayReq, opts ...grpc.CallOption) (*PayResp, error)
+	PayCallback(ctx context.Context, in *PayCallbackReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ApplyRefund(ctx context.Context, in *ApplyRefundReq, opts ...grpc.CallOption) (*RefundInfo, error)
+	AuditRefund(ctx context.Context, in *AuditRefundReq, opts ...grpc.CallOption) (*RefundInfo, error)
+	SubmitReturnLogistics(ctx context.Context, in *ReturnLogisticsReq, opts ...grpc.CallOption) (*RefundInfo, error)
+	ConfirmReturnReceived(ctx context.Context, in *ConfirmReturnReq, opts ...grpc.CallOption) (*RefundInfo, error)
+	RefundDetail(ctx context.Context, in *RefundDetailReq, opts ...grpc.CallOption) (*RefundInfo, error)
+	ListDeadLetter(ctx context.Context, in *v1.ListDeadLetterReq, opts ...grpc.CallOption) (*v1.DeadLetterList, error)
+	ReplayDeadLetter(ctx context.Context, in *v1.ReplayDeadLetterReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type orderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrderClient(cc grpc.ClientConnInterface) OrderClient {
+	return &orderClient{cc}
+}
+
+func (c *orderClient) CreateOrder(ctx context.Context, in *OrderReq, opts ...grpc.CallOption) (*OrderResp, error) {
+	out := new(OrderResp)
+	err := c.cc.Invoke(ctx, "/shop.order.v1.Order/CreateOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderClient) OrderList(ctx context.Context, in *OrderListReq, opts ...grpc.CallOption) (*OrderListResp, error) {
+	out := new(OrderListResp)
+	err := c.cc.Invoke(ctx, "/shop.order.v1.Order/OrderList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderClient) OrderDetail(ctx context.Context, in *OrderDetailReq, opts ...grpc.CallOption) (*OrderDetailInfo, error) {
+	out := new(OrderDetailInfo)
+	err := c.cc.Invoke(ctx, "/shop.order.v1.Order/OrderDetail", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderClient) UpdateOrderStatus(ctx context.Context, in *OrderStatus, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/shop.order.v1.Order/UpdateOrderStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderClient) CancelOrder(ctx context.Context, in *CancelOrderReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/shop.order.v1.Order/CancelOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderClient) Pay(ctx context.Context, in *PayReq, opts ...grpc.CallOption) (*PayResp, error) {
+	out := new(PayResp)
+	err := c.cc.Invoke(ctx, "/shop.order.v1.Order/Pay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderClient) PayCallback(ctx context.Context, in *PayCallbackReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/shop.order.v1.Order/PayCallback", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderClient) ApplyRefund(ctx context.Context, in *ApplyRefundReq, opts ...grpc.CallOption) (*RefundInfo, error) {
+	out := new(RefundInfo)
+	err := c.cc.Invoke(ctx, "/shop.order.v1.Order/ApplyRefund", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderClient) AuditRefund(ctx context.Context, in *AuditRefundReq, opts ...grpc.CallOption) (*RefundInfo, error) {
+	out := new(RefundInfo)
+	err := c.cc.Invoke(ctx, "/shop.order.v1.Order/AuditRefund", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderClient) SubmitReturnLogistics(ctx context.Context, in *ReturnLogisticsReq, opts ...grpc.CallOption) (*RefundInfo, error) {
+	out := new(RefundInfo)
+	err := c.cc.Invoke(ctx, "/shop.order.v1.Order/SubmitReturnLogistics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderClient) ConfirmReturnReceived(ctx context.Context, in *ConfirmReturnReq, opts ...grpc.CallOption) (*RefundInfo, error) {
+	out := new(RefundInfo)
+	err := c.cc.Invoke(ctx, "/shop.order.v1.Order/ConfirmReturnReceived", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderClient) RefundDetail(ctx context.Context, in *RefundDetailReq, opts ...grpc.CallOption) (*RefundInfo, error) {
+	out := new(RefundInfo)
+	err := c.cc.Invoke(ctx, "/shop.order.v1.Order/RefundDetail", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderClient) ListDeadLetter(ctx context.Context, in *v1.ListDeadLetterReq, opts ...grpc.CallOption) (*v1.DeadLetterList, error) {
+	out := new(v1.DeadLetterList)
+	err := c.cc.Invoke(ctx, "/shop.order.v1.Order/ListDeadLetter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderClient) ReplayDeadLetter(ctx context.Context, in *v1.ReplayDeadLetterReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/shop.order.v1.Order/ReplayDeadLetter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrderServer is the server API for Order service.
+// All implementations must embed UnimplementedOrderServer
+// for forward compatibility
+type OrderServer interface {
+	CreateOrder(context.Context, *OrderReq) (*OrderResp, error)
+	OrderList(context.Context, *OrderListReq) (*OrderListResp, error)
+	OrderDetail(context.Context, *OrderDetailReq) (*OrderDetailInfo, error)
+	UpdateOrderStatus(context.Context, *OrderStatus) (*emptypb.Empty, error)
+	CancelOrder(context.Context, *CancelOrderReq) (*emptypb.Empty, error)
+	Pay(context.Context, *PayReq) (*PayResp, error)
+	PayCallback(context.Context, *PayCallbackReq) (*emptypb.Empty, error)
+	ApplyRefund(context.Context, *ApplyRefundReq) (*RefundInfo, error)
+	AuditRefund(context.Context, *AuditRefundReq) (*RefundInfo, error)
+	SubmitReturnLogistics(context.Context, *ReturnLogisticsReq) (*RefundInfo, error)
+	ConfirmReturnReceived(context.Context, *ConfirmReturnReq) (*RefundInfo, error)
+	RefundDetail(context.Context, *RefundDetailReq) (*RefundInfo, error)
+	ListDeadLetter(context.Context, *v1.ListDeadLetterReq) (*v1.DeadLetterList, error)
+	ReplayDeadLetter(context.Context, *v1.ReplayDeadLetterReq) (*emptypb.Empty, error)
+	mustEmbedUnimplementedOrderServer()
+}
+
+// UnimplementedOrderServer must be embedded to have forward compatible implementations.
+type UnimplementedOrderServer struct {
+}
+
+func (UnimplementedOrderServer) CreateOrder(context.Context, *OrderReq) (*OrderResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
+}
+func (UnimplementedOrderServer) OrderList(context.Context, *OrderListReq) (*OrderListResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OrderList not implemented")
+}
+func (UnimplementedOrderServer) OrderDetail(context.Context, *OrderDetailReq) (*OrderDetailInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OrderDetail not implemented")
+}
+func (UnimplementedOrderServer) UpdateOrderStatus(context.Context, *OrderStatus) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrderStatus not implemented")
+}
+func (UnimplementedOrderServer) CancelOrder(context.Context, *CancelOrderReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedOrderServer) Pay(context.Context, *PayReq) (*PayResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pay not implemented")
+}
+func (UnimplementedOrderServer) PayCallback(context.Context, *PayCallbackReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PayCallback not implemented")
+}
+func (UnimplementedOrderServer) ApplyRefund(context.Context, *ApplyRefundReq) (*RefundInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyRefund not implemented")
+}
+func (UnimplementedOrderServer) AuditRefund(context.Context, *AuditRefundReq) (*RefundInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuditRefund not implemented")
+}
+func (UnimplementedOrderServer) SubmitReturnLogistics(context.Context, *ReturnLogisticsReq) (*RefundInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitReturnLogistics not implemented")
+}
+func (UnimplementedOrderServer) ConfirmReturnReceived(context.Context, *ConfirmReturnReq) (*RefundInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmReturnReceived not implemented")
+}
+func (UnimplementedOrderServer) RefundDetail(context.Context, *RefundDetailReq) (*RefundInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundDetail not implemented")
+}
+func (UnimplementedOrderServer) ListDeadLetter(context.Context, *v1.ListDeadLetterReq) (*v1.DeadLetterList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetter not implemented")
+}
+func (UnimplementedOrderServer) ReplayDeadLetter(context.Context, *v1.ReplayDeadLetterReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayDeadLetter not implemented")
+}
+func (UnimplementedOrderServer) mustEmbedUnimplementedOrderServer() {}
+
+// UnsafeOrderServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrderServer will
+// result in compilation errors.
+type UnsafeOrderServer interface {
+	mustEmbedUnimplementedOrderServer()
+}
+
+func RegisterOrderServer(s grpc.ServiceRegistrar, srv OrderServer) {
+	s.RegisterService(&Order_ServiceDesc, srv)
+}
+
+func _Order_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).CreateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.order.v1.Order/CreateOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).CreateOrder(ctx, req.(*OrderReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Order_OrderList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).OrderList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.order.v1.Order/OrderList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).OrderList(ctx, req.(*OrderListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Order_OrderDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderDetailReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).OrderDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.order.v1.Order/OrderDetail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).OrderDetail(ctx, req.(*OrderDetailReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Order_UpdateOrderStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderStatus)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).UpdateOrderStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.order.v1.Order/UpdateOrderStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).UpdateOrderStatus(ctx, req.(*OrderStatus))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Order_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.order.v1.Order/CancelOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).CancelOrder(ctx, req.(*CancelOrderReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Order_Pay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PayReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).Pay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.order.v1.Order/Pay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).Pay(ctx, req.(*PayReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Order_PayCallback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PayCallbackReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).PayCallback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.order.v1.Order/PayCallback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).PayCallback(ctx, req.(*PayCallbackReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Order_ApplyRefund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyRefundReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).ApplyRefund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.order.v1.Order/ApplyRefund",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).ApplyRefund(ctx, req.(*ApplyRefundReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Order_AuditRefund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditRefundReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).AuditRefund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.order.v1.Order/AuditRefund",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).AuditRefund(ctx, req.(*AuditRefundReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Order_SubmitReturnLogistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReturnLogisticsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).SubmitReturnLogistics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.order.v1.Order/SubmitReturnLogistics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).SubmitReturnLogistics(ctx, req.(*ReturnLogisticsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Order_ConfirmReturnReceived_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmReturnReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).ConfirmReturnReceived(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.order.v1.Order/ConfirmReturnReceived",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).ConfirmReturnReceived(ctx, req.(*ConfirmReturnReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Order_RefundDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundDetailReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).RefundDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.order.v1.Order/RefundDetail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).RefundDetail(ctx, req.(*RefundDetailReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Order_ListDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1.ListDeadLetterReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).ListDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.order.v1.Order/ListDeadLetter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).ListDeadLetter(ctx, req.(*v1.ListDeadLetterReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Order_ReplayDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1.ReplayDeadLetterReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).ReplayDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.order.v1.Order/ReplayDeadLetter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).ReplayDeadLetter(ctx, req.(*v1.ReplayDeadLetterReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Order_ServiceDesc is the grpc.ServiceDesc for Order service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Order_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "shop.order.v1.Order",
+	HandlerType: (*OrderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateOrder",
+			Handler:    _Order_CreateOrder_Handler,
+		},
+		{
+			MethodName: "OrderList",
+			Handler:    _Order_OrderList_Handler,
+		},
+		{
+			MethodName: "OrderDetail",
+			Handler:    _Order_OrderDetail_Handler,
+		},
+		{
+			MethodName: "UpdateOrderStatus",
+			Handler:    _Order_UpdateOrderStatus_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _Order_CancelOrder_Handler,
+		},
+		{
+			MethodName: "Pay",
+			Handler:    _Order_Pay_Handler,
+		},
+		{
+			MethodName: "PayCallback",
+			Handler:    _Order_PayCallback_Handler,
+		},
+		{
+			MethodName: "ApplyRefund",
+			Handler:    _Order_ApplyRefund_Handler,
+		},
+		{
+			MethodName: "AuditRefund",
+			Handler:    _Order_AuditRefund_Handler,
+		},
+		{
+			MethodName: "SubmitReturnLogistics",
+			Handler:    _Order_SubmitReturnLogistics_Handler,
+		},
+		{
+			MethodName: "ConfirmReturnReceived",
+			Handler:    _Order_ConfirmReturnReceived_Handler,
+		},
+		{
+			MethodName: "RefundDetail",
+			Handler:    _Order_RefundDetail_Handler,
+		},
+		{
+			MethodName: "ListDeadLetter",
+			Handler:    _Order_ListDeadLetter_Handler,
+		},
+		{
+			MethodName: "ReplayDeadLetter",
+			Handler:    _Order_ReplayDeadLetter_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "shop/order/v1/order.proto",
+}
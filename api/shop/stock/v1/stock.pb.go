@@ -0,0 +1,846 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.20.1
+// source: shop/stock/v1/stock.proto
+
+package stockv1
+
+import (
+	_ "github.com/idMiFeng/api/shop/auth/v1"
+	v1 "github.com/idMiFeng/api/shop/deadletter/v1"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GoodsStockInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GoodsId int64 `protobuf:"varint,1,opt,name=goodsId,proto3" json:"goodsId,omitempty"`
+	Num     int64 `protobuf:"varint,2,opt,name=num,proto3" json:"num,omitempty"`
+	OrderId int64 `protobuf:"varint,3,opt,name=OrderId,proto3" json:"OrderId,omitempty"`
+}
+
+func (x *GoodsStockInfo) Reset() {
+	*x = GoodsStockInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_shop_stock_v1_stock_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GoodsStockInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoodsStockInfo) ProtoMessage() {}
+
+func (x *GoodsStockInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_stock_v1_stock_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoodsStockInfo.ProtoReflect.Descriptor instead.
+func (*GoodsStockInfo) Descriptor() ([]byte, []int) {
+	return file_shop_stock_v1_stock_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GoodsStockInfo) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *GoodsStockInfo) GetNum() int64 {
+	if x != nil {
+		return x.Num
+	}
+	return 0
+}
+
+func (x *GoodsStockInfo) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+type StockInfoList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []*GoodsStockInfo `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *StockInfoList) Reset() {
+	*x = StockInfoList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_shop_stock_v1_stock_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StockInfoList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockInfoList) ProtoMessage() {}
+
+func (x *StockInfoList) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_stock_v1_stock_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockInfoList.ProtoReflect.Descriptor instead.
+func (*StockInfoList) Descriptor() ([]byte, []int) {
+	return file_shop_stock_v1_stock_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StockInfoList) GetData() []*GoodsStockInfo {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ReturnStockReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RefundId int64 `protobuf:"varint,1,opt,name=refundId,proto3" json:"refundId,omitempty"` // 售后单id，用于幂等
+	OrderId  int64 `protobuf:"varint,2,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	GoodsId  int64 `protobuf:"varint,3,opt,name=goodsId,proto3" json:"goodsId,omitempty"`
+	Num      int64 `protobuf:"varint,4,opt,name=num,proto3" json:"num,omitempty"`
+}
+
+func (x *ReturnStockReq) Reset() {
+	*x = ReturnStockReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_shop_stock_v1_stock_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReturnStockReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnStockReq) ProtoMessage() {}
+
+func (x *ReturnStockReq) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_stock_v1_stock_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnStockReq.ProtoReflect.Descriptor instead.
+func (*ReturnStockReq) Descriptor() ([]byte, []int) {
+	return file_shop_stock_v1_stock_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReturnStockReq) GetRefundId() int64 {
+	if x != nil {
+		return x.RefundId
+	}
+	return 0
+}
+
+func (x *ReturnStockReq) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *ReturnStockReq) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *ReturnStockReq) GetNum() int64 {
+	if x != nil {
+		return x.Num
+	}
+	return 0
+}
+
+type ReserveReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxId    string `protobuf:"bytes,1,opt,name=txId,proto3" json:"txId,omitempty"` // 全局事务id
+	OrderId int64  `protobuf:"varint,2,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	GoodsId int64  `protobuf:"varint,3,opt,name=goodsId,proto3" json:"goodsId,omitempty"`
+	Num     int64  `protobuf:"varint,4,opt,name=num,proto3" json:"num,omitempty"` // Confirm和Cancel时不需要，按Try的记录处理
+}
+
+func (x *ReserveReq) Reset() {
+	*x = ReserveReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_shop_stock_v1_stock_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReserveReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveReq) ProtoMessage() {}
+
+func (x *ReserveReq) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_stock_v1_stock_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveReq.ProtoReflect.Descriptor instead.
+func (*ReserveReq) Descriptor() ([]byte, []int) {
+	return file_shop_stock_v1_stock_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReserveReq) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *ReserveReq) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *ReserveReq) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *ReserveReq) GetNum() int64 {
+	if x != nil {
+		return x.Num
+	}
+	return 0
+}
+
+type ListStockRecordReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GoodsId  int64  `protobuf:"varint,1,opt,name=goodsId,proto3" json:"goodsId,omitempty"` // 以下条件为空时不过滤，至少指定一个
+	OrderId  int64  `protobuf:"varint,2,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	TxId     string `protobuf:"bytes,3,opt,name=txId,proto3" json:"txId,omitempty"`
+	Status   int32  `protobuf:"varint,4,opt,name=status,proto3" json:"status,omitempty"` // 1预扣减 2扣减 3已回滚
+	PageNum  int32  `protobuf:"varint,5,opt,name=pageNum,proto3" json:"pageNum,omitempty"`
+	PageSize int32  `protobuf:"varint,6,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+}
+
+func (x *ListStockRecordReq) Reset() {
+	*x = ListStockRecordReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_shop_stock_v1_stock_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListStockRecordReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockRecordReq) ProtoMessage() {}
+
+func (x *ListStockRecordReq) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_stock_v1_stock_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockRecordReq.ProtoReflect.Descriptor instead.
+func (*ListStockRecordReq) Descriptor() ([]byte, []int) {
+	return file_shop_stock_v1_stock_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListStockRecordReq) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *ListStockRecordReq) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *ListStockRecordReq) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *ListStockRecordReq) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ListStockRecordReq) GetPageNum() int32 {
+	if x != nil {
+		return x.PageNum
+	}
+	return 0
+}
+
+func (x *ListStockRecordReq) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type StockRecordInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TxId     string `protobuf:"bytes,2,opt,name=txId,proto3" json:"txId,omitempty"`
+	OrderId  int64  `protobuf:"varint,3,opt,name=orderId,proto3" json:"orderId,omitempty"`
+	GoodsId  int64  `protobuf:"varint,4,opt,name=goodsId,proto3" json:"goodsId,omitempty"`
+	Num      int64  `protobuf:"varint,5,opt,name=num,proto3" json:"num,omitempty"`
+	Status   int32  `protobuf:"varint,6,opt,name=status,proto3" json:"status,omitempty"`
+	CreateAt int64  `protobuf:"varint,7,opt,name=createAt,proto3" json:"createAt,omitempty"` // 毫秒时间戳
+	UpdateAt int64  `protobuf:"varint,8,opt,name=updateAt,proto3" json:"updateAt,omitempty"` // 毫秒时间戳
+}
+
+func (x *StockRecordInfo) Reset() {
+	*x = StockRecordInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_shop_stock_v1_stock_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StockRecordInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockRecordInfo) ProtoMessage() {}
+
+func (x *StockRecordInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_stock_v1_stock_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockRecordInfo.ProtoReflect.Descriptor instead.
+func (*StockRecordInfo) Descriptor() ([]byte, []int) {
+	return file_shop_stock_v1_stock_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StockRecordInfo) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *StockRecordInfo) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *StockRecordInfo) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *StockRecordInfo) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *StockRecordInfo) GetNum() int64 {
+	if x != nil {
+		return x.Num
+	}
+	return 0
+}
+
+func (x *StockRecordInfo) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *StockRecordInfo) GetCreateAt() int64 {
+	if x != nil {
+		return x.CreateAt
+	}
+	return 0
+}
+
+func (x *StockRecordInfo) GetUpdateAt() int64 {
+	if x != nil {
+		return x.UpdateAt
+	}
+	return 0
+}
+
+type StockRecordList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total int32              `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Data  []*StockRecordInfo `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *StockRecordList) Reset() {
+	*x = StockRecordList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_shop_stock_v1_stock_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StockRecordList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockRecordList) ProtoMessage() {}
+
+func (x *StockRecordList) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_stock_v1_stock_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockRecordList.ProtoReflect.Descriptor instead.
+func (*StockRecordList) Descriptor() ([]byte, []int) {
+	return file_shop_stock_v1_stock_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StockRecordList) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *StockRecordList) GetData() []*StockRecordInfo {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_shop_stock_v1_stock_proto protoreflect.FileDescriptor
+
+var file_shop_stock_v1_stock_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x73, 0x68, 0x6f, 0x70, 0x2f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2f, 0x76, 0x31, 0x2f,
+	0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x73, 0x68, 0x6f,
+	0x70, 0x2e, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74,
+	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x73, 0x68, 0x6f, 0x70, 0x2f, 0x61, 0x75, 0x74, 0x68,
+	0x2f, 0x76, 0x31, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x24,
+	0x73, 0x68, 0x6f, 0x70, 0x2f, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x2f,
+	0x76, 0x31, 0x2f, 0x64, 0x65, 0x61, 0x64, 0x5f, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x56, 0x0a, 0x0e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x6f,
+	0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64,
+	0x12, 0x10, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6e,
+	0x75, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x42, 0x0a, 0x0d,
+	0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x31, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x68,
+	0x6f, 0x70, 0x2e, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x6f, 0x64,
+	0x73, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0x72, 0x0a, 0x0e, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52,
+	0x65, 0x71, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x6f, 0x6f, 0x64,
+	0x73, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73,
+	0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x6e, 0x75, 0x6d, 0x22, 0x66, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52,
+	0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x78, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6e, 0x75,
+	0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6e, 0x75, 0x6d, 0x22, 0xaa, 0x01, 0x0a,
+	0x12, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x49, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70, 0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0xcb, 0x01, 0x0a, 0x0f, 0x53, 0x74,
+	0x6f, 0x63, 0x6b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x78, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x49,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x67,
+	0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x67, 0x6f,
+	0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x03, 0x6e, 0x75, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x74, 0x22, 0x5b, 0x0a, 0x0f, 0x53, 0x74, 0x6f, 0x63, 0x6b,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x12, 0x32, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x32, 0xea, 0x09, 0x0a, 0x05, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x7b,
+	0x0a, 0x08, 0x53, 0x65, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x1d, 0x2e, 0x73, 0x68, 0x6f,
+	0x70, 0x2e, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73,
+	0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x22, 0x38, 0xa2, 0xbb, 0x18, 0x16, 0x22, 0x10, 0x12, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73,
+	0x49, 0x64, 0x0a, 0x05, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x1a, 0x02, 0x04, 0x02, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x18, 0x1a, 0x13, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2f, 0x7b,
+	0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x7d, 0x3a, 0x01, 0x2a, 0x12, 0x65, 0x0a, 0x08, 0x47,
+	0x65, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x1d, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x73,
+	0x74, 0x6f, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x6f,
+	0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x1d, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x73, 0x74,
+	0x6f, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x6f, 0x63,
+	0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x12, 0x13, 0x2f,
+	0x76, 0x31, 0x2f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2f, 0x7b, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49,
+	0x64, 0x7d, 0x12, 0x4c, 0x0a, 0x0b, 0x52, 0x65, 0x64, 0x75, 0x63, 0x65, 0x53, 0x74, 0x6f, 0x63,
+	0x6b, 0x12, 0x1d, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f,
+	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0xa2, 0xbb, 0x18, 0x02, 0x10, 0x01,
+	0x12, 0x67, 0x0a, 0x0d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x53, 0x74, 0x6f, 0x63,
+	0x6b, 0x12, 0x1c, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x1a, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x14, 0x3a, 0x01, 0x2a, 0x22, 0x0f, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74,
+	0x6f, 0x63, 0x6b, 0x2f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x12, 0x56, 0x0a, 0x10, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x64, 0x75, 0x63, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x1c, 0x2e,
+	0x73, 0x68, 0x6f, 0x70, 0x2e, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74,
+	0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x73, 0x68,
+	0x6f, 0x70, 0x2e, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x63,
+	0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x06, 0xa2, 0xbb, 0x18, 0x02, 0x10,
+	0x01, 0x12, 0x4e, 0x0a, 0x0d, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x53, 0x74, 0x6f,
+	0x63, 0x6b, 0x12, 0x1d, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66,
+	0x6f, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0xa2, 0xbb, 0x18, 0x02, 0x10,
+	0x01, 0x12, 0x4c, 0x0a, 0x0b, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x53, 0x74, 0x6f, 0x63, 0x6b,
+	0x12, 0x1d, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x1a,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0xa2, 0xbb, 0x18, 0x02, 0x10, 0x01, 0x12,
+	0x47, 0x0a, 0x0a, 0x54, 0x72, 0x79, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x12, 0x19, 0x2e,
+	0x73, 0x68, 0x6f, 0x70, 0x2e, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x22, 0x06, 0xa2, 0xbb, 0x18, 0x02, 0x10, 0x01, 0x12, 0x4b, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x72, 0x6d, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x12, 0x19, 0x2e, 0x73, 0x68, 0x6f,
+	0x70, 0x2e, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0xa2,
+	0xbb, 0x18, 0x02, 0x10, 0x01, 0x12, 0x4a, 0x0a, 0x0d, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x12, 0x19, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x73, 0x74,
+	0x6f, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x65,
+	0x71, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x06, 0xa2, 0xbb, 0x18, 0x02, 0x10,
+	0x01, 0x12, 0xa9, 0x01, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x21, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x73, 0x74, 0x6f,
+	0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x1a, 0x1e, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e,
+	0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x53, 0xa2, 0xbb, 0x18, 0x17, 0x22, 0x10,
+	0x0a, 0x05, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x12, 0x07, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64,
+	0x1a, 0x03, 0x04, 0x03, 0x02, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x32, 0x5a, 0x13, 0x12, 0x11, 0x2f,
+	0x76, 0x31, 0x2f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73,
+	0x12, 0x1b, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2f, 0x7b, 0x67, 0x6f, 0x6f,
+	0x64, 0x73, 0x49, 0x64, 0x7d, 0x2f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x64, 0x0a,
+	0x0e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x12,
+	0x25, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x65, 0x74, 0x74, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74,
+	0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x22, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x64, 0x65,
+	0x61, 0x64, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x61, 0x64,
+	0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x07, 0xa2, 0xbb, 0x18, 0x03,
+	0x1a, 0x01, 0x05, 0x12, 0x5c, 0x0a, 0x10, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x44, 0x65, 0x61,
+	0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x12, 0x27, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x64,
+	0x65, 0x61, 0x64, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70,
+	0x6c, 0x61, 0x79, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x07, 0xa2, 0xbb, 0x18, 0x03, 0x1a, 0x01,
+	0x05, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x69, 0x64, 0x4d, 0x69, 0x46, 0x65, 0x6e, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x68, 0x6f,
+	0x70, 0x2f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x2f, 0x76, 0x31, 0x3b, 0x73, 0x74, 0x6f, 0x63, 0x6b,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_shop_stock_v1_stock_proto_rawDescOnce sync.Once
+	file_shop_stock_v1_stock_proto_rawDescData = file_shop_stock_v1_stock_proto_rawDesc
+)
+
+func file_shop_stock_v1_stock_proto_rawDescGZIP() []byte {
+	file_shop_stock_v1_stock_proto_rawDescOnce.Do(func() {
+		file_shop_stock_v1_stock_proto_rawDescData = protoimpl.X.CompressGZIP(file_shop_stock_v1_stock_proto_rawDescData)
+	})
+	return file_shop_stock_v1_stock_proto_rawDescData
+}
+
+var file_shop_stock_v1_stock_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_shop_stock_v1_stock_proto_goTypes = []interface{}{
+	(*GoodsStockInfo)(nil),         // 0: shop.stock.v1.GoodsStockInfo
+	(*StockInfoList)(nil),          // 1: shop.stock.v1.StockInfoList
+	(*ReturnStockReq)(nil),         // 2: shop.stock.v1.ReturnStockReq
+	(*ReserveReq)(nil),             // 3: shop.stock.v1.ReserveReq
+	(*ListStockRecordReq)(nil),     // 4: shop.stock.v1.ListStockRecordReq
+	(*StockRecordInfo)(nil),        // 5: shop.stock.v1.StockRecordInfo
+	(*StockRecordList)(nil),        // 6: shop.stock.v1.StockRecordList
+	(*v1.ListDeadLetterReq)(nil),   // 7: shop.deadletter.v1.ListDeadLetterReq
+	(*v1.ReplayDeadLetterReq)(nil), // 8: shop.deadletter.v1.ReplayDeadLetterReq
+	(*emptypb.Empty)(nil),          // 9: google.protobuf.Empty
+	(*v1.DeadLetterList)(nil),      // 10: shop.deadletter.v1.DeadLetterList
+}
+var file_shop_stock_v1_stock_proto_depIdxs = []int32{
+	0,  // 0: shop.stock.v1.StockInfoList.data:type_name -> shop.stock.v1.GoodsStockInfo
+	5,  // 1: shop.stock.v1.StockRecordList.data:type_name -> shop.stock.v1.StockRecordInfo
+	0,  // 2: shop.stock.v1.stock.SetStock:input_type -> shop.stock.v1.GoodsStockInfo
+	0,  // 3: shop.stock.v1.stock.GetStock:input_type -> shop.stock.v1.GoodsStockInfo
+	0,  // 4: shop.stock.v1.stock.ReduceStock:input_type -> shop.stock.v1.GoodsStockInfo
+	1,  // 5: shop.stock.v1.stock.BatchGetStock:input_type -> shop.stock.v1.StockInfoList
+	1,  // 6: shop.stock.v1.stock.BatchReduceStock:input_type -> shop.stock.v1.StockInfoList
+	0,  // 7: shop.stock.v1.stock.RollbackStock:input_type -> shop.stock.v1.GoodsStockInfo
+	2,  // 8: shop.stock.v1.stock.ReturnStock:input_type -> shop.stock.v1.ReturnStockReq
+	3,  // 9: shop.stock.v1.stock.TryReserve:input_type -> shop.stock.v1.ReserveReq
+	3,  // 10: shop.stock.v1.stock.ConfirmReserve:input_type -> shop.stock.v1.ReserveReq
+	3,  // 11: shop.stock.v1.stock.CancelReserve:input_type -> shop.stock.v1.ReserveReq
+	4,  // 12: shop.stock.v1.stock.ListStockRecord:input_type -> shop.stock.v1.ListStockRecordReq
+	7,  // 13: shop.stock.v1.stock.ListDeadLetter:input_type -> shop.deadletter.v1.ListDeadLetterReq
+	8,  // 14: shop.stock.v1.stock.ReplayDeadLetter:input_type -> shop.deadletter.v1.ReplayDeadLetterReq
+	9,  // 15: shop.stock.v1.stock.SetStock:output_type -> google.protobuf.Empty
+	0,  // 16: shop.stock.v1.stock.GetStock:output_type -> shop.stock.v1.GoodsStockInfo
+	9,  // 17: shop.stock.v1.stock.ReduceStock:output_type -> google.protobuf.Empty
+	1,  // 18: shop.stock.v1.stock.BatchGetStock:output_type -> shop.stock.v1.StockInfoList
+	1,  // 19: shop.stock.v1.stock.BatchReduceStock:output_type -> shop.stock.v1.StockInfoList
+	9,  // 20: shop.stock.v1.stock.RollbackStock:output_type -> google.protobuf.Empty
+	9,  // 21: shop.stock.v1.stock.ReturnStock:output_type -> google.protobuf.Empty
+	9,  // 22: shop.stock.v1.stock.TryReserve:output_type -> google.protobuf.Empty
+	9,  // 23: shop.stock.v1.stock.ConfirmReserve:output_type -> google.protobuf.Empty
+	9,  // 24: shop.stock.v1.stock.CancelReserve:output_type -> google.protobuf.Empty
+	6,  // 25: shop.stock.v1.stock.ListStockRecord:output_type -> shop.stock.v1.StockRecordList
+	10, // 26: shop.stock.v1.stock.ListDeadLetter:output_type -> shop.deadletter.v1.DeadLetterList
+	9,  // 27: shop.stock.v1.stock.ReplayDeadLetter:output_type -> google.protobuf.Empty
+	15, // [15:28] is the sub-list for method output_type
+	2,  // [2:15] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_shop_stock_v1_stock_proto_init() }
+func file_shop_stock_v1_stock_proto_init() {
+	if File_shop_stock_v1_stock_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_shop_stock_v1_stock_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GoodsStockInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_shop_stock_v1_stock_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StockInfoList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_shop_stock_v1_stock_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReturnStockReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_shop_stock_v1_stock_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReserveReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_shop_stock_v1_stock_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListStockRecordReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_shop_stock_v1_stock_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StockRecordInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_shop_stock_v1_stock_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StockRecordList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_shop_stock_v1_stock_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_shop_stock_v1_stock_proto_goTypes,
+		DependencyIndexes: file_shop_stock_v1_stock_proto_depIdxs,
+		MessageInfos:      file_shop_stock_v1_stock_proto_msgTypes,
+	}.Build()
+	File_shop_stock_v1_stock_proto = out.File
+	file_shop_stock_v1_stock_proto_rawDesc = nil
+	file_shop_stock_v1_stock_proto_goTypes = nil
+	file_shop_stock_v1_stock_proto_depIdxs = nil
+}
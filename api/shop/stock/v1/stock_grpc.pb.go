@@ -0,0 +1,543 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.20.1
+// source: shop/stock/v1/stock.proto
+
+package stockv1
+
+import (
+	context "context"
+	v1 "github.com/idMiFeng/api/shop/deadletter/v1"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// StockClient is the client API for Stock service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StockClient interface {
+	SetStock(ctx context.Context, in *GoodsStockInfo, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetStock(ctx context.Context, in *GoodsStockInfo, opts ...grpc.CallOption) (*GoodsStockInfo, error)
+	ReduceStock(ctx context.Context, in *GoodsStockInfo, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	BatchGetStock(ctx context.Context, in *StockInfoList, opts ...grpc.CallOption) (*StockInfoList, error)
+	BatchReduceStock(ctx context.Context, in *StockInfoList, opts ...grpc.CallOption) (*StockInfoList, error)
+	RollbackStock(ctx context.Context, in *GoodsStockInfo, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ReturnStock(ctx context.Context, in *ReturnStockReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// TCC方式扣减库存，按全局事务id幂等
+	TryReserve(ctx context.Context, in *ReserveReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ConfirmReserve(ctx context.Context, in *ReserveReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CancelReserve(ctx context.Context, in *ReserveReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// 库存流水，即xx_stock_record中的扣减记录
+	ListStockRecord(ctx context.Context, in *ListStockRecordReq, opts ...grpc.CallOption) (*StockRecordList, error)
+	ListDeadLetter(ctx context.Context, in *v1.ListDeadLetterReq, opts ...grpc.CallOption) (*v1.DeadLetterList, error)
+	ReplayDeadLetter(ctx context.Context, in *v1.ReplayDeadLetterReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type stockClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStockClient(cc grpc.ClientConnInterface) StockClient {
+	return &stockClient{cc}
+}
+
+func (c *stockClient) SetStock(ctx context.Context, in *GoodsStockInfo, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/shop.stock.v1.stock/SetStock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) GetStock(ctx context.Context, in *GoodsStockInfo, opts ...grpc.CallOption) (*GoodsStockInfo, error) {
+	out := new(GoodsStockInfo)
+	err := c.cc.Invoke(ctx, "/shop.stock.v1.stock/GetStock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) ReduceStock(ctx context.Context, in *GoodsStockInfo, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/shop.stock.v1.stock/ReduceStock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) BatchGetStock(ctx context.Context, in *StockInfoList, opts ...grpc.CallOption) (*StockInfoList, error) {
+	out := new(StockInfoList)
+	err := c.cc.Invoke(ctx, "/shop.stock.v1.stock/BatchGetStock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) BatchReduceStock(ctx context.Context, in *StockInfoList, opts ...grpc.CallOption) (*StockInfoList, error) {
+	out := new(StockInfoList)
+	err := c.cc.Invoke(ctx, "/shop.stock.v1.stock/BatchReduceStock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) RollbackStock(ctx context.Context, in *GoodsStockInfo, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/shop.stock.v1.stock/RollbackStock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) ReturnStock(ctx context.Context, in *ReturnStockReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/shop.stock.v1.stock/ReturnStock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) TryReserve(ctx context.Context, in *ReserveReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/shop.stock.v1.stock/TryReserve", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) ConfirmReserve(ctx context.Context, in *ReserveReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/shop.stock.v1.stock/ConfirmReserve", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) CancelReserve(ctx context.Context, in *ReserveReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/shop.stock.v1.stock/CancelReserve", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) ListStockRecord(ctx context.Context, in *ListStockRecordReq, opts ...grpc.CallOption) (*StockRecordList, error) {
+	out := new(StockRecordList)
+	err := c.cc.Invoke(ctx, "/shop.stock.v1.stock/ListStockRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) ListDeadLetter(ctx context.Context, in *v1.ListDeadLetterReq, opts ...grpc.CallOption) (*v1.DeadLetterList, error) {
+	out := new(v1.DeadLetterList)
+	err := c.cc.Invoke(ctx, "/shop.stock.v1.stock/ListDeadLetter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockClient) ReplayDeadLetter(ctx context.Context, in *v1.ReplayDeadLetterReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/shop.stock.v1.stock/ReplayDeadLetter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StockServer is the server API for Stock service.
+// All implementations must embed UnimplementedStockServer
+// for forward compatibility
+type StockServer interface {
+	SetStock(context.Context, *GoodsStockInfo) (*emptypb.Empty, error)
+	GetStock(context.Context, *GoodsStockInfo) (*GoodsStockInfo, error)
+	ReduceStock(context.Context, *GoodsStockInfo) (*emptypb.Empty, error)
+	BatchGetStock(context.Context, *StockInfoList) (*StockInfoList, error)
+	BatchReduceStock(context.Context, *StockInfoList) (*StockInfoList, error)
+	RollbackStock(context.Context, *GoodsStockInfo) (*emptypb.Empty, error)
+	ReturnStock(context.Context, *ReturnStockReq) (*emptypb.Empty, error)
+	// TCC方式扣减库存，按全局事务id幂等
+	TryReserve(context.Context, *ReserveReq) (*emptypb.Empty, error)
+	ConfirmReserve(context.Context, *ReserveReq) (*emptypb.Empty, error)
+	CancelReserve(context.Context, *ReserveReq) (*emptypb.Empty, error)
+	// 库存流水，即xx_stock_record中的扣减记录
+	ListStockRecord(context.Context, *ListStockRecordReq) (*StockRecordList, error)
+	ListDeadLetter(context.Context, *v1.ListDeadLetterReq) (*v1.DeadLetterList, error)
+	ReplayDeadLetter(context.Context, *v1.ReplayDeadLetterReq) (*emptypb.Empty, error)
+	mustEmbedUnimplementedStockServer()
+}
+
+// UnimplementedStockServer must be embedded to have forward compatible implementations.
+type UnimplementedStockServer struct {
+}
+
+func (UnimplementedStockServer) SetStock(context.Context, *GoodsStockInfo) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetStock not implemented")
+}
+func (UnimplementedStockServer) GetStock(context.Context, *GoodsStockInfo) (*GoodsStockInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStock not implemented")
+}
+func (UnimplementedStockServer) ReduceStock(context.Context, *GoodsStockInfo) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReduceStock not implemented")
+}
+func (UnimplementedStockServer) BatchGetStock(context.Context, *StockInfoList) (*StockInfoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetStock not implemented")
+}
+func (UnimplementedStockServer) BatchReduceStock(context.Context, *StockInfoList) (*StockInfoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchReduceStock not implemented")
+}
+func (UnimplementedStockServer) RollbackStock(context.Context, *GoodsStockInfo) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackStock not implemented")
+}
+func (UnimplementedStockServer) ReturnStock(context.Context, *ReturnStockReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReturnStock not implemented")
+}
+func (UnimplementedStockServer) TryReserve(context.Context, *ReserveReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TryReserve not implemented")
+}
+func (UnimplementedStockServer) ConfirmReserve(context.Context, *ReserveReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmReserve not implemented")
+}
+func (UnimplementedStockServer) CancelReserve(context.Context, *ReserveReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelReserve not implemented")
+}
+func (UnimplementedStockServer) ListStockRecord(context.Context, *ListStockRecordReq) (*StockRecordList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStockRecord not implemented")
+}
+func (UnimplementedStockServer) ListDeadLetter(context.Context, *v1.ListDeadLetterReq) (*v1.DeadLetterList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetter not implemented")
+}
+func (UnimplementedStockServer) ReplayDeadLetter(context.Context, *v1.ReplayDeadLetterReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayDeadLetter not implemented")
+}
+func (UnimplementedStockServer) mustEmbedUnimplementedStockServer() {}
+
+// UnsafeStockServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StockServer will
+// result in compilation errors.
+type UnsafeStockServer interface {
+	mustEmbedUnimplementedStockServer()
+}
+
+func RegisterStockServer(s grpc.ServiceRegistrar, srv StockServer) {
+	s.RegisterService(&Stock_ServiceDesc, srv)
+}
+
+func _Stock_SetStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GoodsStockInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).SetStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.stock.v1.stock/SetStock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).SetStock(ctx, req.(*GoodsStockInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_GetStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GoodsStockInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).GetStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.stock.v1.stock/GetStock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).GetStock(ctx, req.(*GoodsStockInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_ReduceStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GoodsStockInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).ReduceStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.stock.v1.stock/ReduceStock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).ReduceStock(ctx, req.(*GoodsStockInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_BatchGetStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockInfoList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).BatchGetStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.stock.v1.stock/BatchGetStock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).BatchGetStock(ctx, req.(*StockInfoList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_BatchReduceStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockInfoList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).BatchReduceStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.stock.v1.stock/BatchReduceStock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).BatchReduceStock(ctx, req.(*StockInfoList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_RollbackStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GoodsStockInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).RollbackStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.stock.v1.stock/RollbackStock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).RollbackStock(ctx, req.(*GoodsStockInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_ReturnStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReturnStockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).ReturnStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.stock.v1.stock/ReturnStock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).ReturnStock(ctx, req.(*ReturnStockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_TryReserve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).TryReserve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.stock.v1.stock/TryReserve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).TryReserve(ctx, req.(*ReserveReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_ConfirmReserve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).ConfirmReserve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.stock.v1.stock/ConfirmReserve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).ConfirmReserve(ctx, req.(*ReserveReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_CancelReserve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).CancelReserve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.stock.v1.stock/CancelReserve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).CancelReserve(ctx, req.(*ReserveReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_ListStockRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStockRecordReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).ListStockRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.stock.v1.stock/ListStockRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).ListStockRecord(ctx, req.(*ListStockRecordReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_ListDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1.ListDeadLetterReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).ListDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.stock.v1.stock/ListDeadLetter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).ListDeadLetter(ctx, req.(*v1.ListDeadLetterReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stock_ReplayDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1.ReplayDeadLetterReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServer).ReplayDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shop.stock.v1.stock/ReplayDeadLetter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServer).ReplayDeadLetter(ctx, req.(*v1.ReplayDeadLetterReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Stock_ServiceDesc is the grpc.ServiceDesc for Stock service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Stock_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "shop.stock.v1.stock",
+	HandlerType: (*StockServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetStock",
+			Handler:    _Stock_SetStock_Handler,
+		},
+		{
+			MethodName: "GetStock",
+			Handler:    _Stock_GetStock_Handler,
+		},
+		{
+			MethodName: "ReduceStock",
+			Handler:    _Stock_ReduceStock_Handler,
+		},
+		{
+			MethodName: "BatchGetStock",
+			Handler:    _Stock_BatchGetStock_Handler,
+		},
+		{
+			MethodName: "BatchReduceStock",
+			Handler:    _Stock_BatchReduceStock_Handler,
+		},
+		{
+			MethodName: "RollbackStock",
+			Handler:    _Stock_RollbackStock_Handler,
+		},
+		{
+			MethodName: "ReturnStock",
+			Handler:    _Stock_ReturnStock_Handler,
+		},
+		{
+			MethodName: "TryReserve",
+			Handler:    _Stock_TryReserve_Handler,
+		},
+		{
+			MethodName: "ConfirmReserve",
+			Handler:    _Stock_ConfirmReserve_Handler,
+		},
+		{
+			MethodName: "CancelReserve",
+			Handler:    _Stock_CancelReserve_Handler,
+		},
+		{
+			MethodName: "ListStockRecord",
+			Handler:    _Stock_ListStockRecord_Handler,
+		},
+		{
+			MethodName: "ListDeadLetter",
+			Handler:    _Stock_ListDeadLetter_Handler,
+		},
+		{
+			MethodName: "ReplayDeadLetter",
+			Handler:    _Stock_ReplayDeadLetter_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "shop/stock/v1/stock.proto",
+}
@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	authv1 "github.com/idMiFeng/api/shop/auth/v1"
+	"github.com/idMiFeng/common/config"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// 认证
+// 1. gateway校验HTTP请求的用户token，把用户id放到metadata中转发给本服务
+// 2. 服务间调用带上用共享密钥签发的服务token，只有带了有效服务token的调用方转发的用户id才被信任
+// 3. 直接调用RPC的客户端可以在metadata的authorization中带上用户token
+// 4. 服务端拦截器保证请求中的用户id就是调用方自己
+// 5. 按proto中方法声明的策略检查调用方的角色，商家只能操作自己的资源，见 policy.go
+
+// metadata中的key
+const (
+	authorizationKey = "authorization"        // Bearer 用户token
+	userIDKey        = "x-auth-user-id"       // gateway或上游服务转发的用户id
+	rolesKey         = "x-auth-roles"         // 转发的用户角色，逗号分隔
+	merchantIDKey    = "x-auth-merchant-id"   // 转发的商家id
+	serviceTokenKey  = "x-auth-service-token" // 服务token
+	gatewayKey       = "x-auth-gateway"       // gateway转发的外部请求，调用方是用户而不是服务
+)
+
+// metadataPrefix 认证相关的metadata的前缀，gateway不转发客户端传来的这些key
+const metadataPrefix = "x-auth-"
+
+var (
+	conf        *config.AuthConfig
+	serviceName string
+	userKey     interface{} // 校验用户token的密钥，HS为[]byte，RS为*rsa.PublicKey
+	serviceKey  []byte      // 签发和校验服务token的密钥
+	publicSet   map[string]bool
+)
+
+// Init 加载认证配置，cfg 为空时不做认证
+func Init(cfg *config.AuthConfig, service string) error {
+	conf, serviceName = nil, service
+	if cfg == nil {
+		return nil
+	}
+	secret, err := loadSecret(cfg.ServiceSecret, cfg.ServiceSecretFile, envServiceSecret)
+	if err != nil {
+		return err
+	}
+	if len(secret) == 0 {
+		return errors.New("auth: service_secret is required, set " + envServiceSecret + " or service_secret_file")
+	}
+	key, err := loadKey(cfg)
+	if err != nil {
+		return err
+	}
+	publicSet = make(map[string]bool, len(cfg.PublicMethods))
+	for _, m := range cfg.PublicMethods {
+		publicSet[m] = true
+	}
+	conf, userKey, serviceKey = cfg, key, []byte(secret)
+	return nil
+}
+
+// 密钥的环境变量
+const (
+	envSecret        = "SHOP_AUTH_SECRET"
+	envServiceSecret = "SHOP_AUTH_SERVICE_SECRET"
+)
+
+// loadSecret 依次从环境变量、文件、配置中读取密钥
+func loadSecret(value, file, env string) (string, error) {
+	if v := os.Getenv(env); len(v) > 0 {
+		return v, nil
+	}
+	if len(file) > 0 {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("auth: read secret file failed: %w", err)
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+	return value, nil
+}
+
+// Enabled 是否开启了认证
+func Enabled() bool {
+	return conf != nil
+}
+
+func loadKey(cfg *config.AuthConfig) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(cfg.Algorithm, "HS"):
+		secret, err := loadSecret(cfg.Secret, cfg.SecretFile, envSecret)
+		if err != nil {
+			return nil, err
+		}
+		if len(secret) == 0 {
+			return nil, errors.New("auth: secret is required for " + cfg.Algorithm + ", set " + envSecret + " or secret_file")
+		}
+		return []byte(secret), nil
+	case strings.HasPrefix(cfg.Algorithm, "RS"):
+		b, err := os.ReadFile(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("auth: read public key failed: %w", err)
+		}
+		var key *rsa.PublicKey
+		if key, err = jwt.ParseRSAPublicKeyFromPEM(b); err != nil {
+			return nil, fmt.Errorf("auth: parse public key failed: %w", err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("auth: unsupported algorithm: %s", cfg.Algorithm)
+	}
+}
+
+// Identity 调用方的身份
+type Identity struct {
+	UserID     int64         // 用户id，0表示没有用户身份，比如后台任务发起的服务间调用
+	Roles      []authv1.Role // 用户的角色
+	MerchantID int64         // 商家角色的用户所属的商家id
+	Service    string        // 内部调用方的服务名，用户直接调用时为空
+	// 只因为是商家而被允许调用，且操作的资源属于该商家，此时请求中的用户id是商家自己而不是资源的用户
+	MerchantScoped bool
+}
+
+// HasRole 用户是否有该角色
+func (id *Identity) HasRole(role authv1.Role) bool {
+	for _, r := range id.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+type identityKey struct{}
+
+// NewContext 把调用方的身份放到ctx中
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext 返回ctx中调用方的身份
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+	return id, ok
+}
+
+// UserID 返回ctx中的用户id，没有用户身份时返回0
+func UserID(ctx context.Context) int64 {
+	if id, ok := FromContext(ctx); ok {
+		return id.UserID
+	}
+	return 0
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// 服务token在有效期过半后重新签发
+var tokenCache struct {
+	sync.Mutex
+	token    string
+	expireAt time.Time
+}
+
+// serviceToken 返回本服务的服务token
+func serviceToken() (string, error) {
+	tokenCache.Lock()
+	defer tokenCache.Unlock()
+	if time.Until(tokenCache.expireAt) > serviceTokenTTL/2 {
+		return tokenCache.token, nil
+	}
+	token, err := signServiceToken()
+	if err != nil {
+		return "", err
+	}
+	tokenCache.token, tokenCache.expireAt = token, time.Now().Add(serviceTokenTTL)
+	return token, nil
+}
+
+// outgoingContext 带上服务token，当前请求有用户身份时转发用户id和角色
+func outgoingContext(ctx context.Context) (context.Context, error) {
+	token, err := serviceToken()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "sign service token failed")
+	}
+	id, _ := FromContext(ctx)
+	kv := append([]string{serviceTokenKey, token}, forwardMD(id)...)
+	return metadata.AppendToOutgoingContext(ctx, kv...), nil
+}
+
+// UnaryClient 服务间调用的认证
+func UnaryClient(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if !Enabled() {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	ctx, err := outgoingContext(ctx)
+	if err != nil {
+		return err
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// StreamClient 服务间调用的认证
+func StreamClient(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if !Enabled() {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+	ctx, err := outgoingContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return streamer(ctx, desc, cc, method, opts...)
+}
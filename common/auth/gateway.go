@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// spoofPrefix 客户端通过这个前缀的HTTP头可以直接设置metadata，需要去掉认证相关的头
+var spoofPrefix = strings.ToLower(runtime.MetadataHeaderPrefix + metadataPrefix)
+
+// Gateway 校验HTTP请求的用户token，校验通过后用户身份放到请求的ctx中
+// 没有token的请求继续转发，由服务端拦截器决定是否允许匿名调用
+func Gateway(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k := range r.Header {
+			if strings.HasPrefix(strings.ToLower(k), spoofPrefix) {
+				r.Header.Del(k)
+			}
+		}
+		if !Enabled() {
+			h.ServeHTTP(w, r)
+			return
+		}
+		if v := r.Header.Get("Authorization"); len(v) > 0 {
+			token, ok := bearerToken(v)
+			if !ok {
+				writeUnauthenticated(w, "invalid authorization")
+				return
+			}
+			id, err := ParseUserToken(token)
+			if err != nil {
+				writeUnauthenticated(w, "invalid token")
+				return
+			}
+			r = r.WithContext(NewContext(r.Context(), id))
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// writeUnauthenticated 返回与grpc-gateway错误相同格式的响应
+func writeUnauthenticated(w http.ResponseWriter, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"code":    codes.Unauthenticated,
+		"message": msg,
+	})
+}
+
+// GatewayOptions gateway调用本服务时带上服务token，并转发校验过的用户身份
+func GatewayOptions() []runtime.ServeMuxOption {
+	return []runtime.ServeMuxOption{
+		runtime.WithMetadata(func(ctx context.Context, r *http.Request) metadata.MD {
+			if !Enabled() {
+				return nil
+			}
+			token, err := serviceToken()
+			if err != nil {
+				zap.L().Error("sign service token failed", zap.Error(err))
+				return nil
+			}
+			id, _ := FromContext(r.Context())
+			return metadata.Pairs(append([]string{serviceTokenKey, token, gatewayKey, "1"}, forwardMD(id)...)...)
+		}),
+	}
+}
@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	authv1 "github.com/idMiFeng/api/shop/auth/v1"
+	"github.com/idMiFeng/common/logger"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// 基于角色的权限控制
+// 每个RPC方法的策略通过 (shop.auth.v1.policy) 声明在proto中，没有声明的方法只要求调用方已登录
+// 内部调用信任调用方服务，管理员可以调用所有的方法
+// 商家因为商家角色才能调用的方法，检查请求中的资源是否属于该商家，资源所属的商家由服务通过 RegisterOwner 提供
+
+// roleNames 用户token和metadata中的角色名
+var roleNames = map[authv1.Role]string{
+	authv1.Role_ROLE_SHOPPER:   "shopper",
+	authv1.Role_ROLE_MERCHANT:  "merchant",
+	authv1.Role_ROLE_LIVE_OPS:  "live-ops",
+	authv1.Role_ROLE_WAREHOUSE: "warehouse",
+	authv1.Role_ROLE_ADMIN:     "admin",
+}
+
+// parseRoles 角色名转为角色，有不认识的角色名时返回错误
+func parseRoles(names []string) ([]authv1.Role, error) {
+	roles := make([]authv1.Role, 0, len(names))
+	for _, name := range names {
+		role, ok := roleOf(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown role: %s", name)
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+func roleOf(name string) (authv1.Role, bool) {
+	for role, n := range roleNames {
+		if n == name {
+			return role, true
+		}
+	}
+	return authv1.Role_ROLE_UNSPECIFIED, false
+}
+
+// formatRoles 角色转为逗号分隔的角色名，在metadata中转发
+func formatRoles(roles []authv1.Role) string {
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, roleNames[role])
+	}
+	return strings.Join(names, ",")
+}
+
+// OwnerFunc 返回资源所属的商家id，资源不存在时返回0
+type OwnerFunc func(ctx context.Context, id int64) (int64, error)
+
+var owners = make(map[string]OwnerFunc)
+
+// RegisterOwner 注册查询资源所属商家的方法，resource 对应策略中 merchant_scope 的 resource
+// 在服务启动时注册，运行中不能再修改
+func RegisterOwner(resource string, fn OwnerFunc) {
+	owners[resource] = fn
+}
+
+// policies 方法名 -> *authv1.Policy，没有声明策略的方法为nil
+var policies sync.Map
+
+// methodPolicy 返回方法声明的策略，method 为 /shop.order.v1.Order/CreateOrder 的形式
+func methodPolicy(method string) *authv1.Policy {
+	if p, ok := policies.Load(method); ok {
+		return p.(*authv1.Policy)
+	}
+	p := lookupPolicy(method)
+	policies.Store(method, p)
+	return p
+}
+
+// lookupPolicy 从注册的proto描述中找到方法的策略
+func lookupPolicy(method string) *authv1.Policy {
+	name := strings.TrimPrefix(method, "/")
+	i := strings.LastIndex(name, "/")
+	if i < 0 {
+		return nil
+	}
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name[:i]))
+	if err != nil {
+		return nil
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil
+	}
+	md := sd.Methods().ByName(protoreflect.Name(name[i+1:]))
+	if md == nil {
+		return nil
+	}
+	p, _ := proto.GetExtension(md.Options(), authv1.E_Policy).(*authv1.Policy)
+	return p
+}
+
+// authorize 按方法的策略检查已登录的调用方能否调用
+func authorize(ctx context.Context, id *Identity, p *authv1.Policy, req interface{}) error {
+	if len(id.Service) > 0 {
+		// 内部调用信任调用方服务，用户的权限已经在入口的服务检查过
+		return nil
+	}
+	if p.GetInternal() {
+		return status.Error(codes.PermissionDenied, "只允许内部调用")
+	}
+	if len(p.GetRoles()) == 0 || id.HasRole(authv1.Role_ROLE_ADMIN) {
+		return nil
+	}
+	var granted []authv1.Role
+	for _, role := range p.GetRoles() {
+		if id.HasRole(role) {
+			granted = append(granted, role)
+		}
+	}
+	if len(granted) == 0 {
+		return status.Error(codes.PermissionDenied, "没有权限")
+	}
+	// 只因为是商家才能调用时，检查操作的资源是否属于该商家
+	if scope := p.GetMerchantScope(); scope != nil && len(granted) == 1 && granted[0] == authv1.Role_ROLE_MERCHANT {
+		if err := checkMerchant(ctx, id, scope, req); err != nil {
+			return err
+		}
+		id.MerchantScoped = true
+	}
+	return nil
+}
+
+// checkMerchant 请求中 field 字段对应的资源必须属于调用方的商家
+func checkMerchant(ctx context.Context, id *Identity, scope *authv1.MerchantScope, req interface{}) error {
+	if id.MerchantID == 0 {
+		return status.Error(codes.PermissionDenied, "没有权限")
+	}
+	fn, ok := owners[scope.GetResource()]
+	if !ok {
+		zap.L().Error("resource owner is not registered", zap.String("resource", scope.GetResource()))
+		return status.Error(codes.PermissionDenied, "没有权限")
+	}
+	m, ok := req.(proto.Message)
+	if !ok {
+		return status.Error(codes.PermissionDenied, "没有权限")
+	}
+	msg := m.ProtoReflect()
+	fd := msg.Descriptor().Fields().ByName(protoreflect.Name(scope.GetField()))
+	if fd == nil || fd.Kind() != protoreflect.Int64Kind || fd.Cardinality() == protoreflect.Repeated {
+		zap.L().Error("invalid merchant scope field",
+			zap.String("message", string(msg.Descriptor().FullName())),
+			zap.String("field", scope.GetField()),
+		)
+		return status.Error(codes.PermissionDenied, "没有权限")
+	}
+	owner, err := fn(ctx, msg.Get(fd).Int())
+	if err != nil {
+		logger.FromContext(ctx).Error("query resource owner failed",
+			zap.String("resource", scope.GetResource()),
+			zap.Error(err),
+		)
+		return status.Error(codes.Internal, "内部错误")
+	}
+	if owner != id.MerchantID {
+		return status.Error(codes.PermissionDenied, "不能操作其他商家的数据")
+	}
+	return nil
+}
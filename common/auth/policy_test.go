@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	authv1 "github.com/idMiFeng/api/shop/auth/v1"
+	orderv1 "github.com/idMiFeng/api/shop/order/v1"
+	stockv1 "github.com/idMiFeng/api/shop/stock/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 商品1属于商家10，商品2属于商家20，商品3不存在
+func init() {
+	RegisterOwner("test_goods", func(ctx context.Context, id int64) (int64, error) {
+		switch id {
+		case 1:
+			return 10, nil
+		case 2:
+			return 20, nil
+		case 99:
+			return 0, errors.New("db down")
+		}
+		return 0, nil
+	})
+}
+
+var (
+	merchantPolicy = &authv1.Policy{
+		Roles:         []authv1.Role{authv1.Role_ROLE_WAREHOUSE, authv1.Role_ROLE_MERCHANT},
+		MerchantScope: &authv1.MerchantScope{Resource: "test_goods", Field: "goodsId"},
+	}
+	merchant = func() *Identity {
+		return &Identity{UserID: 1, Roles: []authv1.Role{authv1.Role_ROLE_MERCHANT}, MerchantID: 10}
+	}
+)
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name   string
+		id     *Identity
+		policy *authv1.Policy
+		req    interface{}
+		code   codes.Code
+		scoped bool
+	}{
+		{"service", &Identity{Service: "order_srv"}, &authv1.Policy{Internal: true}, nil, codes.OK, false},
+		{"internal", &Identity{UserID: 1, Roles: []authv1.Role{authv1.Role_ROLE_ADMIN}}, &authv1.Policy{Internal: true}, nil, codes.PermissionDenied, false},
+		{"no roles", &Identity{UserID: 1}, &authv1.Policy{}, nil, codes.OK, false},
+		{"admin", &Identity{UserID: 1, Roles: []authv1.Role{authv1.Role_ROLE_ADMIN}}, merchantPolicy, &stockv1.GoodsStockInfo{GoodsId: 2}, codes.OK, false},
+		{"role denied", &Identity{UserID: 1, Roles: []authv1.Role{authv1.Role_ROLE_SHOPPER}}, merchantPolicy, &stockv1.GoodsStockInfo{GoodsId: 1}, codes.PermissionDenied, false},
+		{"warehouse not scoped", &Identity{UserID: 1, Roles: []authv1.Role{authv1.Role_ROLE_WAREHOUSE}}, merchantPolicy, &stockv1.GoodsStockInfo{GoodsId: 2}, codes.OK, false},
+		{"merchant own", merchant(), merchantPolicy, &stockv1.GoodsStockInfo{GoodsId: 1}, codes.OK, true},
+		{"merchant other", merchant(), merchantPolicy, &stockv1.GoodsStockInfo{GoodsId: 2}, codes.PermissionDenied, false},
+		{"merchant missing", merchant(), merchantPolicy, &stockv1.GoodsStockInfo{GoodsId: 3}, codes.PermissionDenied, false},
+		{"merchant and warehouse", &Identity{UserID: 1, Roles: []authv1.Role{authv1.Role_ROLE_MERCHANT, authv1.Role_ROLE_WAREHOUSE}, MerchantID: 10}, merchantPolicy, &stockv1.GoodsStockInfo{GoodsId: 2}, codes.OK, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authorize(context.Background(), tt.id, tt.policy, tt.req)
+			if status.Code(err) != tt.code {
+				t.Fatalf("authorize = %v, want %v", err, tt.code)
+			}
+			if tt.id.MerchantScoped != tt.scoped {
+				t.Fatalf("MerchantScoped = %v, want %v", tt.id.MerchantScoped, tt.scoped)
+			}
+		})
+	}
+}
+
+func TestCheckMerchant(t *testing.T) {
+	scope := merchantPolicy.MerchantScope
+	tests := []struct {
+		name  string
+		id    *Identity
+		scope *authv1.MerchantScope
+		req   interface{}
+		code  codes.Code
+	}{
+		{"own", merchant(), scope, &stockv1.GoodsStockInfo{GoodsId: 1}, codes.OK},
+		{"no merchant id", &Identity{UserID: 1, Roles: []authv1.Role{authv1.Role_ROLE_MERCHANT}}, scope, &stockv1.GoodsStockInfo{GoodsId: 1}, codes.PermissionDenied},
+		{"unregistered resource", merchant(), &authv1.MerchantScope{Resource: "unknown", Field: "goodsId"}, &stockv1.GoodsStockInfo{GoodsId: 1}, codes.PermissionDenied},
+		{"missing field", merchant(), &authv1.MerchantScope{Resource: "test_goods", Field: "refundId"}, &stockv1.GoodsStockInfo{GoodsId: 1}, codes.PermissionDenied},
+		{"not proto", merchant(), scope, struct{}{}, codes.PermissionDenied},
+		{"other field name", merchant(), &authv1.MerchantScope{Resource: "test_goods", Field: "orderId"}, &orderv1.OrderDetailReq{OrderId: 1}, codes.OK},
+		{"owner query failed", merchant(), scope, &stockv1.GoodsStockInfo{GoodsId: 99}, codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMerchant(context.Background(), tt.id, tt.scope, tt.req)
+			if status.Code(err) != tt.code {
+				t.Fatalf("checkMerchant = %v, want %v", err, tt.code)
+			}
+		})
+	}
+}
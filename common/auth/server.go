@@ -0,0 +1,192 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	authv1 "github.com/idMiFeng/api/shop/auth/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// healthPrefix 健康检查不需要认证
+const healthPrefix = "/grpc.health.v1.Health/"
+
+// UnaryServer 认证调用方并检查权限，请求中的用户id只能是调用方自己
+func UnaryServer(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !Enabled() {
+		return handler(ctx, req)
+	}
+	ctx, err := authenticate(ctx, info.FullMethod, req)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// serverStream 替换stream的ctx
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// StreamServer 认证调用方并检查权限，流式请求的消息不检查用户id和商家的数据范围
+func StreamServer(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !Enabled() {
+		return handler(srv, ss)
+	}
+	ctx, err := authenticate(ss.Context(), info.FullMethod, nil)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate 识别调用方的身份放到ctx中，并按方法的策略检查权限，公开的方法允许匿名调用
+func authenticate(ctx context.Context, method string, req interface{}) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	id, err := identify(md)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	p := methodPolicy(method)
+	if id == nil {
+		if strings.HasPrefix(method, healthPrefix) || publicSet[method] || p.GetPublic() {
+			return ctx, nil
+		}
+		return nil, status.Error(codes.Unauthenticated, "未登录")
+	}
+	if err = authorize(ctx, id, p, req); err != nil {
+		return nil, err
+	}
+	ctx = NewContext(ctx, id)
+	if err = checkUser(ctx, req); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// identify 优先使用服务token，只有带了有效服务token时才信任转发的用户id
+// gateway转发的请求调用方是用户，没有用户id时为匿名请求
+func identify(md metadata.MD) (*Identity, error) {
+	if v := first(md, serviceTokenKey); len(v) > 0 {
+		svc, err := parseServiceToken(v)
+		if err != nil {
+			return nil, errors.New("invalid service token")
+		}
+		id := &Identity{Service: svc}
+		if err = forwardedUser(md, id); err != nil {
+			return nil, err
+		}
+		if len(first(md, gatewayKey)) > 0 {
+			if id.UserID == 0 {
+				return nil, nil
+			}
+			id.Service = ""
+		}
+		return id, nil
+	}
+	if v := first(md, authorizationKey); len(v) > 0 {
+		token, ok := bearerToken(v)
+		if !ok {
+			return nil, errors.New("invalid authorization")
+		}
+		id, err := ParseUserToken(token)
+		if err != nil {
+			return nil, errors.New("invalid token")
+		}
+		return id, nil
+	}
+	return nil, nil
+}
+
+// forwardedUser 取出上游转发的用户id、角色和商家id
+func forwardedUser(md metadata.MD, id *Identity) error {
+	var err error
+	if v := first(md, userIDKey); len(v) > 0 {
+		if id.UserID, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return errors.New("invalid user id")
+		}
+	}
+	if v := first(md, rolesKey); len(v) > 0 {
+		if id.Roles, err = parseRoles(strings.Split(v, ",")); err != nil {
+			return errors.New("invalid roles")
+		}
+	}
+	if v := first(md, merchantIDKey); len(v) > 0 {
+		if id.MerchantID, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return errors.New("invalid merchant id")
+		}
+	}
+	return nil
+}
+
+// forwardMD 转发给下游的用户身份
+func forwardMD(id *Identity) []string {
+	if id == nil || id.UserID == 0 {
+		return nil
+	}
+	kv := []string{userIDKey, strconv.FormatInt(id.UserID, 10)}
+	if len(id.Roles) > 0 {
+		kv = append(kv, rolesKey, formatRoles(id.Roles))
+	}
+	if id.MerchantID > 0 {
+		kv = append(kv, merchantIDKey, strconv.FormatInt(id.MerchantID, 10))
+	}
+	return kv
+}
+
+func first(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// checkUser 请求中有用户id字段的是用户范围的RPC，只能操作调用方自己的数据
+// 用户id为0时填入调用方的用户id，没有用户身份的内部调用和管理员不检查
+func checkUser(ctx context.Context, req interface{}) error {
+	id, ok := FromContext(ctx)
+	if !ok || id.UserID == 0 || id.HasRole(authv1.Role_ROLE_ADMIN) {
+		return nil
+	}
+	m, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	msg := m.ProtoReflect()
+	fd := userIDField(msg.Descriptor())
+	if fd == nil {
+		return nil
+	}
+	switch v := msg.Get(fd).Int(); v {
+	case 0:
+		msg.Set(fd, protoreflect.ValueOfInt64(id.UserID))
+	case id.UserID:
+	default:
+		return status.Error(codes.PermissionDenied, "不能操作其他用户的数据")
+	}
+	return nil
+}
+
+// userIDField 请求中的用户id字段，proto中有 userId 和 UserId 两种写法，商家操作的请求中为 operatorId
+func userIDField(md protoreflect.MessageDescriptor) protoreflect.FieldDescriptor {
+	for _, name := range []protoreflect.Name{"userId", "UserId", "operatorId"} {
+		fd := md.Fields().ByName(name)
+		if fd != nil && fd.Kind() == protoreflect.Int64Kind && fd.Cardinality() != protoreflect.Repeated {
+			return fd
+		}
+	}
+	return nil
+}
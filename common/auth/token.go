@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	authv1 "github.com/idMiFeng/api/shop/auth/v1"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// serviceAudience 服务token的audience
+const serviceAudience = "internal"
+
+// serviceTokenTTL 服务token的有效期
+const serviceTokenTTL = time.Minute
+
+// UserClaims 用户token的内容，subject为用户id
+// roles 为用户的角色名，没有时为买家，商家角色的用户需要带上 merchant_id
+type UserClaims struct {
+	Roles      []string `json:"roles,omitempty"`
+	MerchantID int64    `json:"merchant_id,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// ParseUserToken 校验用户token，返回用户的身份
+func ParseUserToken(token string) (*Identity, error) {
+	var claims UserClaims
+	_, err := jwt.NewParser(jwt.WithValidMethods([]string{conf.Algorithm})).
+		ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+			return userKey, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	// 没有过期时间的token泄露后一直有效，不接受
+	if claims.ExpiresAt == nil {
+		return nil, errors.New("token has no expiration")
+	}
+	if len(conf.Issuer) > 0 && !claims.VerifyIssuer(conf.Issuer, true) {
+		return nil, errors.New("invalid issuer")
+	}
+	uid, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil || uid <= 0 {
+		return nil, errors.New("invalid subject")
+	}
+	roles, err := parseRoles(claims.Roles)
+	if err != nil {
+		return nil, err
+	}
+	if len(roles) == 0 {
+		roles = []authv1.Role{authv1.Role_ROLE_SHOPPER}
+	}
+	return &Identity{UserID: uid, Roles: roles, MerchantID: claims.MerchantID}, nil
+}
+
+// signServiceToken 签发本服务的服务token
+func signServiceToken() (string, error) {
+	now := time.Now()
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   serviceName,
+		Audience:  jwt.ClaimStrings{serviceAudience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(serviceTokenTTL)),
+	}).SignedString(serviceKey)
+}
+
+// parseServiceToken 校验服务token，返回调用方的服务名
+func parseServiceToken(token string) (string, error) {
+	var claims jwt.RegisteredClaims
+	_, err := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()})).
+		ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+			return serviceKey, nil
+		})
+	if err != nil {
+		return "", err
+	}
+	if !claims.VerifyAudience(serviceAudience, true) || claims.ExpiresAt == nil || len(claims.Subject) == 0 {
+		return "", errors.New("invalid service token")
+	}
+	return claims.Subject, nil
+}
+
+// bearerToken 取出 "Bearer xxx" 中的token
+func bearerToken(v string) (string, bool) {
+	const prefix = "bearer "
+	if len(v) <= len(prefix) || !strings.EqualFold(v[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(v[len(prefix):]), true
+}
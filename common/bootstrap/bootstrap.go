@@ -0,0 +1,343 @@
+package bootstrap
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/idMiFeng/common/auth"
+	"github.com/idMiFeng/common/config"
+	"github.com/idMiFeng/common/healthcheck"
+	"github.com/idMiFeng/common/interceptor"
+	"github.com/idMiFeng/common/lifecycle"
+	"github.com/idMiFeng/common/logger"
+	"github.com/idMiFeng/common/metrics"
+	"github.com/idMiFeng/common/registry"
+	"github.com/idMiFeng/common/tracing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// 各服务启动时共同的流程都放在这里，main只需要关心自己的依赖和RPC服务
+
+// defaultShutdownTimeout 没有配置时，退出时等待进行中的请求和消息处理完的最长时间
+const defaultShutdownTimeout = 10 * time.Second
+
+// GatewayHandler grpc-gateway生成的RegisterXxxHandler
+type GatewayHandler func(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error
+
+// App 一个服务进程
+type App struct {
+	conf *config.Base
+
+	Server *grpc.Server   // gRPC服务，main在上面注册自己的RPC服务
+	Health *health.Server // 健康检查服务
+
+	instance *registry.Instance // 注册到注册中心的实例
+	checker  *healthcheck.Checker
+	gateways []GatewayHandler
+	httpSrv  *http.Server
+	adminSrv *http.Server
+	stoppers []func() error
+	closers  []func() error
+}
+
+// Must 程序启动时初始化失败直接退出
+func Must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+// New 解析命令行参数，依次加载配置文件、日志和注册中心，创建gRPC服务
+// conf 为服务自己的配置变量，需要嵌入config.Base
+func New(conf config.Provider, opts ...grpc.ServerOption) *App {
+	var cfn string
+	// 从命令行获取可能的conf路径
+	// xx_service -conf="./conf/config_qa.yaml"
+	// xx_service -conf="./conf/config_online.yaml"
+	flag.StringVar(&cfn, "conf", "./conf/config.yaml", "指定配置文件路径")
+	flag.Parse()
+	// 1. 加载配置文件
+	Must(config.Load(cfn, conf))
+	base := conf.GetBase()
+	// 2. 加载日志
+	Must(logger.Init(base.LogConfig, base.Mode))
+	// 3. 初始化注册中心
+	Must(registry.Init(base.RegistryConfig))
+	host, err := registry.AdvertiseHost(base.RegistryConfig.Advertise, base.IP)
+	Must(err)
+	if base.RegistryConfig.Kind != registry.KindStatic && registry.IsLoopback(host) {
+		zap.L().Warn("register with loopback address, only reachable from this host, set registry.advertise", zap.String("host", host))
+	}
+	// 4. 初始化链路追踪
+	stopTracing, err := tracing.Init(base.TraceConfig, base.Name, base.Version)
+	Must(err)
+	// 5. 加载认证配置
+	Must(auth.Init(base.AuthConfig, base.Name))
+	if !auth.Enabled() {
+		zap.L().Warn("auth is not configured, all requests are allowed")
+	}
+
+	// trace、请求id、访问日志、指标、panic恢复和认证的拦截器在服务自己的选项之前
+	s := grpc.NewServer(append(interceptor.ServerOptions(), opts...)...)
+	// 注册健康检查服务，支持consul来对我进行健康检查
+	h := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(s, h)
+	app := &App{
+		conf:     base,
+		Server:   s,
+		Health:   h,
+		instance: registry.NewInstance(base.Name, host, base.RpcPort),
+		checker:  healthcheck.New(h, base.HealthConfig),
+	}
+	// 最后关闭，导出退出过程中的span
+	app.OnShutdown(stopTracing)
+	return app
+}
+
+// RegisterGateway 在gateway上注册HTTP到RPC的映射
+func (a *App) RegisterGateway(h GatewayHandler) {
+	a.gateways = append(a.gateways, h)
+}
+
+// AddCheck 添加一个依赖的检查，如MySQL、Redis，依赖都可用时服务才注册到注册中心
+func (a *App) AddCheck(name string, fn healthcheck.Check) {
+	a.checker.Add(name, fn)
+}
+
+// OnStop 注册退出时停止接收新消息的方法，如消费者停止拉取消息，在等待进行中的消息处理完之前按注册的顺序调用
+// 调用时生产者等 OnShutdown 注册的资源还没有关闭，进行中的处理仍然可以使用
+func (a *App) OnStop(fn func() error) {
+	a.stoppers = append(a.stoppers, fn)
+}
+
+// OnShutdown 注册退出时要释放的资源，在进行中的请求和消息处理完之后按注册的相反顺序关闭
+func (a *App) OnShutdown(fn func() error) {
+	a.closers = append(a.closers, fn)
+}
+
+// Run 启动gRPC服务和HTTP服务，依赖都可用后注册到注册中心，阻塞到收到退出信号后依次退出
+func (a *App) Run() {
+	// 监听端口
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", a.conf.RpcPort))
+	Must(err)
+	// 启动gRPC服务，Serve会阻塞直到服务停止，所以放到单独的goroutine
+	go func() {
+		if err := a.Server.Serve(lis); err != nil {
+			zap.L().Error("grpc server serve failed", zap.Error(err))
+		}
+	}()
+	zap.L().Info(
+		"rpc server start",
+		zap.String("ip", a.conf.IP),
+		zap.Int("port", a.conf.RpcPort),
+	)
+
+	Must(a.serveHTTP())
+	a.serveAdmin()
+
+	// 依赖都可用后才注册到注册中心，依赖不可用时注销，恢复后重新注册
+	a.checker.OnReady(a.register)
+	a.checker.OnDown(a.deregister)
+	go a.checker.Run(a.services())
+
+	// 服务退出时要注销服务
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
+	<-quit // 正常会hang在此处
+	a.shutdown()
+}
+
+// register 注册服务到注册中心
+func (a *App) register() {
+	if err := registry.Reg.Register(context.Background(), a.instance); err != nil {
+		zap.L().Error("register service failed", zap.Error(err))
+		return
+	}
+	zap.L().Info("register service success", zap.String("id", a.instance.ID), zap.String("addr", a.instance.Addr()))
+}
+
+// deregister 从注册中心注销
+func (a *App) deregister() {
+	if err := registry.Reg.Deregister(context.Background(), a.instance); err != nil {
+		zap.L().Error("deregister service failed", zap.Error(err))
+	}
+}
+
+// services 服务上注册的RPC服务名，不包括健康检查服务
+func (a *App) services() []string {
+	var names []string
+	for name := range a.Server.GetServiceInfo() {
+		if name != grpc_health_v1.Health_ServiceDesc.ServiceName {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// serveHTTP 在HTTP端口上暴露指标和健康检查，有gateway时其余的请求交给gateway
+func (a *App) serveHTTP() error {
+	mux := http.NewServeMux()
+	mux.Handle(metrics.Path, metrics.Handler())
+	mux.Handle("/healthz", a.checker.LiveHandler())
+	mux.Handle("/readyz", a.checker.ReadyHandler())
+	if len(a.gateways) > 0 {
+		gwmux, err := a.gateway()
+		if err != nil {
+			return err
+		}
+		// HTTP请求是链路的入口，先校验用户token再转发
+		mux.Handle("/", otelhttp.NewHandler(auth.Gateway(gwmux), "gateway",
+			otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+				return r.Method + " " + r.URL.Path
+			}),
+		))
+	}
+	a.httpSrv = &http.Server{
+		Addr:    fmt.Sprintf(":%d", a.conf.HttpPort),
+		Handler: mux,
+	}
+	zap.L().Info(
+		"HTTP Server start",
+		zap.String("ip", a.conf.IP),
+		zap.Int("port", a.conf.HttpPort),
+	)
+	go func() {
+		err := a.httpSrv.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			zap.L().Error("httpSrv.ListenAndServe failed", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// serveAdmin 在管理端口上也暴露指标和健康检查，HTTP端口对外开放时可以只在内网采集管理端口
+func (a *App) serveAdmin() {
+	if a.conf.AdminPort == 0 {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle(metrics.Path, metrics.Handler())
+	mux.Handle("/healthz", a.checker.LiveHandler())
+	mux.Handle("/readyz", a.checker.ReadyHandler())
+	a.adminSrv = &http.Server{
+		Addr:    fmt.Sprintf(":%d", a.conf.AdminPort),
+		Handler: mux,
+	}
+	zap.L().Info("admin server start", zap.Int("port", a.conf.AdminPort))
+	go func() {
+		err := a.adminSrv.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			zap.L().Error("adminSrv.ListenAndServe failed", zap.Error(err))
+		}
+	}()
+}
+
+// gateway 创建grpc-gateway，把HTTP请求转为对本服务的RPC请求
+func (a *App) gateway() (http.Handler, error) {
+	// 连接刚启动的gRPC服务，gateway通过它转发请求
+	conn, err := grpc.DialContext(
+		context.Background(),
+		fmt.Sprintf("%s:%d", a.conf.IP, a.conf.RpcPort),
+		grpc.WithBlock(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(otelgrpc.StreamClientInterceptor()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("dial server failed: %w", err)
+	}
+	a.OnShutdown(conn.Close)
+
+	gwmux := runtime.NewServeMux(append(interceptor.GatewayOptions(), auth.GatewayOptions()...)...)
+	for _, h := range a.gateways {
+		if err := h(context.Background(), gwmux, conn); err != nil {
+			return nil, fmt.Errorf("register gateway failed: %w", err)
+		}
+	}
+	return gwmux, nil
+}
+
+// shutdown 按顺序退出：
+// 1. 停止依赖检查，健康检查返回NOT_SERVING 2. 从注册中心注销 3. 停止接收新的请求和消息，消费者停止拉取，等进行中的处理完，超时后强制关闭 4. 释放资源
+func (a *App) shutdown() {
+	timeout, delay := defaultShutdownTimeout, time.Duration(0)
+	if c := a.conf.ShutdownConfig; c != nil {
+		if c.Timeout > 0 {
+			timeout = c.Timeout
+		}
+		delay = c.Delay
+	}
+	zap.L().Info("service shutting down", zap.Duration("timeout", timeout))
+	// 健康检查失败后，consul和做健康检查的调用方不再把请求发过来
+	a.checker.Shutdown()
+	a.Health.Shutdown()
+	a.deregister()
+	// 调用方的服务列表更新之前，仍然可能把请求发过来
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	// 消费者不再处理新的消息，新投递过来的消息稍后重新投递
+	lifecycle.Stop()
+	// 消费者停止拉取，等待进行中的处理期间不再拉到消息后又放回去重试
+	for _, fn := range a.stoppers {
+		if err := fn(); err != nil {
+			zap.L().Error("stop failed", zap.Error(err))
+		}
+	}
+	// gateway的请求会转发给gRPC服务，先停止HTTP服务
+	if a.httpSrv != nil {
+		if err := a.httpSrv.Shutdown(ctx); err != nil {
+			zap.L().Error("httpSrv.Shutdown failed", zap.Error(err))
+		}
+	}
+	a.stopServer(ctx)
+	if err := lifecycle.Wait(ctx); err != nil {
+		zap.L().Error("wait in-flight messages failed", zap.Int("running", lifecycle.Running()), zap.Error(err))
+	}
+
+	for i := len(a.closers) - 1; i >= 0; i-- {
+		if err := a.closers[i](); err != nil {
+			zap.L().Error("close resource failed", zap.Error(err))
+		}
+	}
+	// 退出过程中仍然可以采集指标，最后关闭
+	if a.adminSrv != nil {
+		a.adminSrv.Close()
+	}
+	zap.L().Info("service exit")
+	zap.L().Sync()
+}
+
+// stopServer 等待进行中的RPC处理完，ctx超时后断开所有连接
+func (a *App) stopServer(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		a.Server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		zap.L().Error("grpc server graceful stop timeout, force stop")
+		a.Server.Stop()
+		<-done
+	}
+}
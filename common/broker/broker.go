@@ -0,0 +1,127 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/idMiFeng/common/errno"
+)
+
+// 消息中间件的抽象，业务代码只依赖这里的接口，不直接依赖 RocketMQ 的类型
+// RocketMQ 的实现用于线上，内存的实现用于单元测试
+
+// MQ 服务全局的消息中间件，在 main 中初始化
+var MQ Broker
+
+// Message 消息
+type Message struct {
+	Topic      string
+	Body       []byte
+	Properties map[string]string
+	Delay      time.Duration // 延迟投递的时间，0表示立即投递
+
+	// 以下字段只在消费时有值
+	MsgId          string
+	ReconsumeTimes int32 // 重试次数，第一次消费时为0
+	StoreTimestamp int64 // 存入broker的毫秒时间戳，用于统计消费延迟
+}
+
+// GetProperty 获取消息属性
+func (m *Message) GetProperty(key string) string {
+	if m.Properties == nil {
+		return ""
+	}
+	return m.Properties[key]
+}
+
+// WithProperty 设置消息属性
+func (m *Message) WithProperty(key, value string) *Message {
+	if m.Properties == nil {
+		m.Properties = make(map[string]string)
+	}
+	m.Properties[key] = value
+	return m
+}
+
+// Publisher 发送消息
+type Publisher interface {
+	// Publish 同步发送，Delay 大于0时发送延迟消息
+	Publish(ctx context.Context, msg *Message) error
+}
+
+// Handler 消费消息，返回错误时消息稍后重新投递
+// 返回 errno.ErrPoisonMsg 或者超过最大重试次数时，消息投递到死信topic
+type Handler func(ctx context.Context, msg *Message) error
+
+// defaultMaxReconsume 没有配置时的最大重试次数
+const defaultMaxReconsume = 5
+
+// 死信消息的属性，记录原始消息的信息
+const (
+	PropOriginTopic    = "ORIGIN_TOPIC"
+	PropOriginMsgId    = "ORIGIN_MSG_ID"
+	PropReconsumeTimes = "ORIGIN_RECONSUME_TIMES"
+	PropDeadReason     = "DEAD_REASON"
+)
+
+// shouldDeadLetter 判断消费失败的消息是否进入死信，死信topic自己的消息一直重试
+func shouldDeadLetter(msg *Message, err error, deadLetter string, maxReconsume int32) bool {
+	if len(deadLetter) == 0 || msg.Topic == deadLetter {
+		return false
+	}
+	if errors.Is(err, errno.ErrPoisonMsg) {
+		return true
+	}
+	return msg.ReconsumeTimes >= maxReconsume
+}
+
+// newDeadLetter 构造死信消息，消息体和属性不变
+func newDeadLetter(deadLetter string, msg *Message, reason error) *Message {
+	dlq := &Message{
+		Topic:      deadLetter,
+		Body:       msg.Body,
+		Properties: make(map[string]string, len(msg.Properties)+4),
+	}
+	for k, v := range msg.Properties {
+		dlq.Properties[k] = v
+	}
+	dlq.WithProperty(PropOriginTopic, msg.Topic)
+	dlq.WithProperty(PropOriginMsgId, msg.MsgId)
+	dlq.WithProperty(PropReconsumeTimes, strconv.Itoa(int(msg.ReconsumeTimes)))
+	dlq.WithProperty(PropDeadReason, reason.Error())
+	return dlq
+}
+
+// TxState 本地事务的状态
+type TxState int
+
+const (
+	TxCommit   TxState = iota + 1 // 提交，消息投递给消费者
+	TxRollback                    // 回滚，丢弃消息
+	TxUnknown                     // 未知，稍后回查
+)
+
+// TxListener 执行本地事务和回查本地事务状态，由业务层提供
+type TxListener interface {
+	// ExecuteLocalTransaction 半消息发送成功后执行本地事务
+	ExecuteLocalTransaction(msg *Message) TxState
+	// CheckLocalTransaction 本地事务状态未知时回查
+	CheckLocalTransaction(msg *Message) TxState
+}
+
+// Broker 消息中间件
+type Broker interface {
+	Publisher
+	// PublishInTransaction 发送事务消息，返回本地事务的执行结果
+	PublishInTransaction(ctx context.Context, msg *Message) (TxState, error)
+	// Subscribe 订阅topic，需要在 Start 之前调用
+	Subscribe(topic string, h Handler) error
+	// Start 开始消费
+	Start() error
+	// StopConsume 停止拉取新的消息，进行中的消息继续处理，生产者仍然可用
+	StopConsume() error
+	// Shutdown 停止消费并关闭生产者
+	Shutdown() error
+}
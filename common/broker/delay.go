@@ -0,0 +1,29 @@
+package broker
+
+import "time"
+
+// RocketMQ 只支持固定的延迟级别
+// 1s 5s 10s 30s 1m 2m 3m 4m 5m 6m 7m 8m 9m 10m 20m 30m 1h 2h
+var delayLevels = []time.Duration{
+	time.Second, 5 * time.Second, 10 * time.Second, 30 * time.Second,
+	time.Minute, 2 * time.Minute, 3 * time.Minute, 4 * time.Minute, 5 * time.Minute,
+	6 * time.Minute, 7 * time.Minute, 8 * time.Minute, 9 * time.Minute, 10 * time.Minute,
+	20 * time.Minute, 30 * time.Minute, time.Hour, 2 * time.Hour,
+}
+
+// DelayLevel 返回不超过 d 的最大延迟级别及其对应的延迟时间
+// d 小于最小级别时返回 0，表示不需要再延迟
+// 超过最大级别的延迟需要在消费时判断是否到期，没到期就再投递一次延迟消息
+func DelayLevel(d time.Duration) (int, time.Duration) {
+	level := 0
+	for i, l := range delayLevels {
+		if l > d {
+			break
+		}
+		level = i + 1 // 级别从1开始
+	}
+	if level == 0 {
+		return 0, 0
+	}
+	return level, delayLevels[level-1]
+}
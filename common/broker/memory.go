@@ -0,0 +1,214 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/idMiFeng/common/errno"
+)
+
+// Memory 内存中的消息中间件，用于单元测试
+// 支持延迟消息、消费失败重新投递和事务消息回查，语义与 RocketMQ 保持一致：
+// 消费失败的消息按 RetryDelay 重新投递，无法处理或超过 MaxReconsume 次后放入死信
+type Memory struct {
+	RetryDelay      time.Duration // 消费失败后重新投递的间隔
+	MaxReconsume    int32         // 最大重试次数
+	DeadLetterTopic string        // 不为空时死信同时投递到这个topic
+	CheckInterval   time.Duration // 事务状态未知时的回查间隔
+	MaxCheck        int           // 最大回查次数
+
+	listener TxListener
+	seq      int64
+
+	mu       sync.Mutex
+	started  bool
+	closed   bool
+	handlers map[string]Handler
+	backlog  map[string][]*Message // 还没有订阅者或还没开始消费的消息
+	timers   map[*time.Timer]struct{}
+	dead     []*Message
+	wg       sync.WaitGroup
+}
+
+var _ Broker = (*Memory)(nil)
+
+// NewMemory 创建内存消息中间件，listener 为空时不支持事务消息
+func NewMemory(listener TxListener) *Memory {
+	return &Memory{
+		RetryDelay:    10 * time.Millisecond,
+		MaxReconsume:  16,
+		CheckInterval: 10 * time.Millisecond,
+		MaxCheck:      15,
+
+		listener: listener,
+		handlers: make(map[string]Handler),
+		backlog:  make(map[string][]*Message),
+		timers:   make(map[*time.Timer]struct{}),
+	}
+}
+
+// Publish 按 Delay 精确延迟投递
+func (m *Memory) Publish(ctx context.Context, msg *Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c := *msg
+	c.MsgId = fmt.Sprintf("mem-%d", atomic.AddInt64(&m.seq, 1))
+	c.ReconsumeTimes = 0
+	return m.schedule(&c, msg.Delay)
+}
+
+// PublishInTransaction 直接执行本地事务，状态未知时按 CheckInterval 回查
+func (m *Memory) PublishInTransaction(ctx context.Context, msg *Message) (TxState, error) {
+	if m.listener == nil {
+		return 0, errors.New("transaction listener not set")
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	c := *msg
+	c.MsgId = fmt.Sprintf("mem-%d", atomic.AddInt64(&m.seq, 1))
+	state := m.listener.ExecuteLocalTransaction(&c)
+	switch state {
+	case TxCommit:
+		if err := m.schedule(&c, c.Delay); err != nil {
+			return 0, err
+		}
+	case TxUnknown:
+		m.check(&c, 1)
+	}
+	return state, nil
+}
+
+// check 回查本地事务状态
+func (m *Memory) check(msg *Message, times int) {
+	m.after(m.CheckInterval, func() {
+		switch m.listener.CheckLocalTransaction(msg) {
+		case TxCommit:
+			m.schedule(msg, msg.Delay)
+		case TxUnknown:
+			if times < m.MaxCheck {
+				m.check(msg, times+1)
+			}
+		}
+	})
+}
+
+// Subscribe 每个topic只能有一个消费者
+func (m *Memory) Subscribe(topic string, h Handler) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.handlers[topic]; ok {
+		return fmt.Errorf("topic %s already subscribed", topic)
+	}
+	m.handlers[topic] = h
+	return nil
+}
+
+// Start 开始消费，投递启动前积压的消息
+func (m *Memory) Start() error {
+	m.mu.Lock()
+	m.started = true
+	backlog := m.backlog
+	m.backlog = make(map[string][]*Message)
+	m.mu.Unlock()
+	for _, msgs := range backlog {
+		for _, msg := range msgs {
+			m.deliver(msg)
+		}
+	}
+	return nil
+}
+
+// StopConsume 停止投递，之后到期的消息积压到重新 Start
+func (m *Memory) StopConsume() error {
+	m.mu.Lock()
+	m.started = false
+	m.mu.Unlock()
+	return nil
+}
+
+// Shutdown 丢弃还没到期的消息，等待正在消费的消息处理完
+func (m *Memory) Shutdown() error {
+	m.mu.Lock()
+	m.closed = true
+	for t := range m.timers {
+		if t.Stop() {
+			m.wg.Done()
+		}
+	}
+	m.timers = nil
+	m.mu.Unlock()
+	m.wg.Wait()
+	return nil
+}
+
+// Dead 进入死信的消息
+func (m *Memory) Dead() []*Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Message(nil), m.dead...)
+}
+
+// schedule 延迟 d 后投递消息
+func (m *Memory) schedule(msg *Message, d time.Duration) error {
+	if !m.after(d, func() { m.deliver(msg) }) {
+		return errors.New("broker closed")
+	}
+	return nil
+}
+
+// after 延迟 d 后执行 fn，关闭后返回 false
+func (m *Memory) after(d time.Duration, fn func()) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return false
+	}
+	m.wg.Add(1)
+	var t *time.Timer
+	t = time.AfterFunc(d, func() {
+		defer m.wg.Done()
+		m.mu.Lock()
+		delete(m.timers, t)
+		m.mu.Unlock()
+		fn()
+	})
+	m.timers[t] = struct{}{}
+	return true
+}
+
+// deliver 投递消息，消费失败时重新投递
+func (m *Memory) deliver(msg *Message) {
+	m.mu.Lock()
+	h, ok := m.handlers[msg.Topic]
+	if !ok || !m.started {
+		m.backlog[msg.Topic] = append(m.backlog[msg.Topic], msg)
+		m.mu.Unlock()
+		return
+	}
+	m.mu.Unlock()
+
+	err := h(context.Background(), msg)
+	if err == nil {
+		return
+	}
+	if errors.Is(err, errno.ErrPoisonMsg) || msg.ReconsumeTimes >= m.MaxReconsume {
+		if msg.Topic != m.DeadLetterTopic {
+			m.mu.Lock()
+			m.dead = append(m.dead, msg)
+			m.mu.Unlock()
+			if len(m.DeadLetterTopic) > 0 {
+				m.Publish(context.Background(), newDeadLetter(m.DeadLetterTopic, msg, err))
+			}
+			return
+		}
+	}
+	c := *msg
+	c.ReconsumeTimes++
+	m.schedule(&c, m.RetryDelay)
+}
@@ -0,0 +1,261 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/idMiFeng/common/lifecycle"
+	"github.com/idMiFeng/common/metrics"
+	"github.com/idMiFeng/common/tracing"
+
+	"github.com/apache/rocketmq-client-go/v2"
+	"github.com/apache/rocketmq-client-go/v2/consumer"
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+	"github.com/apache/rocketmq-client-go/v2/producer"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// rocketmqBroker RocketMQ 的实现
+type rocketmqBroker struct {
+	producer   rocketmq.Producer
+	txProducer rocketmq.TransactionProducer // 事务消息生产者，整个服务共用一个
+	consumer   rocketmq.PushConsumer
+	stopOnce   sync.Once
+	stopErr    error
+
+	deadLetter   string // 死信topic
+	maxReconsume int32
+}
+
+var _ Broker = (*rocketmqBroker)(nil)
+
+// Config RocketMQ 的连接配置，由各服务的配置转换而来
+type Config struct {
+	Addr            string
+	GroupId         string
+	TxGroupId       string // 事务消息生产者组，有事务监听器时使用
+	ConsumerGroupId string
+	MaxReconsume    int32  // 最大重试次数，超过后投递到死信topic
+	DeadLetter      string // 死信topic，为空时不投递死信
+}
+
+// NewRocketMQ 创建并启动生产者，listener 不为空时同时创建事务消息生产者
+func NewRocketMQ(cfg *Config, listener TxListener) (Broker, error) {
+	ns := primitive.NewPassthroughResolver([]string{cfg.Addr})
+	b := &rocketmqBroker{
+		deadLetter:   cfg.DeadLetter,
+		maxReconsume: cfg.MaxReconsume,
+	}
+	if b.maxReconsume <= 0 {
+		b.maxReconsume = defaultMaxReconsume
+	}
+	var err error
+	b.producer, err = rocketmq.NewProducer(
+		producer.WithNsResolver(ns),
+		producer.WithRetry(2),
+		producer.WithGroupName(cfg.GroupId),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err = b.producer.Start(); err != nil {
+		return nil, err
+	}
+	if listener != nil {
+		b.txProducer, err = rocketmq.NewTransactionProducer(
+			txListener{listener},
+			producer.WithNsResolver(ns),
+			producer.WithRetry(2),
+			producer.WithGroupName(cfg.TxGroupId), // 事务生产者组，broker按组回查
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err = b.txProducer.Start(); err != nil {
+			return nil, err
+		}
+	}
+	b.consumer, err = rocketmq.NewPushConsumer(
+		consumer.WithGroupName(cfg.ConsumerGroupId),
+		consumer.WithNsResolver(ns),
+		// 每批只投递一条消息，一条失败不会让同批其他消息跟着重试
+		consumer.WithConsumeMessageBatchMaxSize(1),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Publish 同步发送，延迟时间换算成不超过它的最大延迟级别
+// 消息属性中带上当前的trace，消费者据此关联到发送消息的请求
+func (b *rocketmqBroker) Publish(ctx context.Context, msg *Message) (err error) {
+	ctx, span := startPublish(ctx, msg)
+	defer func() { tracing.End(span, err) }()
+	m := toPrimitive(msg)
+	if level, _ := DelayLevel(msg.Delay); level > 0 {
+		m.WithDelayTimeLevel(level)
+	}
+	_, err = b.producer.SendSync(ctx, m)
+	return err
+}
+
+// PublishInTransaction 发送Half消息，成功后调用 TxListener 执行本地事务
+// 本地事务通过消息属性中的trace关联到发送消息的请求
+func (b *rocketmqBroker) PublishInTransaction(ctx context.Context, msg *Message) (state TxState, err error) {
+	if b.txProducer == nil {
+		return 0, errors.New("transaction producer not initialized")
+	}
+	ctx, span := startPublish(ctx, msg)
+	defer func() { tracing.End(span, err) }()
+	res, err := b.txProducer.SendMessageInTransaction(ctx, toPrimitive(msg))
+	if err != nil {
+		return 0, err
+	}
+	zap.L().Info("SendMessageInTransaction success", zap.Any("res", res))
+	return fromLocalState(res.State), nil
+}
+
+// Subscribe 每批只有一条消息，需要重试时只重新投递这一条，消费者需要保证幂等
+// 无法处理的消息和超过最大重试次数的消息投递到死信topic
+func (b *rocketmqBroker) Subscribe(topic string, h Handler) error {
+	return b.consumer.Subscribe(topic, consumer.MessageSelector{}, func(ctx context.Context, msgs ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
+		// 服务退出中不再处理新的消息，稍后重新投递给其他实例
+		if !lifecycle.Begin() {
+			return consumer.ConsumeRetryLater, nil
+		}
+		defer lifecycle.End()
+		result := consumer.ConsumeSuccess
+		for _, m := range msgs {
+			if !b.consumeOne(ctx, h, fromMessageExt(m)) {
+				result = consumer.ConsumeRetryLater // 稍后再试
+			}
+		}
+		return result, nil
+	})
+}
+
+// consumeOne 处理单条消息，返回false时需要稍后重试
+// 消费的span以发送消息的span为父span
+func (b *rocketmqBroker) consumeOne(ctx context.Context, h Handler, msg *Message) bool {
+	metrics.ObserveConsume(msg.Topic, msg.StoreTimestamp, msg.ReconsumeTimes)
+	ctx, span := tracing.StartConsume(ctx, msg.Topic, msg.MsgId, msg.ReconsumeTimes, msg.Properties)
+	err := h(ctx, msg)
+	defer func() { tracing.End(span, err) }()
+	if err == nil {
+		metrics.CountConsume(msg.Topic, metrics.ConsumeSuccess)
+		return true
+	}
+	if !shouldDeadLetter(msg, err, b.deadLetter, b.maxReconsume) {
+		zap.L().Warn("consume msg failed, retry later", zap.String("topic", msg.Topic), zap.String("msg_id", msg.MsgId), zap.Int32("reconsume_times", msg.ReconsumeTimes), zap.Error(err))
+		metrics.CountConsume(msg.Topic, metrics.ConsumeRetry)
+		return false
+	}
+	zap.L().Error("consume msg failed, send to dead letter", zap.String("topic", msg.Topic), zap.String("msg_id", msg.MsgId), zap.Int32("reconsume_times", msg.ReconsumeTimes), zap.Error(err))
+	if e := b.Publish(ctx, newDeadLetter(b.deadLetter, msg, err)); e != nil {
+		zap.L().Error("send dead letter failed", zap.String("msg_id", msg.MsgId), zap.Error(e))
+		metrics.CountConsume(msg.Topic, metrics.ConsumeRetry)
+		return false
+	}
+	metrics.CountConsume(msg.Topic, metrics.ConsumeDeadLetter)
+	return true
+}
+
+// startPublish 创建发送消息的span，并把trace写入消息属性
+func startPublish(ctx context.Context, msg *Message) (context.Context, trace.Span) {
+	if msg.Properties == nil {
+		msg.Properties = make(map[string]string)
+	}
+	return tracing.StartPublish(ctx, msg.Topic, msg.Properties)
+}
+
+// Start 开始消费，需要在 Subscribe 之后调用
+func (b *rocketmqBroker) Start() error {
+	return b.consumer.Start()
+}
+
+// StopConsume 停止拉取消息，只执行一次，Shutdown 时没有停止的话也会先停止
+func (b *rocketmqBroker) StopConsume() error {
+	b.stopOnce.Do(func() {
+		if b.stopErr = b.consumer.Shutdown(); b.stopErr != nil {
+			zap.L().Error("shutdown consumer failed", zap.Error(b.stopErr))
+		}
+	})
+	return b.stopErr
+}
+
+func (b *rocketmqBroker) Shutdown() error {
+	err := b.StopConsume()
+	if b.txProducer != nil {
+		if e := b.txProducer.Shutdown(); e != nil {
+			zap.L().Error("shutdown transaction producer failed", zap.Error(e))
+			err = e
+		}
+	}
+	if e := b.producer.Shutdown(); e != nil {
+		zap.L().Error("shutdown producer failed", zap.Error(e))
+		err = e
+	}
+	return err
+}
+
+// txListener 把 TxListener 适配成 RocketMQ 的事务监听器
+type txListener struct {
+	l TxListener
+}
+
+func (t txListener) ExecuteLocalTransaction(msg *primitive.Message) primitive.LocalTransactionState {
+	return toLocalState(t.l.ExecuteLocalTransaction(fromPrimitive(msg)))
+}
+
+func (t txListener) CheckLocalTransaction(msg *primitive.MessageExt) primitive.LocalTransactionState {
+	return toLocalState(t.l.CheckLocalTransaction(fromMessageExt(msg)))
+}
+
+func toPrimitive(msg *Message) *primitive.Message {
+	m := primitive.NewMessage(msg.Topic, msg.Body)
+	if len(msg.Properties) > 0 {
+		m.WithProperties(msg.Properties)
+	}
+	return m
+}
+
+func fromPrimitive(m *primitive.Message) *Message {
+	return &Message{
+		Topic:      m.Topic,
+		Body:       m.Body,
+		Properties: m.GetProperties(),
+	}
+}
+
+func fromMessageExt(m *primitive.MessageExt) *Message {
+	msg := fromPrimitive(&m.Message)
+	msg.MsgId = m.MsgId
+	msg.ReconsumeTimes = m.ReconsumeTimes
+	msg.StoreTimestamp = m.StoreTimestamp
+	return msg
+}
+
+func toLocalState(s TxState) primitive.LocalTransactionState {
+	switch s {
+	case TxCommit:
+		return primitive.CommitMessageState
+	case TxRollback:
+		return primitive.RollbackMessageState
+	default:
+		return primitive.UnknowState
+	}
+}
+
+func fromLocalState(s primitive.LocalTransactionState) TxState {
+	switch s {
+	case primitive.CommitMessageState:
+		return TxCommit
+	case primitive.RollbackMessageState:
+		return TxRollback
+	default:
+		return TxUnknown
+	}
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/idMiFeng/common/config"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 熔断器的状态
+const (
+	stateClosed   = iota // 正常放行，统计失败率
+	stateOpen            // 熔断，直接返回错误
+	stateHalfOpen        // 放行少量请求探测下游是否恢复
+)
+
+var breakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "grpc_client_breaker_state",
+	Help: "调用其他服务的熔断器状态，0正常 1熔断 2半开",
+}, []string{"service"})
+
+// breaker 一个被调用服务的熔断器
+// 在固定的时间窗口内统计失败率，达到阈值后熔断，经过 open_timeout 进入半开状态，
+// 半开时放行的请求都成功后恢复，有一个失败就重新熔断
+type breaker struct {
+	service string
+	cfg     config.BreakerConfig
+
+	mu          sync.Mutex
+	state       int
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probes      int // 半开状态已经放行的请求数
+	successes   int // 半开状态成功的请求数
+}
+
+func newBreaker(service string, cfg *config.BreakerConfig) *breaker {
+	b := &breaker{service: service, cfg: *cfg, windowStart: time.Now()}
+	if b.cfg.Window <= 0 {
+		b.cfg.Window = 10 * time.Second
+	}
+	if b.cfg.MinRequests <= 0 {
+		b.cfg.MinRequests = 20
+	}
+	if b.cfg.FailureRatio <= 0 {
+		b.cfg.FailureRatio = 0.5
+	}
+	if b.cfg.OpenTimeout <= 0 {
+		b.cfg.OpenTimeout = 5 * time.Second
+	}
+	if b.cfg.HalfOpenRequests <= 0 {
+		b.cfg.HalfOpenRequests = 1
+	}
+	breakerState.WithLabelValues(service).Set(stateClosed)
+	return b
+}
+
+// allow 是否放行这次请求
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	switch b.state {
+	case stateOpen:
+		if now.Sub(b.openedAt) < b.cfg.OpenTimeout {
+			return false
+		}
+		b.setState(stateHalfOpen)
+		b.probes, b.successes = 0, 0
+		fallthrough
+	case stateHalfOpen:
+		if b.probes >= b.cfg.HalfOpenRequests {
+			return false
+		}
+		b.probes++
+		return true
+	default:
+		if now.Sub(b.windowStart) >= b.cfg.Window {
+			b.windowStart, b.requests, b.failures = now, 0, 0
+		}
+		return true
+	}
+}
+
+// done 记录请求的结果
+func (b *breaker) done(err error) {
+	failed := isFailure(err)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case stateHalfOpen:
+		if failed {
+			b.open()
+			return
+		}
+		if b.successes++; b.successes >= b.cfg.HalfOpenRequests {
+			b.setState(stateClosed)
+			b.windowStart, b.requests, b.failures = time.Now(), 0, 0
+		}
+	case stateClosed:
+		b.requests++
+		if failed {
+			b.failures++
+		}
+		if b.requests >= b.cfg.MinRequests && float64(b.failures)/float64(b.requests) >= b.cfg.FailureRatio {
+			b.open()
+		}
+	}
+}
+
+func (b *breaker) open() {
+	zap.L().Warn("circuit breaker open", zap.String("service", b.service), zap.Int("requests", b.requests), zap.Int("failures", b.failures))
+	b.setState(stateOpen)
+	b.openedAt = time.Now()
+}
+
+func (b *breaker) setState(state int) {
+	b.state = state
+	breakerState.WithLabelValues(b.service).Set(float64(state))
+}
+
+// isFailure 下游不可用或者超时的错误计入失败率，业务错误不算
+func isFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.Unknown:
+		return true
+	}
+	return false
+}
+
+func (b *breaker) rejected() error {
+	return status.Errorf(codes.Unavailable, "%s熔断中", b.service)
+}
+
+func (b *breaker) unary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if !b.allow() {
+		return b.rejected()
+	}
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	b.done(err)
+	return err
+}
+
+// stream 流式调用只统计建立流的结果
+func (b *breaker) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if !b.allow() {
+		return nil, b.rejected()
+	}
+	cs, err := streamer(ctx, desc, cc, method, opts...)
+	b.done(err)
+	return cs, err
+}
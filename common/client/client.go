@@ -0,0 +1,157 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/idMiFeng/common/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// 调用其他服务的客户端策略
+// 1. 超时和幂等方法的重试通过gRPC的service config实现，调用方ctx的截止时间更早时以ctx为准
+// 2. 读方法可以配置对冲，慢的请求再发一次，使用先返回的结果
+// 3. 每个被调用的服务一个熔断器，失败率过高时直接返回 Unavailable，不再请求下游
+
+// DialOptions 按配置返回连接 service 服务的选项，service 为proto中的服务全名，如 shop.goods.v1.Goods
+// cfg 为空时只使用round_robin负载均衡
+func DialOptions(service string, cfg *config.ClientConfig) ([]grpc.DialOption, error) {
+	if cfg == nil {
+		cfg = &config.ClientConfig{}
+	}
+	sc, err := serviceConfig(service, cfg)
+	if err != nil {
+		return nil, err
+	}
+	opts := []grpc.DialOption{grpc.WithDefaultServiceConfig(sc)}
+	var interceptors []grpc.UnaryClientInterceptor
+	if cfg.Hedging != nil && len(cfg.Hedging.Methods) > 0 {
+		h, err := newHedging(service, cfg.Hedging)
+		if err != nil {
+			return nil, err
+		}
+		interceptors = append(interceptors, h.unary)
+	}
+	// 熔断在对冲之后，对冲发出的每个请求都计入熔断的统计
+	if cfg.Breaker != nil {
+		b := newBreaker(service, cfg.Breaker)
+		interceptors = append(interceptors, b.unary)
+		opts = append(opts, grpc.WithChainStreamInterceptor(b.stream))
+	}
+	if len(interceptors) > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(interceptors...))
+	}
+	return opts, nil
+}
+
+// gRPC service config，见 https://github.com/grpc/grpc/blob/master/doc/service_config.md
+type (
+	serviceConf struct {
+		LoadBalancingPolicy string       `json:"loadBalancingPolicy"`
+		MethodConfig        []methodConf `json:"methodConfig,omitempty"`
+	}
+	methodName struct {
+		Service string `json:"service"`
+		Method  string `json:"method,omitempty"`
+	}
+	methodConf struct {
+		Name        []methodName `json:"name"`
+		Timeout     string       `json:"timeout,omitempty"`
+		RetryPolicy *retryPolicy `json:"retryPolicy,omitempty"`
+	}
+	retryPolicy struct {
+		MaxAttempts          int      `json:"maxAttempts"`
+		InitialBackoff       string   `json:"initialBackoff"`
+		MaxBackoff           string   `json:"maxBackoff"`
+		BackoffMultiplier    float64  `json:"backoffMultiplier"`
+		RetryableStatusCodes []string `json:"retryableStatusCodes"`
+	}
+)
+
+// serviceConfig 生成service config，所有方法使用默认超时，重试的方法单独配置
+func serviceConfig(service string, cfg *config.ClientConfig) (string, error) {
+	sc := serviceConf{LoadBalancingPolicy: "round_robin"}
+	timeout := ""
+	if cfg.Timeout > 0 {
+		timeout = duration(cfg.Timeout)
+		sc.MethodConfig = append(sc.MethodConfig, methodConf{
+			Name:    []methodName{{Service: service}},
+			Timeout: timeout,
+		})
+	}
+	if r := cfg.Retry; r != nil && len(r.Methods) > 0 {
+		policy, err := newRetryPolicy(r)
+		if err != nil {
+			return "", err
+		}
+		hedged := make(map[string]bool)
+		if cfg.Hedging != nil {
+			for _, m := range cfg.Hedging.Methods {
+				hedged[m] = true
+			}
+		}
+		mc := methodConf{Timeout: timeout, RetryPolicy: policy}
+		for _, m := range r.Methods {
+			if !hedged[m] {
+				mc.Name = append(mc.Name, methodName{Service: service, Method: m})
+			}
+		}
+		if len(mc.Name) > 0 {
+			sc.MethodConfig = append(sc.MethodConfig, mc)
+		}
+	}
+	b, err := json.Marshal(sc)
+	return string(b), err
+}
+
+func newRetryPolicy(cfg *config.RetryConfig) (*retryPolicy, error) {
+	p := &retryPolicy{
+		MaxAttempts:       cfg.MaxAttempts,
+		InitialBackoff:    duration(cfg.InitialBackoff),
+		MaxBackoff:        duration(cfg.MaxBackoff),
+		BackoffMultiplier: 2,
+	}
+	if p.MaxAttempts < 2 {
+		p.MaxAttempts = 3
+	}
+	if cfg.InitialBackoff <= 0 {
+		p.InitialBackoff = duration(50 * time.Millisecond)
+	}
+	if cfg.MaxBackoff <= 0 {
+		p.MaxBackoff = duration(time.Second)
+	}
+	// 检查状态码的名字是否正确
+	if _, err := parseCodes(cfg.Codes); err != nil {
+		return nil, err
+	}
+	p.RetryableStatusCodes = cfg.Codes
+	if len(p.RetryableStatusCodes) == 0 {
+		p.RetryableStatusCodes = []string{"UNAVAILABLE"}
+	}
+	return p, nil
+}
+
+// parseCodes 解析 UNAVAILABLE 形式的状态码，为空时默认 UNAVAILABLE
+func parseCodes(names []string) ([]codes.Code, error) {
+	if len(names) == 0 {
+		return []codes.Code{codes.Unavailable}, nil
+	}
+	list := make([]codes.Code, 0, len(names))
+	for _, name := range names {
+		var c codes.Code
+		if err := c.UnmarshalJSON([]byte(strconv.Quote(name))); err != nil {
+			return nil, fmt.Errorf("invalid status code %q: %w", name, err)
+		}
+		list = append(list, c)
+	}
+	return list, nil
+}
+
+// duration service config中的时长，如 0.5s
+func duration(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/idMiFeng/common/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// hedging 对冲请求，grpc-go没有实现service config中的hedgingPolicy，这里用拦截器实现
+type hedging struct {
+	methods     map[string]bool // 完整的方法名，如 /shop.goods.v1.Goods/GetGoodsDetail
+	maxAttempts int
+	delay       time.Duration
+	nonFatal    map[codes.Code]bool
+}
+
+func newHedging(service string, cfg *config.HedgingConfig) (*hedging, error) {
+	list, err := parseCodes(cfg.Codes)
+	if err != nil {
+		return nil, err
+	}
+	h := &hedging{
+		methods:     make(map[string]bool, len(cfg.Methods)),
+		maxAttempts: cfg.MaxAttempts,
+		delay:       cfg.Delay,
+		nonFatal:    make(map[codes.Code]bool, len(list)),
+	}
+	for _, m := range cfg.Methods {
+		h.methods["/"+service+"/"+m] = true
+	}
+	for _, c := range list {
+		h.nonFatal[c] = true
+	}
+	if h.maxAttempts < 2 {
+		h.maxAttempts = 2
+	}
+	if h.delay <= 0 {
+		h.delay = 100 * time.Millisecond
+	}
+	return h, nil
+}
+
+type attempt struct {
+	reply proto.Message
+	err   error
+}
+
+// unary 第一个请求超过 delay 没有返回或者返回可重试的错误时发出下一个请求，使用第一个成功的结果
+// 返回其他错误时直接返回，所有请求都失败时返回最后一个错误，返回时取消其余的请求
+func (h *hedging) unary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	out, ok := reply.(proto.Message)
+	if !h.methods[method] || !ok {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan attempt, h.maxAttempts)
+	send := func() {
+		r := out.ProtoReflect().New().Interface()
+		err := invoker(ctx, method, req, r, cc, opts...)
+		results <- attempt{reply: r, err: err}
+	}
+	go send()
+	sent, finished := 1, 0
+	timer := time.NewTimer(h.delay)
+	defer timer.Stop()
+	var lastErr error
+	for {
+		select {
+		case <-timer.C:
+			if sent < h.maxAttempts {
+				sent++
+				go send()
+				timer.Reset(h.delay)
+			}
+		case a := <-results:
+			finished++
+			if a.err == nil {
+				proto.Reset(out)
+				proto.Merge(out, a.reply)
+				return nil
+			}
+			lastErr = a.err
+			if !h.nonFatal[status.Code(a.err)] {
+				return a.err
+			}
+			if sent < h.maxAttempts {
+				// 可重试的错误立刻发出下一个请求
+				sent++
+				go send()
+			} else if finished == sent {
+				return lastErr
+			}
+		}
+	}
+}
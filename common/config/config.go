@@ -0,0 +1,198 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fsnotify/fsnotify" //用于监听文件系统事件
+	"github.com/spf13/viper"
+)
+
+// viper.GetXxx()读取的方式
+// 注意：
+// Viper使用的是 `mapstructure`
+
+// Base 各服务通用的配置，服务自己的SrvConfig以 `mapstructure:",squash"` 嵌入
+type Base struct {
+	Name    string `mapstructure:"name"`
+	Mode    string `mapstructure:"mode"`
+	Version string `mapstructure:"version"`
+	// snowflake
+	StartTime string `mapstructure:"start_time"`
+	MachineID int64  `mapstructure:"machine_id"`
+
+	IP        string `mapstructure:"ip"`
+	RpcPort   int    `mapstructure:"rpcPort"`
+	HttpPort  int    `mapstructure:"httpPort"`
+	AdminPort int    `mapstructure:"adminPort"` // 管理端口，与HTTP端口一样暴露指标和健康检查，只在内网开放，为0时不开启
+
+	*LogConfig      `mapstructure:"log"`
+	*MySQLConfig    `mapstructure:"mysql"`
+	*RedisConfig    `mapstructure:"redis"`
+	*RegistryConfig `mapstructure:"registry"`
+	*TraceConfig    `mapstructure:"trace"`
+	*AuthConfig     `mapstructure:"auth"`
+	*ShutdownConfig `mapstructure:"shutdown"`
+	*HealthConfig   `mapstructure:"health"`
+}
+
+// GetBase 返回通用配置，bootstrap通过它拿到启动需要的配置
+func (b *Base) GetBase() *Base {
+	return b
+}
+
+// Provider 嵌入了Base的服务配置
+type Provider interface {
+	GetBase() *Base
+}
+
+type MySQLConfig struct {
+	Host         string `mapstructure:"host"`
+	User         string `mapstructure:"user"`
+	Password     string `mapstructure:"password"`
+	DB           string `mapstructure:"dbname"`
+	Port         int    `mapstructure:"port"`
+	MaxOpenConns int    `mapstructure:"max_open_conns"`
+	MaxIdleConns int    `mapstructure:"max_idle_conns"`
+}
+
+type RedisConfig struct {
+	Host         string `mapstructure:"host"`
+	Password     string `mapstructure:"password"`
+	Port         int    `mapstructure:"port"`
+	DB           int    `mapstructure:"db"`
+	PoolSize     int    `mapstructure:"pool_size"`
+	MinIdleConns int    `mapstructure:"min_idle_conns"`
+}
+
+type LogConfig struct {
+	Level      string `mapstructure:"level"`
+	Filename   string `mapstructure:"filename"`
+	MaxSize    int    `mapstructure:"max_size"`
+	MaxAge     int    `mapstructure:"max_age"`
+	MaxBackups int    `mapstructure:"max_backups"`
+}
+
+// RegistryConfig 注册中心配置
+type RegistryConfig struct {
+	Kind      string        `mapstructure:"kind"`      // consul/etcd/static，默认consul
+	Advertise string        `mapstructure:"advertise"` // 注册的地址，其他服务和健康检查通过它访问本服务，为空时使用ip
+	Consul    *ConsulConfig `mapstructure:"consul"`
+	Etcd      *EtcdConfig   `mapstructure:"etcd"`
+	Static    *StaticConfig `mapstructure:"static"`
+}
+
+type ConsulConfig struct {
+	Addr string `mapstructure:"addr"`
+}
+
+// EtcdConfig 实例注册在 prefix/服务名/实例id 下，绑定ttl的租约，进程退出后租约过期自动注销
+type EtcdConfig struct {
+	Endpoints   []string      `mapstructure:"endpoints"`
+	Username    string        `mapstructure:"username"`
+	Password    string        `mapstructure:"password"`
+	Prefix      string        `mapstructure:"prefix"`       // 默认 /services
+	TTL         time.Duration `mapstructure:"ttl"`          // 租约时间，默认10s
+	DialTimeout time.Duration `mapstructure:"dial_timeout"` // 默认5s
+}
+
+// StaticConfig 本地运行时使用，服务地址写在文件中，修改文件后自动生效
+type StaticConfig struct {
+	File string `mapstructure:"file"` // yaml或json，服务名到地址列表，如 goods_srv: ["127.0.0.1:8382"]
+}
+
+// TraceConfig 链路追踪配置，没有配置exporter时只传递上游的trace，不导出span
+type TraceConfig struct {
+	Exporter    string  `mapstructure:"exporter"`     // otlp/stdout
+	Endpoint    string  `mapstructure:"endpoint"`     // OTLP gRPC地址，如 127.0.0.1:4317
+	Insecure    bool    `mapstructure:"insecure"`     // OTLP不使用TLS
+	SampleRatio float64 `mapstructure:"sample_ratio"` // 采样比例，取值(0,1]，不配置时全部采样，上游已采样的请求一定采样
+}
+
+// AuthConfig 认证配置，用户token由gateway校验，服务间调用使用各服务共用的密钥签发的token
+// 密钥不要写在配置文件中，依次从环境变量 SHOP_AUTH_SECRET/SHOP_AUTH_SERVICE_SECRET、*_file 指定的文件中读取
+type AuthConfig struct {
+	Algorithm         string   `mapstructure:"algorithm"`           // 用户token的签名算法，HS256/HS384/HS512/RS256/RS384/RS512
+	Secret            string   `mapstructure:"secret"`              // HS算法的密钥
+	SecretFile        string   `mapstructure:"secret_file"`         // HS算法的密钥文件
+	PublicKeyFile     string   `mapstructure:"public_key_file"`     // RS算法的公钥文件，PEM格式
+	Issuer            string   `mapstructure:"issuer"`              // 不为空时校验token的签发方
+	ServiceSecret     string   `mapstructure:"service_secret"`      // 服务间调用的密钥，所有服务需要一致
+	ServiceSecretFile string   `mapstructure:"service_secret_file"` // 服务间调用的密钥文件
+	PublicMethods     []string `mapstructure:"public_methods"`      // 不需要认证的RPC方法，如 /shop.order.v1.Order/PayCallback
+}
+
+// ShutdownConfig 优雅退出配置
+type ShutdownConfig struct {
+	Timeout time.Duration `mapstructure:"timeout"` // 等待进行中的请求和消息处理完的最长时间，超时后强制关闭
+	Delay   time.Duration `mapstructure:"delay"`   // 注销服务后等待调用方刷新服务列表的时间，期间仍然正常处理请求
+}
+
+// HealthConfig 依赖检查配置，依赖都可用时服务才注册到注册中心
+type HealthConfig struct {
+	Interval time.Duration `mapstructure:"interval"` // 检查依赖的间隔
+	Timeout  time.Duration `mapstructure:"timeout"`  // 每个检查的超时时间，超时算作不可用
+}
+
+// ClientConfig 调用其他服务的客户端策略，方法名为proto中的方法名，如 GetGoodsDetail
+type ClientConfig struct {
+	Timeout time.Duration  `mapstructure:"timeout"` // 每次调用的默认超时时间，调用方的ctx截止时间更早时以ctx为准
+	Retry   *RetryConfig   `mapstructure:"retry"`
+	Hedging *HedgingConfig `mapstructure:"hedging"`
+	Breaker *BreakerConfig `mapstructure:"breaker"`
+}
+
+// RetryConfig 重试配置，只能配置幂等的方法
+type RetryConfig struct {
+	Methods        []string      `mapstructure:"methods"`         // 重试的方法
+	MaxAttempts    int           `mapstructure:"max_attempts"`    // 最多调用次数，包括第一次，最大为5
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"` // 第一次重试的间隔，之后翻倍
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`     // 最大重试间隔
+	Codes          []string      `mapstructure:"codes"`           // 重试的状态码，如 UNAVAILABLE，默认 UNAVAILABLE
+}
+
+// HedgingConfig 对冲配置，调用超过 delay 没有返回时再发一个相同的请求，使用先返回的结果，只能配置幂等的读方法
+type HedgingConfig struct {
+	Methods     []string      `mapstructure:"methods"`      // 对冲的方法，这些方法不再重试
+	MaxAttempts int           `mapstructure:"max_attempts"` // 最多同时发出的请求数，包括第一个
+	Delay       time.Duration `mapstructure:"delay"`        // 发出下一个请求前等待的时间
+	Codes       []string      `mapstructure:"codes"`        // 立刻发出下一个请求的状态码，其他错误直接返回，默认 UNAVAILABLE
+}
+
+// BreakerConfig 熔断配置，每个被调用的服务一个熔断器
+type BreakerConfig struct {
+	Window           time.Duration `mapstructure:"window"`             // 统计失败率的时间窗口
+	MinRequests      int           `mapstructure:"min_requests"`       // 窗口内请求数达到这个值才会熔断
+	FailureRatio     float64       `mapstructure:"failure_ratio"`      // 失败率达到这个值时熔断
+	OpenTimeout      time.Duration `mapstructure:"open_timeout"`       // 熔断后经过这个时间进入半开状态
+	HalfOpenRequests int           `mapstructure:"half_open_requests"` // 半开状态放行的请求数，都成功后恢复
+}
+
+// Load 读取配置文件反序列化到conf中，并监听配置文件的修改
+func Load(filePath string, conf interface{}) (err error) {
+	// 直接指定配置文件路径（相对路径或者绝对路径）
+	// 相对路径：相对执行的可执行文件的相对路径
+	viper.SetConfigFile(filePath)
+
+	err = viper.ReadInConfig() // 读取配置信息
+	if err != nil {
+		// 读取配置信息失败
+		fmt.Printf("viper.ReadInConfig failed, err:%v\n", err)
+		return
+	}
+
+	// 把读取到的配置信息反序列化到 conf 变量中
+	if err = viper.Unmarshal(conf); err != nil {
+		fmt.Printf("viper.Unmarshal failed, err:%v\n", err)
+		return
+	}
+
+	viper.WatchConfig() // 配置文件监听
+	viper.OnConfigChange(func(in fsnotify.Event) {
+		fmt.Println("配置文件修改了...")
+		if err := viper.Unmarshal(conf); err != nil {
+			fmt.Printf("viper.Unmarshal failed, err:%v\n", err)
+		}
+	})
+	return
+}
@@ -0,0 +1,59 @@
+package deadletter
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var db *gorm.DB
+
+// Init 设置死信表所在的数据库，各服务的dao层建立连接后调用
+func Init(d *gorm.DB) {
+	db = d
+}
+
+// createDeadLetter 保存死信，同一条消息重复投递只保存一次
+func createDeadLetter(ctx context.Context, data *DeadLetter) error {
+	return db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(data).Error
+}
+
+// listDeadLetter 分页查询死信，topic 为空时查询全部
+func listDeadLetter(ctx context.Context, topic string, status int32, offset, limit int) ([]*DeadLetter, int64, error) {
+	query := db.WithContext(ctx).
+		Model(&DeadLetter{}).
+		Where("status = ?", status)
+	if len(topic) > 0 {
+		query = query.Where("topic = ?", topic)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var data []*DeadLetter
+	err := query.Order("id desc").
+		Offset(offset).
+		Limit(limit).
+		Find(&data).Error
+	return data, total, err
+}
+
+func queryDeadLetter(ctx context.Context, id int64) (*DeadLetter, error) {
+	var data DeadLetter
+	err := db.WithContext(ctx).
+		Model(&DeadLetter{}).
+		Where("id = ?", id).
+		First(&data).Error
+	return &data, err
+}
+
+// markReplayed 标记死信已重放
+func markReplayed(ctx context.Context, id int64) error {
+	return db.WithContext(ctx).
+		Model(&DeadLetter{}).
+		Where("id = ?", id).
+		Update("status", StatusReplayed).Error
+}
@@ -0,0 +1,86 @@
+package deadletter
+
+import (
+	"context"
+	"strconv"
+
+	deadletterv1 "github.com/idMiFeng/api/shop/deadletter/v1"
+	"github.com/idMiFeng/common/broker"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// 死信消息的保存、查看和重放，订单服务和库存服务共用
+
+// Save 保存死信topic中的消息
+func Save(ctx context.Context, msg *broker.Message) error {
+	times, _ := strconv.Atoi(msg.GetProperty(broker.PropReconsumeTimes))
+	return createDeadLetter(ctx, &DeadLetter{
+		Topic:          msg.GetProperty(broker.PropOriginTopic),
+		MsgId:          msg.GetProperty(broker.PropOriginMsgId),
+		Body:           string(msg.Body),
+		ReconsumeTimes: int32(times),
+		Reason:         msg.GetProperty(broker.PropDeadReason),
+		Status:         StatusPending,
+	})
+}
+
+// List 分页查询死信
+func List(ctx context.Context, req *deadletterv1.ListDeadLetterReq) (*deadletterv1.DeadLetterList, error) {
+	pageNum, pageSize := int(req.GetPageNum()), int(req.GetPageSize())
+	if pageNum <= 0 {
+		pageNum = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+	data, total, err := listDeadLetter(ctx, req.GetTopic(), req.GetStatus(), (pageNum-1)*pageSize, pageSize)
+	if err != nil {
+		zap.L().Error("listDeadLetter failed", zap.Error(err))
+		return nil, status.Error(codes.Internal, "query dead letter failed")
+	}
+	resp := &deadletterv1.DeadLetterList{
+		Total: int32(total),
+		Data:  make([]*deadletterv1.DeadLetterInfo, 0, len(data)),
+	}
+	for _, d := range data {
+		resp.Data = append(resp.Data, &deadletterv1.DeadLetterInfo{
+			Id:             int64(d.ID),
+			Topic:          d.Topic,
+			MsgId:          d.MsgId,
+			Body:           d.Body,
+			ReconsumeTimes: d.ReconsumeTimes,
+			Reason:         d.Reason,
+			Status:         d.Status,
+			CreateAt:       d.CreateAt.UnixMilli(),
+		})
+	}
+	return resp, nil
+}
+
+// Replay 把死信按原样投递回原始topic，问题修复后由人工触发
+func Replay(ctx context.Context, id int64) error {
+	d, err := queryDeadLetter(ctx, id)
+	if err == gorm.ErrRecordNotFound {
+		return status.Error(codes.NotFound, "死信不存在")
+	}
+	if err != nil {
+		zap.L().Error("queryDeadLetter failed", zap.Int64("id", id), zap.Error(err))
+		return status.Error(codes.Internal, "query dead letter failed")
+	}
+	if len(d.Topic) == 0 {
+		return status.Error(codes.FailedPrecondition, "死信缺少原始topic")
+	}
+	err = broker.MQ.Publish(ctx, &broker.Message{Topic: d.Topic, Body: []byte(d.Body)})
+	if err != nil {
+		zap.L().Error("replay dead letter failed", zap.Int64("id", id), zap.Error(err))
+		return status.Error(codes.Internal, "replay dead letter failed")
+	}
+	if err = markReplayed(ctx, id); err != nil {
+		zap.L().Error("markReplayed failed", zap.Int64("id", id), zap.Error(err))
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package deadletter
+
+import "time"
+
+// 死信状态
+const (
+	StatusPending  = 0 // 待处理
+	StatusReplayed = 1 // 已重放
+)
+
+// DeadLetter 死信消息，从死信topic消费后保存，便于查看和重放
+// 各服务在自己的库中建表，字段与服务的BaseModel保持一致
+type DeadLetter struct {
+	ID       uint      `gorm:"primaryKey"`
+	CreateAt time.Time `gorm:"autoCreateTime"`   // 创建时间
+	UpdateAt time.Time `gorm:"autoUpdateTime"`   // 更新时间
+	CreateBy string    `gorm:"column:create_by"` // 指定数据库中的列名
+	UpdateBy string
+	Version  int16
+	isDel    int8 `gorm:"index"`
+
+	Topic          string // 原始topic
+	MsgId          string // 原始消息id
+	Body           string
+	ReconsumeTimes int32
+	Reason         string // 进入死信的原因
+	Status         int32
+}
+
+// TableName 声明表名
+func (DeadLetter) TableName() string {
+	return "xx_dead_letter"
+}
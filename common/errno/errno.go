@@ -0,0 +1,11 @@
+package errno
+
+import "errors"
+
+// 各服务通用的错误，服务特有的错误定义在服务自己的errno包
+var (
+	ErrQueryFailed = errors.New("query db failed")
+	ErrQueryEmpty  = errors.New("query empty") // 查询结果为空
+
+	ErrPoisonMsg = errors.New("poison message") // 无法处理的消息，重试也不会成功
+)
@@ -0,0 +1,310 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/idMiFeng/common/config"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// 定时检查服务依赖的MySQL、Redis、RocketMQ等是否可用，按结果设置gRPC健康检查的状态
+// 依赖都可用时服务才是就绪的，consul的健康检查和 /readyz 都以此为准
+
+// 没有配置时的检查间隔和每个检查的超时时间
+const (
+	defaultInterval = 10 * time.Second
+	defaultTimeout  = 3 * time.Second
+)
+
+// 返回给 /healthz 和 /readyz 的状态
+const (
+	StatusUp           = "up"
+	StatusDown         = "down"
+	StatusShuttingDown = "shutting_down"
+)
+
+var dependencyUp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "dependency_up",
+	Help: "服务依赖是否可用，1为可用",
+}, []string{"dependency"})
+
+// Check 检查一个依赖，返回错误表示不可用
+type Check func(ctx context.Context) error
+
+// Result 一个依赖最近一次的检查结果
+type Result struct {
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Latency   string    `json:"latency"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Report 服务和各个依赖的状态
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Checker 定时检查依赖，依赖的状态变化时更新gRPC健康检查的状态
+type Checker struct {
+	interval time.Duration
+	timeout  time.Duration
+	server   *health.Server
+
+	names  []string
+	checks map[string]Check
+
+	mu       sync.RWMutex
+	services []string
+	results  map[string]Result
+	ready    bool
+	stopping bool
+	onReady  []func()
+	onDown   []func()
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New 创建Checker，cfg为空时使用默认的间隔和超时
+func New(server *health.Server, cfg *config.HealthConfig) *Checker {
+	c := &Checker{
+		interval: defaultInterval,
+		timeout:  defaultTimeout,
+		server:   server,
+		checks:   make(map[string]Check),
+		results:  make(map[string]Result),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if cfg != nil {
+		if cfg.Interval > 0 {
+			c.interval = cfg.Interval
+		}
+		if cfg.Timeout > 0 {
+			c.timeout = cfg.Timeout
+		}
+	}
+	return c
+}
+
+// Add 添加一个依赖的检查，需要在 Run 之前调用
+func (c *Checker) Add(name string, fn Check) {
+	if _, ok := c.checks[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.checks[name] = fn
+}
+
+// OnReady 服务从不可用变为就绪时调用，包括启动后第一次就绪
+func (c *Checker) OnReady(fn func()) {
+	c.onReady = append(c.onReady, fn)
+}
+
+// OnDown 服务从就绪变为不可用时调用
+func (c *Checker) OnDown(fn func()) {
+	c.onDown = append(c.onDown, fn)
+}
+
+// Run 先把services和整个服务设置为NOT_SERVING，然后定时检查依赖，阻塞到 Shutdown
+func (c *Checker) Run(services []string) {
+	defer close(c.done)
+	c.mu.Lock()
+	c.services = services
+	c.mu.Unlock()
+	c.setServing(false)
+
+	c.probe()
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.probe()
+		}
+	}
+}
+
+// Shutdown 停止检查，服务不再就绪，等进行中的检查结束后返回
+func (c *Checker) Shutdown() {
+	c.mu.Lock()
+	if c.stopping {
+		c.mu.Unlock()
+		return
+	}
+	c.stopping = true
+	c.ready = false
+	c.mu.Unlock()
+	close(c.stop)
+	<-c.done
+}
+
+// Ready 依赖是否都可用
+func (c *Checker) Ready() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ready
+}
+
+// probe 并发检查所有依赖，就绪状态变化时更新gRPC健康检查的状态
+func (c *Checker) probe() {
+	results := make(map[string]Result, len(c.names))
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, name := range c.names {
+		wg.Add(1)
+		go func(name string, fn Check) {
+			defer wg.Done()
+			r := c.run(name, fn)
+			mu.Lock()
+			results[name] = r
+			mu.Unlock()
+		}(name, c.checks[name])
+	}
+	wg.Wait()
+
+	ready := true
+	for _, name := range c.names {
+		r := results[name]
+		if r.Status != StatusUp {
+			ready = false
+			zap.L().Warn("dependency check failed", zap.String("dependency", name), zap.String("error", r.Error))
+		}
+	}
+
+	c.mu.Lock()
+	if c.stopping {
+		c.mu.Unlock()
+		return
+	}
+	c.results = results
+	changed := c.ready != ready
+	c.ready = ready
+	c.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	c.setServing(ready)
+	if !ready {
+		zap.L().Error("service is not ready, dependency unavailable")
+		for _, fn := range c.onDown {
+			fn()
+		}
+		return
+	}
+	zap.L().Info("service is ready")
+	for _, fn := range c.onReady {
+		fn()
+	}
+}
+
+// run 执行一个检查，超时算作不可用
+func (c *Checker) run(name string, fn Check) Result {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	start := time.Now()
+	err := fn(ctx)
+	r := Result{
+		Status:    StatusUp,
+		Latency:   time.Since(start).String(),
+		CheckedAt: start,
+	}
+	if err != nil {
+		r.Status = StatusDown
+		r.Error = err.Error()
+		dependencyUp.WithLabelValues(name).Set(0)
+		return r
+	}
+	dependencyUp.WithLabelValues(name).Set(1)
+	return r
+}
+
+// setServing 设置整个服务（空的服务名）和每个RPC服务的状态
+func (c *Checker) setServing(serving bool) {
+	status := grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	c.mu.RLock()
+	services := c.services
+	c.mu.RUnlock()
+	c.server.SetServingStatus("", status)
+	for _, s := range services {
+		c.server.SetServingStatus(s, status)
+	}
+}
+
+// Report 返回服务和各个依赖最近一次的检查结果
+func (c *Checker) Report() Report {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	r := Report{Status: StatusUp, Checks: make(map[string]Result, len(c.results))}
+	for name, res := range c.results {
+		r.Checks[name] = res
+	}
+	switch {
+	case c.stopping:
+		r.Status = StatusShuttingDown
+	case !c.ready:
+		r.Status = StatusDown
+	}
+	return r
+}
+
+// LiveHandler /healthz，进程在运行就返回200，同时返回各个依赖的状态
+func (c *Checker) LiveHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, http.StatusOK, c.Report())
+	})
+}
+
+// ReadyHandler /readyz，依赖都可用时返回200，否则返回503
+func (c *Checker) ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := c.Report()
+		code := http.StatusOK
+		if report.Status != StatusUp {
+			code = http.StatusServiceUnavailable
+		}
+		writeReport(w, code, report)
+	})
+}
+
+func writeReport(w http.ResponseWriter, code int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// TCP 检查能否连上地址，用于没有ping接口的依赖，如RocketMQ的NameServer
+// addr 可以是分号分隔的多个地址，有一个能连上就算可用
+func TCP(addr string) Check {
+	return func(ctx context.Context) error {
+		var err error
+		var d net.Dialer
+		for _, a := range strings.Split(addr, ";") {
+			var conn net.Conn
+			conn, err = d.DialContext(ctx, "tcp", strings.TrimSpace(a))
+			if err == nil {
+				return conn.Close()
+			}
+		}
+		return err
+	}
+}
@@ -0,0 +1,62 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"github.com/idMiFeng/common/auth"
+	"github.com/idMiFeng/common/logger"
+	"github.com/idMiFeng/common/metrics"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// 客户端拦截器，把当前请求的id、trace和调用方身份传给下游服务，并记录调用的耗时和状态码，同时统计到指标
+
+// DialOptions 返回客户端的拦截器链
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(otelgrpc.UnaryClientInterceptor(), unaryClient, metrics.UnaryClient, auth.UnaryClient),
+		grpc.WithChainStreamInterceptor(otelgrpc.StreamClientInterceptor(), streamClient, metrics.StreamClient, auth.StreamClient),
+	}
+}
+
+// outgoingContext 把ctx中的请求id放到metadata中，不在RPC请求中时生成一个新的
+func outgoingContext(ctx context.Context) context.Context {
+	id := RequestID(ctx)
+	if len(id) == 0 {
+		id = NewRequestID()
+	}
+	return metadata.AppendToOutgoingContext(ctx, RequestIDKey, id)
+}
+
+func unaryClient(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	clientLog(ctx, method, start, err)
+	return err
+}
+
+func streamClient(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	start := time.Now()
+	cs, err := streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	clientLog(ctx, method, start, err)
+	return cs, err
+}
+
+func clientLog(ctx context.Context, method string, start time.Time, err error) {
+	fields := []zap.Field{
+		zap.String("target_method", method),
+		zap.String("code", status.Code(err).String()),
+		zap.Duration("latency", time.Since(start)),
+	}
+	if err != nil {
+		logger.FromContext(ctx).Warn("rpc call failed", append(fields, zap.Error(err))...)
+		return
+	}
+	logger.FromContext(ctx).Debug("rpc call", fields...)
+}
@@ -0,0 +1,30 @@
+package interceptor
+
+import (
+	"context"
+	"net/http"
+	"net/textproto"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+)
+
+// GatewayOptions grpc-gateway收到HTTP请求时带上请求id，没有X-Request-Id头时生成一个
+// 服务端返回的请求id通过X-Request-Id响应头返回给调用方
+func GatewayOptions() []runtime.ServeMuxOption {
+	return []runtime.ServeMuxOption{
+		runtime.WithMetadata(func(ctx context.Context, r *http.Request) metadata.MD {
+			id := r.Header.Get(RequestIDKey)
+			if len(id) == 0 {
+				id = NewRequestID()
+			}
+			return metadata.Pairs(RequestIDKey, id)
+		}),
+		runtime.WithOutgoingHeaderMatcher(func(key string) (string, bool) {
+			if key == RequestIDKey {
+				return textproto.CanonicalMIMEHeaderKey(key), true
+			}
+			return runtime.MetadataHeaderPrefix + key, true
+		}),
+	}
+}
@@ -0,0 +1,42 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDKey 请求id在metadata和HTTP头中的key
+const RequestIDKey = "x-request-id"
+
+type requestIDKey struct{}
+
+// NewRequestID 生成一个新的请求id
+func NewRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// RequestID 返回ctx中的请求id
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// WithRequestID 把请求id放到ctx中，之后用这个ctx发起的RPC调用会带上它
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// incomingRequestID 从上游的metadata中取请求id，没有时生成一个
+func incomingRequestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDKey); len(ids) > 0 && len(ids[0]) > 0 {
+			return ids[0]
+		}
+	}
+	return NewRequestID()
+}
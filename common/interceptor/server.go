@@ -0,0 +1,126 @@
+package interceptor
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"github.com/idMiFeng/common/auth"
+	"github.com/idMiFeng/common/logger"
+	"github.com/idMiFeng/common/metrics"
+	"github.com/idMiFeng/common/tracing"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// 服务端拦截器，执行顺序：trace -> 请求id和logger -> 访问日志 -> 指标 -> panic恢复 -> 认证 -> handler
+
+// ServerOptions 返回服务端的拦截器链
+func ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(otelgrpc.UnaryServerInterceptor(), unaryContext, unaryAccessLog, metrics.UnaryServer, unaryRecovery, auth.UnaryServer),
+		grpc.ChainStreamInterceptor(otelgrpc.StreamServerInterceptor(), streamContext, streamAccessLog, metrics.StreamServer, streamRecovery, auth.StreamServer),
+	}
+}
+
+// newContext 在ctx中放入请求id和带请求信息的logger，并把请求id返回给调用方
+func newContext(ctx context.Context, method string) context.Context {
+	id := incomingRequestID(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+	ctx = WithRequestID(ctx, id)
+	fields := []zap.Field{
+		zap.String("request_id", id),
+		zap.String("method", method),
+	}
+	// 日志带上trace id，可以从日志找到对应的链路
+	if traceId := tracing.TraceID(ctx); len(traceId) > 0 {
+		fields = append(fields, zap.String("trace_id", traceId))
+	}
+	return logger.NewContext(ctx, zap.L().With(fields...))
+}
+
+func unaryContext(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(newContext(ctx, info.FullMethod), req)
+}
+
+func unaryAccessLog(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	accessLog(ctx, start, err)
+	return resp, err
+}
+
+func unaryRecovery(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(ctx, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// serverStream 替换stream的ctx
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func streamContext(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &serverStream{ServerStream: ss, ctx: newContext(ss.Context(), info.FullMethod)})
+}
+
+func streamAccessLog(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	accessLog(ss.Context(), start, err)
+	return err
+}
+
+func streamRecovery(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(ss.Context(), r)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// accessLog 记录方法、耗时、状态码和调用方地址，方法和请求id已经在ctx的logger中
+func accessLog(ctx context.Context, start time.Time, err error) {
+	code := status.Code(err)
+	fields := []zap.Field{
+		zap.String("code", code.String()),
+		zap.Duration("latency", time.Since(start)),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		fields = append(fields, zap.String("peer", p.Addr.String()))
+	}
+	l := logger.FromContext(ctx)
+	switch code {
+	case codes.OK:
+		l.Info("access", fields...)
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		l.Error("access", append(fields, zap.Error(err))...)
+	default:
+		l.Warn("access", append(fields, zap.Error(err))...)
+	}
+}
+
+// recovered handler中的panic不让进程退出，记录堆栈后返回Internal
+func recovered(ctx context.Context, r interface{}) error {
+	logger.FromContext(ctx).Error("panic recovered",
+		zap.Any("panic", r),
+		zap.ByteString("stack", debug.Stack()),
+	)
+	return status.Error(codes.Internal, "内部错误")
+}
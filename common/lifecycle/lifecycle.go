@@ -0,0 +1,69 @@
+package lifecycle
+
+import (
+	"context"
+	"sync"
+)
+
+// 记录进行中的消息消费和后台任务，服务退出时先停止接收新的任务，等进行中的处理完再关闭连接
+// RPC请求由gRPC服务的GracefulStop等待，这里只管RPC以外的入口
+
+var (
+	mu       sync.Mutex
+	running  int
+	stopping bool
+	idle     = make(chan struct{})
+)
+
+// Begin 开始处理一个任务，服务退出中时返回false，调用方不应再处理（消息稍后重新投递）
+// 返回true时处理完需要调用End
+func Begin() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	if stopping {
+		return false
+	}
+	running++
+	return true
+}
+
+// End 一个任务处理完成
+func End() {
+	mu.Lock()
+	defer mu.Unlock()
+	running--
+	if stopping && running == 0 {
+		close(idle)
+	}
+}
+
+// Stop 停止接收新的任务
+func Stop() {
+	mu.Lock()
+	defer mu.Unlock()
+	if stopping {
+		return
+	}
+	stopping = true
+	if running == 0 {
+		close(idle)
+	}
+}
+
+// Wait 停止接收新的任务，等待进行中的任务处理完，超时返回ctx的错误
+func Wait(ctx context.Context) error {
+	Stop()
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Running 进行中的任务数
+func Running() int {
+	mu.Lock()
+	defer mu.Unlock()
+	return running
+}
@@ -1,11 +1,14 @@
 package logger
 
 import (
-	"github.com/idMiFeng/goods_service/config"
+	"context"
+	"os"
+
+	"github.com/idMiFeng/common/config"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
-	"os"
 )
 
 var lg *zap.Logger
@@ -61,3 +64,18 @@ func getLogWriter(filename string, maxSize, maxBackup, maxAge int) zapcore.Write
 	}
 	return zapcore.AddSync(lumberJackLogger)
 }
+
+type ctxKey struct{}
+
+// NewContext 把请求级别的logger放到ctx中
+func NewContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext 取出ctx中的logger，带有request_id等请求信息，没有时返回全局logger
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(ctxKey{}).(*zap.Logger); ok {
+		return l
+	}
+	return zap.L()
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// 每个RPC方法的请求数、耗时和状态码，服务端和客户端分开统计
+
+var (
+	serverHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "服务端处理完成的RPC数量",
+	}, []string{"method", "code"})
+	serverHandling = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "服务端处理RPC的耗时",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+
+	clientHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_handled_total",
+		Help: "客户端调用完成的RPC数量",
+	}, []string{"method", "code"})
+	clientHandling = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_client_handling_seconds",
+		Help:    "客户端调用RPC的耗时",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+)
+
+// UnaryServer 统计服务端一元RPC，放在panic恢复之前，panic也按Internal统计
+func UnaryServer(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observe(serverHandled, serverHandling, info.FullMethod, start, err)
+	return resp, err
+}
+
+// StreamServer 统计服务端流式RPC，耗时是整个流的持续时间
+func StreamServer(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	observe(serverHandled, serverHandling, info.FullMethod, start, err)
+	return err
+}
+
+// UnaryClient 统计客户端一元RPC
+func UnaryClient(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	observe(clientHandled, clientHandling, method, start, err)
+	return err
+}
+
+// StreamClient 统计客户端建立流的结果和耗时
+func StreamClient(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	start := time.Now()
+	cs, err := streamer(ctx, desc, cc, method, opts...)
+	observe(clientHandled, clientHandling, method, start, err)
+	return cs, err
+}
+
+func observe(handled *prometheus.CounterVec, handling *prometheus.HistogramVec, method string, start time.Time, err error) {
+	handled.WithLabelValues(method, status.Code(err).String()).Inc()
+	handling.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,18 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Prometheus指标，各服务在HTTP端口的 /metrics 上暴露
+// 通用的RPC、数据库和消息消费的指标定义在这里，业务相关的指标在各服务自己定义
+
+// Path 暴露指标的HTTP路径
+const Path = "/metrics"
+
+// Handler 返回默认注册表上所有指标的HTTP handler
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
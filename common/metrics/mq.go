@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// 消息从存入broker到开始消费的时间和消费结果
+
+// 消费结果
+const (
+	ConsumeSuccess    = "success"     // 消费成功
+	ConsumeRetry      = "retry"       // 消费失败，稍后重新投递
+	ConsumeDeadLetter = "dead_letter" // 投递到死信topic
+)
+
+var (
+	// 不是消费积压：重试投递的消息从最初存入broker开始计算，延迟消息从到期投递开始计算
+	storeToConsume = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "mq_store_to_consume_seconds",
+		Help:    "消息存入broker到开始消费的时间",
+		Buckets: []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300, 900},
+	}, []string{"topic"})
+	consumed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "mq_consume_total",
+		Help: "消费的消息数量，按消费结果区分",
+	}, []string{"topic", "result"})
+	redelivered = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "mq_consume_redelivered_total",
+		Help: "重试投递的消息数量",
+	}, []string{"topic"})
+)
+
+// ObserveConsume 开始消费时记录消息存入broker到开始消费的时间和重试投递
+// storeTimestamp 为消息存入broker的毫秒时间戳，延迟消息是到期后投递到目标topic的时间，为0时不记录延迟
+func ObserveConsume(topic string, storeTimestamp int64, reconsumeTimes int32) {
+	if storeTimestamp > 0 {
+		d := time.Since(time.Unix(0, storeTimestamp*int64(time.Millisecond)))
+		storeToConsume.WithLabelValues(topic).Observe(d.Seconds())
+	}
+	if reconsumeTimes > 0 {
+		redelivered.WithLabelValues(topic).Inc()
+	}
+}
+
+// CountConsume 记录一条消息的消费结果
+func CountConsume(topic, result string) {
+	consumed.WithLabelValues(topic, result).Inc()
+}
@@ -0,0 +1,71 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/idMiFeng/common/config"
+	"github.com/idMiFeng/common/metrics"
+	"github.com/idMiFeng/common/tracing"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// https://gorm.io/zh_CN/docs/connecting_to_the_database.html
+
+// Open 按配置建立MySQL连接，各服务dao层保存返回的*gorm.DB
+func Open(cfg *config.MySQLConfig) (*gorm.DB, error) {
+	// 参考 https://github.com/go-sql-driver/mysql#dsn-data-source-name 获取详情
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local", cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.DB)
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	// 统计SQL耗时，记录SQL的span
+	if err = db.Use(metrics.GormPlugin{}); err != nil {
+		return nil, err
+	}
+	if err = db.Use(tracing.GormPlugin{}); err != nil {
+		return nil, err
+	}
+
+	// 额外的连接配置
+	sqlDB, err := db.DB() // database/sql.DB
+	if err != nil {
+		return nil, err
+	}
+
+	// 以下配置要配合 my.conf 进行配置
+	// SetMaxIdleConns 设置空闲连接池中连接的最大数量
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+
+	// SetMaxOpenConns 设置打开数据库连接的最大数量。
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+
+	// SetConnMaxLifetime 设置了连接可复用的最大时间。
+	sqlDB.SetConnMaxLifetime(time.Hour)
+	return db, nil
+}
+
+// Close 关闭连接池
+func Close(db *gorm.DB) error {
+	if db == nil {
+		return nil
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// Ping 检查MySQL是否可用
+func Ping(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// 令牌桶限流
+// 每个key一个桶，桶里最多有burst个令牌，每秒补充rate个，每个请求消耗一个令牌
+// Local 在进程内计数，每个副本单独限流；Redis 在Redis中计数，所有副本共享同一个桶
+
+// Limiter 令牌桶限流器
+type Limiter interface {
+	// Allow 消耗key对应的桶中的一个令牌，没有令牌时返回false
+	Allow(ctx context.Context, key string) (bool, error)
+}
+
+// bucket 一个令牌桶
+type bucket struct {
+	tokens float64
+	last   time.Time // 上次补充令牌的时间
+}
+
+// take 补充令牌后尝试取一个令牌
+func (b *bucket) take(now time.Time, rate float64, burst int) bool {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(float64(burst), b.tokens+elapsed*rate)
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// sweepInterval 清理长时间没有请求的桶的间隔
+const sweepInterval = time.Minute
+
+// Local 进程内的令牌桶
+type Local struct {
+	rate  float64
+	burst int
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+var _ Limiter = (*Local)(nil)
+
+// NewLocal 创建进程内的令牌桶，rate为每秒补充的令牌数，burst为桶的容量
+func NewLocal(rate float64, burst int) *Local {
+	return &Local{
+		rate:      rate,
+		burst:     burst,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow 消耗key对应的桶中的一个令牌
+func (l *Local) Allow(_ context.Context, key string) (bool, error) {
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now.Sub(l.lastSweep) > sweepInterval {
+		l.sweep(now)
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.burst), last: now}
+		l.buckets[key] = b
+	}
+	return b.take(now, l.rate, l.burst), nil
+}
+
+// sweep 删除已经补满的桶，之后的请求和新建的桶没有区别
+func (l *Local) sweep(now time.Time) {
+	full := time.Duration(float64(l.burst) / l.rate * float64(time.Second))
+	for key, b := range l.buckets {
+		if now.Sub(b.last) > full {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
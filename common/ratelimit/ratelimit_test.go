@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestBucketTake(t *testing.T) {
+	now := time.Now()
+	b := &bucket{tokens: 2, last: now}
+	if !b.take(now, 10, 2) || !b.take(now, 10, 2) {
+		t.Fatal("burst not allowed")
+	}
+	if b.take(now, 10, 2) {
+		t.Fatal("want rejected when bucket is empty")
+	}
+	// 100ms 补充一个令牌
+	if !b.take(now.Add(100*time.Millisecond), 10, 2) {
+		t.Fatal("want allowed after refill")
+	}
+	if b.take(now.Add(100*time.Millisecond), 10, 2) {
+		t.Fatal("want rejected after refill is used")
+	}
+	// 补充的令牌不超过桶的容量
+	b.take(now.Add(time.Hour), 10, 2)
+	if b.tokens != 1 {
+		t.Fatalf("tokens = %v, want 1", b.tokens)
+	}
+	// 时钟回拨时不补充
+	b.take(now, 10, 2)
+	if b.tokens != 0 {
+		t.Fatalf("tokens = %v, want 0", b.tokens)
+	}
+}
+
+func TestLocal(t *testing.T) {
+	l := NewLocal(0.001, 2)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.Allow(ctx, "a"); !ok {
+			t.Fatal("burst not allowed")
+		}
+	}
+	if ok, _ := l.Allow(ctx, "a"); ok {
+		t.Fatal("want rejected")
+	}
+	if ok, _ := l.Allow(ctx, "b"); !ok {
+		t.Fatal("other key should have its own bucket")
+	}
+}
+
+// 补满的桶被清理，没有补满的桶保留
+func TestLocalSweep(t *testing.T) {
+	l := NewLocal(10, 2)
+	now := time.Now()
+	l.buckets["full"] = &bucket{tokens: 0, last: now.Add(-time.Second)}
+	l.buckets["busy"] = &bucket{tokens: 0, last: now}
+	l.sweep(now)
+	if _, ok := l.buckets["full"]; ok {
+		t.Fatal("full bucket not removed")
+	}
+	if _, ok := l.buckets["busy"]; !ok {
+		t.Fatal("busy bucket removed")
+	}
+}
+
+func TestRedis(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rc := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rc.Close()
+	l := NewRedis(rc, "test", 0.001, 2)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		ok, err := l.Allow(ctx, "a")
+		if err != nil || !ok {
+			t.Fatalf("Allow = %v, %v, want allowed", ok, err)
+		}
+	}
+	if ok, _ := l.Allow(ctx, "a"); ok {
+		t.Fatal("want rejected")
+	}
+	// 其他副本共享同一个桶
+	other := NewRedis(rc, "test", 0.001, 2)
+	if ok, _ := other.Allow(ctx, "a"); ok {
+		t.Fatal("want rejected by shared bucket")
+	}
+	if mr.TTL("test:a") <= 0 {
+		t.Fatal("bucket should expire")
+	}
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// takeScript 在Redis中补充令牌后尝试取一个令牌，桶补满后过期
+// KEYS[1] 桶  ARGV[1] 每秒补充的令牌数  ARGV[2] 桶的容量  ARGV[3] 当前时间（毫秒）  ARGV[4] 过期时间（毫秒）
+// 各副本的时钟可能不一致，时间只往前走
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local b = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(b[1])
+local ts = tonumber(b[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+if now > ts then
+	tokens = math.min(burst, tokens + (now - ts) * rate / 1000)
+	ts = now
+end
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tokens, 'ts', ts)
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+return allowed
+`)
+
+// Redis 所有副本共享的令牌桶
+type Redis struct {
+	rc     *redis.Client
+	prefix string
+	rate   float64
+	burst  int
+	ttl    int64 // 桶补满的时间（毫秒），之后桶可以删除
+}
+
+var _ Limiter = (*Redis)(nil)
+
+// NewRedis 创建Redis中的令牌桶，桶的key为 prefix:key
+func NewRedis(rc *redis.Client, prefix string, rate float64, burst int) *Redis {
+	return &Redis{
+		rc:     rc,
+		prefix: prefix,
+		rate:   rate,
+		burst:  burst,
+		ttl:    int64(math.Ceil(float64(burst)/rate*1000)) + 1000,
+	}
+}
+
+// Allow 消耗key对应的桶中的一个令牌
+func (r *Redis) Allow(ctx context.Context, key string) (bool, error) {
+	n, err := takeScript.Run(ctx, r.rc, []string{r.prefix + ":" + key},
+		r.rate, r.burst, time.Now().UnixMilli(), r.ttl,
+	).Int()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
@@ -0,0 +1,26 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/idMiFeng/common/config"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Open 按配置建立Redis连接并检查是否可用
+func Open(cfg *config.RedisConfig) (*redis.Client, error) {
+	rc := redis.NewClient(&redis.Options{
+		Addr:         fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+		Password:     cfg.Password,     // 密码
+		DB:           cfg.DB,           // 数据库
+		PoolSize:     cfg.PoolSize,     // 连接池大小
+		MinIdleConns: cfg.MinIdleConns, // 最小空闲连接数
+	})
+	if err := rc.Ping(context.Background()).Err(); err != nil {
+		rc.Close()
+		return nil, err
+	}
+	return rc, nil
+}
@@ -0,0 +1,155 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/idMiFeng/common/config"
+
+	"github.com/hashicorp/consul/api"
+	"go.uber.org/zap"
+)
+
+// consulWaitTime Watch时阻塞查询的最长等待时间
+const consulWaitTime = 14 * time.Second
+
+type consul struct {
+	client *api.Client
+}
+
+// 确保某个结构体实现了对应的接口
+var _ Registry = (*consul)(nil)
+
+// NewConsul 连接至consul服务
+func NewConsul(cfg *config.ConsulConfig) (Registry, error) {
+	if cfg == nil {
+		return nil, errors.New("registry.consul is not configured")
+	}
+	//api.DefaultConfig返回一个默认的 Consul 配置对象
+	c := api.DefaultConfig()
+	//Address 字段表示要连接的 Consul 服务的地址
+	c.Address = cfg.Addr
+	//一个连接到 Consul 的客户端对象在应用程序中扮演着与 Consul 交互的角色。
+	client, err := api.NewClient(c)
+	if err != nil {
+		return nil, err
+	}
+	return &consul{client}, nil
+}
+
+// Register 将gRPC服务注册到consul，consul通过实例的地址做gRPC健康检查
+func (c *consul) Register(ctx context.Context, ins *Instance) error {
+	// 健康检查，Timeout 表示检查超时时间，Interval 表示检查的间隔时间，DeregisterCriticalServiceAfter 表示如果服务在指定时间内没有通过健康检查，将会从 Consul 注销。
+	check := &api.AgentServiceCheck{
+		GRPC:                           ins.Addr(), // 这里一定是外部可以访问的地址
+		Timeout:                        "10s",
+		Interval:                       "10s",
+		DeregisterCriticalServiceAfter: "20s",
+	}
+	srv := &api.AgentServiceRegistration{
+		ID:      ins.ID,   // 服务唯一ID
+		Name:    ins.Name, // 服务名称
+		Tags:    ins.Tags, // 为服务打标签
+		Meta:    ins.Metadata,
+		Address: ins.Host,
+		Port:    ins.Port,
+		Check:   check,
+	}
+	return c.client.Agent().ServiceRegisterOpts(srv, api.ServiceRegisterOpts{}.WithContext(ctx))
+}
+
+// Deregister 注销服务
+func (c *consul) Deregister(ctx context.Context, ins *Instance) error {
+	return c.client.Agent().ServiceDeregisterOpts(ins.ID, (&api.QueryOptions{}).WithContext(ctx))
+}
+
+// List 服务发现，只返回通过健康检查的实例
+func (c *consul) List(ctx context.Context, name string) ([]*Instance, error) {
+	list, _, err := c.client.Health().Service(name, "", true, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return fromConsul(list), nil
+}
+
+// Watch 使用阻塞查询，实例变化时consul立即返回
+func (c *consul) Watch(ctx context.Context, name string) (<-chan []*Instance, error) {
+	ch := make(chan []*Instance, 1)
+	go func() {
+		defer close(ch)
+		var index uint64
+		for {
+			opts := (&api.QueryOptions{WaitIndex: index, WaitTime: consulWaitTime}).WithContext(ctx)
+			list, meta, err := c.client.Health().Service(name, "", true, opts)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				zap.L().Warn("watch consul service failed", zap.String("service", name), zap.Error(err))
+				if !sleep(ctx, time.Second) {
+					return
+				}
+				continue
+			}
+			// 索引没有变化说明是等待超时，实例没有变化
+			if index != 0 && meta.LastIndex == index {
+				continue
+			}
+			// 索引变小时consul重置了索引，从头开始
+			index = meta.LastIndex
+			if index == 0 {
+				index = 1
+			}
+			if !send(ctx, ch, fromConsul(list)) {
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func fromConsul(list []*api.ServiceEntry) []*Instance {
+	instances := make([]*Instance, 0, len(list))
+	for _, e := range list {
+		host := e.Service.Address
+		if len(host) == 0 {
+			host = e.Node.Address
+		}
+		instances = append(instances, &Instance{
+			ID:       e.Service.ID,
+			Name:     e.Service.Service,
+			Host:     host,
+			Port:     e.Service.Port,
+			Tags:     e.Service.Tags,
+			Metadata: e.Service.Meta,
+		})
+	}
+	return instances
+}
+
+// send 发送最新的实例列表，上一次的还没有被取走时替换掉
+func send(ctx context.Context, ch chan []*Instance, list []*Instance) bool {
+	select {
+	case <-ch:
+	default:
+	}
+	select {
+	case ch <- list:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// sleep 等待d，ctx结束时返回false
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,228 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/idMiFeng/common/config"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+// etcd没有配置时的默认值
+const (
+	defaultEtcdPrefix      = "/services"
+	defaultEtcdTTL         = 10 * time.Second
+	defaultEtcdDialTimeout = 5 * time.Second
+)
+
+// etcd 实例以json保存在 prefix/服务名/实例id 下，key绑定租约
+// 进程存活时续约，退出或者挂掉后租约过期，key被删除，相当于注销
+type etcd struct {
+	client *clientv3.Client
+	prefix string
+	ttl    time.Duration
+
+	mu     sync.Mutex
+	leases map[string]context.CancelFunc // 实例id -> 停止续约
+}
+
+var _ Registry = (*etcd)(nil)
+
+// NewEtcd 连接etcd
+func NewEtcd(cfg *config.EtcdConfig) (Registry, error) {
+	if cfg == nil || len(cfg.Endpoints) == 0 {
+		return nil, errors.New("registry.etcd.endpoints is not configured")
+	}
+	e := &etcd{
+		prefix: strings.TrimSuffix(cfg.Prefix, "/"),
+		ttl:    cfg.TTL,
+		leases: make(map[string]context.CancelFunc),
+	}
+	if len(e.prefix) == 0 {
+		e.prefix = defaultEtcdPrefix
+	}
+	if e.ttl < time.Second {
+		e.ttl = defaultEtcdTTL
+	}
+	dialTimeout := cfg.DialTimeout
+	if dialTimeout <= 0 {
+		dialTimeout = defaultEtcdDialTimeout
+	}
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   cfg.Endpoints,
+		Username:    cfg.Username,
+		Password:    cfg.Password,
+		DialTimeout: dialTimeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	e.client = client
+	return e, nil
+}
+
+func (e *etcd) key(name, id string) string {
+	return e.prefix + "/" + name + "/" + id
+}
+
+// Register 写入实例并在后台续约，租约丢失（如etcd长时间不可用）后重新注册
+func (e *etcd) Register(ctx context.Context, ins *Instance) error {
+	val, err := json.Marshal(ins)
+	if err != nil {
+		return err
+	}
+	leaseId, err := e.put(ctx, ins, string(val))
+	if err != nil {
+		return err
+	}
+	keepCtx, cancel := context.WithCancel(context.Background())
+	e.mu.Lock()
+	if stop, ok := e.leases[ins.ID]; ok {
+		stop()
+	}
+	e.leases[ins.ID] = cancel
+	e.mu.Unlock()
+	go e.keepAlive(keepCtx, ins, string(val), leaseId)
+	return nil
+}
+
+// put 创建租约并写入实例
+func (e *etcd) put(ctx context.Context, ins *Instance, val string) (clientv3.LeaseID, error) {
+	lease, err := e.client.Grant(ctx, int64(e.ttl/time.Second))
+	if err != nil {
+		return 0, err
+	}
+	if _, err = e.client.Put(ctx, e.key(ins.Name, ins.ID), val, clientv3.WithLease(lease.ID)); err != nil {
+		return 0, err
+	}
+	return lease.ID, nil
+}
+
+// keepAlive 续约到ctx结束
+func (e *etcd) keepAlive(ctx context.Context, ins *Instance, val string, leaseId clientv3.LeaseID) {
+	for {
+		ch, err := e.client.KeepAlive(ctx, leaseId)
+		if err == nil {
+			for range ch {
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		zap.L().Warn("etcd lease lost, register again", zap.String("instance", ins.ID), zap.Error(err))
+		for {
+			if !sleep(ctx, time.Second) {
+				return
+			}
+			if leaseId, err = e.put(ctx, ins, val); err == nil {
+				break
+			}
+			zap.L().Warn("etcd register failed", zap.String("instance", ins.ID), zap.Error(err))
+		}
+	}
+}
+
+// Deregister 停止续约并删除实例
+func (e *etcd) Deregister(ctx context.Context, ins *Instance) error {
+	e.mu.Lock()
+	if stop, ok := e.leases[ins.ID]; ok {
+		stop()
+		delete(e.leases, ins.ID)
+	}
+	e.mu.Unlock()
+	_, err := e.client.Delete(ctx, e.key(ins.Name, ins.ID))
+	return err
+}
+
+// List 服务发现，租约有效的实例都是健康的
+func (e *etcd) List(ctx context.Context, name string) ([]*Instance, error) {
+	resp, err := e.client.Get(ctx, e.key(name, ""), clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	instances := make([]*Instance, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		if ins := decodeInstance(kv.Key, kv.Value); ins != nil {
+			instances = append(instances, ins)
+		}
+	}
+	return instances, nil
+}
+
+// Watch 先读出当前的实例，再从读到的版本开始监听变化
+// 监听中断（如版本被压缩）后重新读取
+func (e *etcd) Watch(ctx context.Context, name string) (<-chan []*Instance, error) {
+	ch := make(chan []*Instance, 1)
+	prefix := e.key(name, "")
+	go func() {
+		defer close(ch)
+		for {
+			resp, err := e.client.Get(ctx, prefix, clientv3.WithPrefix())
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				zap.L().Warn("list etcd service failed", zap.String("service", name), zap.Error(err))
+				if !sleep(ctx, time.Second) {
+					return
+				}
+				continue
+			}
+			current := make(map[string]*Instance, len(resp.Kvs))
+			for _, kv := range resp.Kvs {
+				if ins := decodeInstance(kv.Key, kv.Value); ins != nil {
+					current[string(kv.Key)] = ins
+				}
+			}
+			if !send(ctx, ch, values(current)) {
+				return
+			}
+			wch := e.client.Watch(clientv3.WithRequireLeader(ctx), prefix, clientv3.WithPrefix(), clientv3.WithRev(resp.Header.Revision+1))
+			for wresp := range wch {
+				if err := wresp.Err(); err != nil {
+					zap.L().Warn("watch etcd service failed", zap.String("service", name), zap.Error(err))
+					break
+				}
+				for _, ev := range wresp.Events {
+					if ev.Type == clientv3.EventTypeDelete {
+						delete(current, string(ev.Kv.Key))
+						continue
+					}
+					if ins := decodeInstance(ev.Kv.Key, ev.Kv.Value); ins != nil {
+						current[string(ev.Kv.Key)] = ins
+					}
+				}
+				if !send(ctx, ch, values(current)) {
+					return
+				}
+			}
+			if !sleep(ctx, time.Second) {
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func decodeInstance(key, val []byte) *Instance {
+	ins := new(Instance)
+	if err := json.Unmarshal(val, ins); err != nil {
+		zap.L().Warn("invalid instance in etcd", zap.ByteString("key", key), zap.Error(err))
+		return nil
+	}
+	return ins
+}
+
+func values(m map[string]*Instance) []*Instance {
+	list := make([]*Instance, 0, len(m))
+	for _, ins := range m {
+		list = append(list, ins)
+	}
+	return list
+}
@@ -0,0 +1,113 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/idMiFeng/common/config"
+)
+
+// 面向接口开发
+// 我不关心对方是什么（类型是什么），只关心对方能做什么（方法）。
+// 抽象做的好，后期可以很方便的切换不同的注册中心：接口中只有自己定义的实例类型，不依赖某个注册中心的库
+
+// 支持的注册中心
+const (
+	KindConsul = "consul"
+	KindEtcd   = "etcd"
+	KindStatic = "static"
+)
+
+// Instance 一个服务实例
+type Instance struct {
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Host     string            `json:"host"`
+	Port     int               `json:"port"`
+	Tags     []string          `json:"tags,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Addr 实例的 host:port
+func (i *Instance) Addr() string {
+	return net.JoinHostPort(i.Host, strconv.Itoa(i.Port))
+}
+
+// NewInstance 创建实例，id为 服务名-host-port
+func NewInstance(name, host string, port int) *Instance {
+	return &Instance{
+		ID:   fmt.Sprintf("%s-%s-%d", name, host, port),
+		Name: name,
+		Host: host,
+		Port: port,
+	}
+}
+
+// Registry 注册中心
+type Registry interface {
+	// Register 注册实例，重复注册时覆盖
+	Register(ctx context.Context, ins *Instance) error
+	// Deregister 注销实例
+	Deregister(ctx context.Context, ins *Instance) error
+	// List 服务发现，只返回健康的实例
+	List(ctx context.Context, name string) ([]*Instance, error)
+	// Watch 先返回当前的实例，之后实例变化时返回变化后的全部实例，ctx结束后关闭channel
+	Watch(ctx context.Context, name string) (<-chan []*Instance, error)
+}
+
+var Reg Registry
+
+// Init 按配置连接注册中心，初始化全局的Reg，并注册对应的gRPC resolver
+func Init(cfg *config.RegistryConfig) (err error) {
+	if cfg == nil {
+		return errors.New("registry is not configured")
+	}
+	switch cfg.Kind {
+	case "", KindConsul:
+		Reg, err = NewConsul(cfg.Consul)
+	case KindEtcd:
+		Reg, err = NewEtcd(cfg.Etcd)
+	case KindStatic:
+		Reg, err = NewStatic(cfg.Static)
+	default:
+		return fmt.Errorf("unknown registry kind %q", cfg.Kind)
+	}
+	if err != nil {
+		return err
+	}
+	registerResolver(Reg)
+	return nil
+}
+
+// AdvertiseHost 注册到注册中心的地址：配置了advertise时使用advertise，否则使用ip
+// 都没有配置或者配置的是 0.0.0.0 时，使用第一个非回环网卡的IPv4地址
+func AdvertiseHost(advertise, ip string) (string, error) {
+	if len(advertise) > 0 {
+		return advertise, nil
+	}
+	if parsed := net.ParseIP(ip); len(ip) > 0 && (parsed == nil || !parsed.IsUnspecified()) {
+		return ip, nil
+	}
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return "", err
+	}
+	for _, a := range addrs {
+		if n, ok := a.(*net.IPNet); ok && !n.IP.IsLoopback() && n.IP.To4() != nil {
+			return n.IP.String(), nil
+		}
+	}
+	return "", errors.New("no advertise address, please set registry.advertise")
+}
+
+// IsLoopback 注册的地址是否为回环地址，其他机器上的服务和consul的健康检查都访问不到
+func IsLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,112 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"google.golang.org/grpc/attributes"
+	"google.golang.org/grpc/resolver"
+)
+
+// gRPC客户端通过 Target 返回的地址连接服务，resolver从注册中心Watch服务的实例，实例变化时更新连接
+// 各个注册中心都实现了Watch，resolver不关心用的是哪个注册中心
+
+// Scheme resolver的scheme
+const Scheme = "registry"
+
+// Target gRPC客户端连接服务时使用的地址，如 registry:///goods_srv
+func Target(name string) string {
+	return Scheme + ":///" + name
+}
+
+var errNoInstance = errors.New("no available instance")
+
+// instanceKey 实例信息在地址属性中的key
+type instanceKey struct{}
+
+// InstanceOf 返回地址对应的实例，可以在负载均衡中按标签和元数据选择实例
+func InstanceOf(addr resolver.Address) *Instance {
+	ins, _ := addr.Attributes.Value(instanceKey{}).(*Instance)
+	return ins
+}
+
+var (
+	builderMu sync.RWMutex
+	builder   = &resolverBuilder{}
+	once      sync.Once
+)
+
+// registerResolver 使用reg解析 registry:/// 的地址
+func registerResolver(reg Registry) {
+	builderMu.Lock()
+	builder.reg = reg
+	builderMu.Unlock()
+	once.Do(func() {
+		resolver.Register(builder)
+	})
+}
+
+type resolverBuilder struct {
+	reg Registry
+}
+
+func (b *resolverBuilder) Scheme() string {
+	return Scheme
+}
+
+func (b *resolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	builderMu.RLock()
+	reg := b.reg
+	builderMu.RUnlock()
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := reg.Watch(ctx, serviceName(target))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	r := &registryResolver{cc: cc, cancel: cancel, done: make(chan struct{})}
+	go r.watch(ch)
+	return r, nil
+}
+
+// serviceName 从 registry:///goods_srv 中取出服务名
+func serviceName(target resolver.Target) string {
+	if len(target.URL.Path) > 1 {
+		return target.URL.Path[1:]
+	}
+	return target.URL.Opaque
+}
+
+type registryResolver struct {
+	cc     resolver.ClientConn
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// watch 实例变化时更新gRPC的地址列表，没有实例时报告错误，gRPC会在调用时返回Unavailable
+func (r *registryResolver) watch(ch <-chan []*Instance) {
+	defer close(r.done)
+	for list := range ch {
+		if len(list) == 0 {
+			r.cc.ReportError(errNoInstance)
+			continue
+		}
+		addrs := make([]resolver.Address, 0, len(list))
+		for _, ins := range list {
+			addrs = append(addrs, resolver.Address{
+				Addr:       ins.Addr(),
+				Attributes: attributes.New(instanceKey{}, ins),
+			})
+		}
+		_ = r.cc.UpdateState(resolver.State{Addresses: addrs})
+	}
+}
+
+// ResolveNow Watch已经在实例变化时推送了，不需要主动查询
+func (r *registryResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+func (r *registryResolver) Close() {
+	r.cancel()
+	<-r.done
+}
@@ -0,0 +1,129 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/idMiFeng/common/config"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// staticPollInterval 检查地址文件是否修改的间隔
+const staticPollInterval = 2 * time.Second
+
+// static 本地运行时不需要部署注册中心，服务地址写在文件中：
+//
+//	goods_srv:
+//	  - "127.0.0.1:8381"
+//	stock_srv:
+//	  - "127.0.0.1:8382"
+//
+// 注册和注销不做任何事，实例不做健康检查
+type static struct {
+	file string
+}
+
+var _ Registry = (*static)(nil)
+
+// NewStatic 使用地址文件，文件必须存在且格式正确
+func NewStatic(cfg *config.StaticConfig) (Registry, error) {
+	if cfg == nil || len(cfg.File) == 0 {
+		return nil, errors.New("registry.static.file is not configured")
+	}
+	s := &static{file: cfg.File}
+	if _, err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *static) Register(ctx context.Context, ins *Instance) error {
+	zap.L().Info("static registry, skip register", zap.String("instance", ins.ID))
+	return nil
+}
+
+func (s *static) Deregister(ctx context.Context, ins *Instance) error {
+	return nil
+}
+
+func (s *static) List(ctx context.Context, name string) ([]*Instance, error) {
+	services, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return services[name], nil
+}
+
+// Watch 定时检查文件的修改时间，修改后重新读取
+func (s *static) Watch(ctx context.Context, name string) (<-chan []*Instance, error) {
+	services, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan []*Instance, 1)
+	ch <- services[name]
+	go func() {
+		defer close(ch)
+		modTime := s.modTime()
+		for sleep(ctx, staticPollInterval) {
+			t := s.modTime()
+			if t.Equal(modTime) {
+				continue
+			}
+			modTime = t
+			services, err := s.load()
+			if err != nil {
+				// 文件改到一半时可能格式不对，保留之前的地址
+				zap.L().Warn("load static registry failed", zap.String("file", s.file), zap.Error(err))
+				continue
+			}
+			if !send(ctx, ch, services[name]) {
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (s *static) modTime() time.Time {
+	fi, err := os.Stat(s.file)
+	if err != nil {
+		return time.Time{}
+	}
+	return fi.ModTime()
+}
+
+// load 读取文件，返回服务名到实例的映射
+func (s *static) load() (map[string][]*Instance, error) {
+	v := viper.New()
+	v.SetConfigFile(s.file)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	var addrs map[string][]string
+	if err := v.Unmarshal(&addrs); err != nil {
+		return nil, err
+	}
+	services := make(map[string][]*Instance, len(addrs))
+	for name, list := range addrs {
+		for _, addr := range list {
+			host, p, err := net.SplitHostPort(addr)
+			if err != nil {
+				return nil, fmt.Errorf("service %s: %w", name, err)
+			}
+			port, err := strconv.Atoi(p)
+			if err != nil {
+				return nil, fmt.Errorf("service %s: invalid port %q", name, p)
+			}
+			services[name] = append(services[name], NewInstance(name, host, port))
+		}
+	}
+	return services, nil
+}
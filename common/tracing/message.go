@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// 消息的trace通过消息属性传递，延迟消息和重试的消息也能关联到发送消息的请求
+
+const messageTracer = "github.com/idMiFeng/common/tracing/message"
+
+// Inject 把ctx中的trace写入消息属性，props 不能为空
+func Inject(ctx context.Context, props map[string]string) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(props))
+}
+
+// Extract 从消息属性中取出trace放到ctx中
+func Extract(ctx context.Context, props map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(props))
+}
+
+// StartPublish 创建发送消息的span，并把它写入消息属性，props 不能为空
+// ctx 中没有trace时使用消息属性中已有的trace，比如中继投递本地消息表中的消息
+func StartPublish(ctx context.Context, topic string, props map[string]string) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = Extract(ctx, props)
+	}
+	ctx, span := otel.Tracer(messageTracer).Start(ctx, topic+" send",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("rocketmq"),
+			semconv.MessagingDestinationKey.String(topic),
+		),
+	)
+	Inject(ctx, props)
+	return ctx, span
+}
+
+// StartConsume 创建消费消息的span，父span为发送消息的span
+func StartConsume(ctx context.Context, topic, msgId string, reconsumeTimes int32, props map[string]string) (context.Context, trace.Span) {
+	return otel.Tracer(messageTracer).Start(Extract(ctx, props), topic+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("rocketmq"),
+			semconv.MessagingDestinationKey.String(topic),
+			semconv.MessagingMessageIDKey.String(msgId),
+			attribute.Int("messaging.rocketmq.reconsume_times", int(reconsumeTimes)),
+		),
+	)
+}
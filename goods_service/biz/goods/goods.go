@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	goodsv1 "github.com/idMiFeng/api/shop/goods/v1"
+	"github.com/idMiFeng/common/errno"
 	"github.com/idMiFeng/goods_service/dao/mysql"
-	"github.com/idMiFeng/goods_service/proto"
+	"strconv"
 )
 
-func GetGoodsByRoomId(ctx context.Context, roomId int64) (*proto.GoodsListResp, error) {
+func GetGoodsByRoomId(ctx context.Context, roomId int64) (*goodsv1.GoodsListResp, error) {
 	// 先查es
 	// 再查MySQL
 	// 更新redis
@@ -27,7 +29,6 @@ func GetGoodsByRoomId(ctx context.Context, roomId int64) (*proto.GoodsListResp,
 	)
 
 	for _, obj := range objList {
-		fmt.Printf("obj:%#v\n", obj)
 		idList = append(idList, obj.GoodsId)
 		if obj.IsCurrent == 1 {
 			currGoodsId = obj.GoodsId
@@ -39,11 +40,11 @@ func GetGoodsByRoomId(ctx context.Context, roomId int64) (*proto.GoodsListResp,
 		return nil, err
 	}
 	// 拼装响应数据
-	data := make([]*proto.GoodsInfo, 0, len(goodsList))
+	data := make([]*goodsv1.GoodsInfo, 0, len(goodsList))
 	for _, goods := range goodsList {
 		var headImgs []string
 		json.Unmarshal([]byte(goods.HeadImgs), &headImgs)
-		data = append(data, &proto.GoodsInfo{
+		data = append(data, &goodsv1.GoodsInfo{
 			GoodsId:     goods.GoodsId,
 			CategoryId:  goods.CategoryId,
 			Status:      int32(goods.Status),
@@ -54,9 +55,41 @@ func GetGoodsByRoomId(ctx context.Context, roomId int64) (*proto.GoodsListResp,
 			HeadImgs:    headImgs,
 		})
 	}
-	resp := &proto.GoodsListResp{
+	resp := &goodsv1.GoodsListResp{
 		CurrentGoodsId: currGoodsId,
 		Data:           data,
 	}
 	return resp, nil
 }
+
+// GetGoodsDetail 查询商品详情，订单服务按分计算金额，所以详情中的价格为分
+func GetGoodsDetail(ctx context.Context, goodsId int64) (*goodsv1.GoodsDetail, error) {
+	goodsList, err := mysql.GetGoodsById(ctx, []int64{goodsId})
+	if err != nil {
+		return nil, err
+	}
+	if len(goodsList) == 0 {
+		return nil, errno.ErrQueryEmpty
+	}
+	goods := goodsList[0]
+	var headImgs, videos, detail []string
+	json.Unmarshal([]byte(goods.HeadImgs), &headImgs)
+	json.Unmarshal([]byte(goods.Videos), &videos)
+	json.Unmarshal([]byte(goods.Detail), &detail)
+	return &goodsv1.GoodsDetail{
+		GoodsId:     goods.GoodsId,
+		CategoryId:  goods.CategoryId,
+		Status:      int32(goods.Status),
+		Title:       goods.Title,
+		Code:        strconv.FormatInt(goods.Code, 10),
+		BrandName:   goods.BrandName,
+		MarketPrice: strconv.FormatInt(goods.MarketPrice, 10),
+		Price:       strconv.FormatInt(goods.Price, 10),
+		Brief:       goods.Brief,
+		HeadImgs:    headImgs,
+		Videos:      videos,
+		Detail:      detail,
+		MerchantId:  goods.MerchantId,
+		OrderType:   int32(goods.SaleType),
+	}, nil
+}
@@ -2,7 +2,7 @@ package mysql
 
 import (
 	"context"
-	"github.com/idMiFeng/goods_service/errno"
+	"github.com/idMiFeng/common/errno"
 	"github.com/idMiFeng/goods_service/model"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
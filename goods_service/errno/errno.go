@@ -1,7 +0,0 @@
-package errno
-
-import "errors"
-
-var (
-	ErrQueryFailed = errors.New("query db failed")
-)
@@ -2,20 +2,22 @@ package handler
 
 import (
 	"context"
-	"fmt"
+	goodsv1 "github.com/idMiFeng/api/shop/goods/v1"
+	"github.com/idMiFeng/common/errno"
+	"github.com/idMiFeng/common/logger"
 	"github.com/idMiFeng/goods_service/biz/goods"
-	"github.com/idMiFeng/goods_service/proto"
+	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 // handler -> biz -> dao
 type GoodsSrv struct {
-	proto.UnimplementedGoodsServer
+	goodsv1.UnimplementedGoodsServer
 }
 
-func (GoodsSrv) GetGoodsByRoom(ctx context.Context, req *proto.GetGoodsByRoomReq) (*proto.GoodsListResp, error) {
-	fmt.Println(req.RoomId)
+func (GoodsSrv) GetGoodsByRoom(ctx context.Context, req *goodsv1.GetGoodsByRoomReq) (*goodsv1.GoodsListResp, error) {
+	logger.FromContext(ctx).Debug("GetGoodsByRoom", zap.Int64("room_id", req.GetRoomId()))
 	if req.GetRoomId() <= 0 {
 		// 无效的请求
 		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
@@ -27,6 +29,17 @@ func (GoodsSrv) GetGoodsByRoom(ctx context.Context, req *proto.GetGoodsByRoomReq
 	}
 	return data, nil
 }
-func (GoodsSrv) GetGoodsDetail(context.Context, *proto.GetGoodsDetailReq) (*proto.GoodsDetail, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetGoodsDetail not implemented")
+func (GoodsSrv) GetGoodsDetail(ctx context.Context, req *goodsv1.GetGoodsDetailReq) (*goodsv1.GoodsDetail, error) {
+	logger.FromContext(ctx).Debug("GetGoodsDetail", zap.Int64("goods_id", req.GetGoodsId()))
+	if req.GetGoodsId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
+	}
+	data, err := goods.GetGoodsDetail(ctx, req.GetGoodsId())
+	if err == errno.ErrQueryEmpty {
+		return nil, status.Error(codes.NotFound, "商品不存在")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "内部错误")
+	}
+	return data, nil
 }
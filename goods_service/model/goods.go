@@ -4,10 +4,12 @@ type Goods struct {
 	BaseModel // 嵌入默认的7个字段
 
 	GoodsId     int64
+	MerchantId  int64 // 商品所属的商家
 	CategoryId  int64
 	BrandName   string
 	Code        int64
 	Status      int8
+	SaleType    int8 // 售卖方式：0普通 1秒杀，决定购买时的订单类型
 	Title       string
 	MarketPrice int64
 	Price       int64
@@ -1,146 +0,0 @@
-// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
-// versions:
-// - protoc-gen-go-grpc v1.3.0
-// - protoc             v4.24.0--rc3
-// source: goods.proto
-
-package proto
-
-import (
-	context "context"
-	grpc "google.golang.org/grpc"
-	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
-)
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-// Requires gRPC-Go v1.32.0 or later.
-const _ = grpc.SupportPackageIsVersion7
-
-const (
-	Goods_GetGoodsByRoom_FullMethodName = "/proto.Goods/GetGoodsByRoom"
-	Goods_GetGoodsDetail_FullMethodName = "/proto.Goods/GetGoodsDetail"
-)
-
-// GoodsClient is the client API for Goods service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type GoodsClient interface {
-	GetGoodsByRoom(ctx context.Context, in *GetGoodsByRoomReq, opts ...grpc.CallOption) (*GoodsListResp, error)
-	GetGoodsDetail(ctx context.Context, in *GetGoodsDetailReq, opts ...grpc.CallOption) (*GoodsDetail, error)
-}
-
-type goodsClient struct {
-	cc grpc.ClientConnInterface
-}
-
-func NewGoodsClient(cc grpc.ClientConnInterface) GoodsClient {
-	return &goodsClient{cc}
-}
-
-func (c *goodsClient) GetGoodsByRoom(ctx context.Context, in *GetGoodsByRoomReq, opts ...grpc.CallOption) (*GoodsListResp, error) {
-	out := new(GoodsListResp)
-	err := c.cc.Invoke(ctx, Goods_GetGoodsByRoom_FullMethodName, in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *goodsClient) GetGoodsDetail(ctx context.Context, in *GetGoodsDetailReq, opts ...grpc.CallOption) (*GoodsDetail, error) {
-	out := new(GoodsDetail)
-	err := c.cc.Invoke(ctx, Goods_GetGoodsDetail_FullMethodName, in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-// GoodsServer is the server API for Goods service.
-// All implementations must embed UnimplementedGoodsServer
-// for forward compatibility
-type GoodsServer interface {
-	GetGoodsByRoom(context.Context, *GetGoodsByRoomReq) (*GoodsListResp, error)
-	GetGoodsDetail(context.Context, *GetGoodsDetailReq) (*GoodsDetail, error)
-	mustEmbedUnimplementedGoodsServer()
-}
-
-// UnimplementedGoodsServer must be embedded to have forward compatible implementations.
-type UnimplementedGoodsServer struct {
-}
-
-func (UnimplementedGoodsServer) GetGoodsByRoom(context.Context, *GetGoodsByRoomReq) (*GoodsListResp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetGoodsByRoom not implemented")
-}
-func (UnimplementedGoodsServer) GetGoodsDetail(context.Context, *GetGoodsDetailReq) (*GoodsDetail, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetGoodsDetail not implemented")
-}
-func (UnimplementedGoodsServer) mustEmbedUnimplementedGoodsServer() {}
-
-// UnsafeGoodsServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to GoodsServer will
-// result in compilation errors.
-type UnsafeGoodsServer interface {
-	mustEmbedUnimplementedGoodsServer()
-}
-
-func RegisterGoodsServer(s grpc.ServiceRegistrar, srv GoodsServer) {
-	s.RegisterService(&Goods_ServiceDesc, srv)
-}
-
-func _Goods_GetGoodsByRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetGoodsByRoomReq)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(GoodsServer).GetGoodsByRoom(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: Goods_GetGoodsByRoom_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GoodsServer).GetGoodsByRoom(ctx, req.(*GetGoodsByRoomReq))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _Goods_GetGoodsDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetGoodsDetailReq)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(GoodsServer).GetGoodsDetail(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: Goods_GetGoodsDetail_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GoodsServer).GetGoodsDetail(ctx, req.(*GetGoodsDetailReq))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-// Goods_ServiceDesc is the grpc.ServiceDesc for Goods service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Goods_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "proto.Goods",
-	HandlerType: (*GoodsServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetGoodsByRoom",
-			Handler:    _Goods_GetGoodsByRoom_Handler,
-		},
-		{
-			MethodName: "GetGoodsDetail",
-			Handler:    _Goods_GetGoodsDetail_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "goods.proto",
-}
@@ -0,0 +1,7 @@
+-- 商品所属的商家，商家只能操作自己商品的库存和订单
+-- 老商品默认为0，没有商家能操作，需要按商家入驻的数据逐个回填：
+-- UPDATE `xx_goods` SET `merchant_id` = ? WHERE `goods_id` IN (?) AND `merchant_id` = 0;
+-- 回填完成后再执行 order_service/sql/order_detail_merchant_id.sql 回填订单商品
+ALTER TABLE `xx_goods`
+    ADD COLUMN `merchant_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '商品所属的商家id' AFTER `goods_id`,
+    ADD INDEX (`merchant_id`);
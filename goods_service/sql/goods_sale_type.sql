@@ -0,0 +1,3 @@
+-- 商品的售卖方式，下单时据此决定订单类型，老商品都是普通售卖
+ALTER TABLE `xx_goods`
+    ADD COLUMN `sale_type` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '售卖方式：0普通 1秒杀' AFTER `status`;
@@ -0,0 +1,233 @@
+package order
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	stockv1 "github.com/idMiFeng/api/shop/stock/v1"
+	"github.com/idMiFeng/common/logger"
+	"github.com/idMiFeng/common/ratelimit"
+	"github.com/idMiFeng/order_service/config"
+	"github.com/idMiFeng/order_service/dao/redis"
+	"github.com/idMiFeng/order_service/rpc"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 创建订单的准入控制，秒杀时大量请求在进入扣库存的分布式锁之前被挡住
+// 1. 用户、商品、全局三个维度的令牌桶限流
+// 2. 同一个商品同时只有 concurrency 个请求去扣库存，其余的排队，队列满或排队超时直接拒绝
+// 3. 扣库存时发现商品库存为0后，sold_out_ttl 内直接拒绝该商品的请求，售罄标记只在本副本内有效
+// 被拒绝的请求返回 ResourceExhausted，客户端可以稍后重试
+
+// 拒绝的原因
+const (
+	rejectRateLimited  = "rate_limited"
+	rejectQueueFull    = "queue_full"
+	rejectQueueTimeout = "queue_timeout"
+	rejectSoldOut      = "sold_out"
+)
+
+var admissionRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "order_admission_rejected_total",
+	Help: "创建订单在准入控制中被拒绝的次数，按原因区分",
+}, []string{"reason"})
+
+// Admission 创建订单的准入控制，在 main 中按配置创建，为nil时不限制
+var Admission *Gate
+
+// Gate 创建订单的准入控制
+type Gate struct {
+	user   ratelimit.Limiter // 为nil时该维度不限流
+	goods  ratelimit.Limiter
+	global ratelimit.Limiter
+	cfg    config.AdmissionConfig
+
+	mu      sync.Mutex
+	queues  map[int64]*queue    // 商品id -> 排队，没有请求时删除
+	soldOut map[int64]time.Time // 商品id -> 售罄标记的过期时间，过期后删除
+}
+
+// queue 一个商品的排队，slots 中的元素数为正在扣库存的请求数
+type queue struct {
+	slots   chan struct{}
+	waiting int
+}
+
+// NewGate 按配置创建准入控制，distributed 的令牌桶需要先初始化Redis
+func NewGate(cfg *config.LimitConfig) *Gate {
+	g := &Gate{
+		user:    newLimiter("xx_order_limit:user", cfg.User),
+		goods:   newLimiter("xx_order_limit:goods", cfg.Goods),
+		global:  newLimiter("xx_order_limit:global", cfg.Global),
+		cfg:     cfg.Admission,
+		queues:  make(map[int64]*queue),
+		soldOut: make(map[int64]time.Time),
+	}
+	if g.cfg.QueueTimeout <= 0 {
+		g.cfg.QueueTimeout = time.Second
+	}
+	if g.cfg.SoldOutTTL <= 0 {
+		g.cfg.SoldOutTTL = 3 * time.Second
+	}
+	return g
+}
+
+func newLimiter(name string, cfg config.BucketConfig) ratelimit.Limiter {
+	if cfg.Rate <= 0 {
+		return nil
+	}
+	burst := cfg.Burst
+	if burst <= 0 {
+		burst = int(cfg.Rate) + 1
+	}
+	if cfg.Distributed {
+		return redis.NewRateLimiter(name, cfg.Rate, burst)
+	}
+	return ratelimit.NewLocal(cfg.Rate, burst)
+}
+
+// Admit 检查是否允许创建订单，允许时返回的 release 在创建订单结束后调用
+func (g *Gate) Admit(ctx context.Context, userId, goodsId int64) (release func(), err error) {
+	if g.isSoldOut(goodsId) {
+		return nil, reject(rejectSoldOut, "商品已售罄")
+	}
+	if !g.allow(ctx, g.user, strconv.FormatInt(userId, 10)) ||
+		!g.allow(ctx, g.goods, strconv.FormatInt(goodsId, 10)) ||
+		!g.allow(ctx, g.global, "all") {
+		return nil, reject(rejectRateLimited, "请求过于频繁，请稍后重试")
+	}
+	return g.enqueue(ctx, goodsId)
+}
+
+// allow Redis出错时不限流，避免Redis故障导致无法下单
+func (g *Gate) allow(ctx context.Context, l ratelimit.Limiter, key string) bool {
+	if l == nil {
+		return true
+	}
+	ok, err := l.Allow(ctx, key)
+	if err != nil {
+		logger.FromContext(ctx).Warn("rate limiter failed", zap.String("key", key), zap.Error(err))
+		return true
+	}
+	return ok
+}
+
+// enqueue 占用商品的一个扣库存名额，没有空闲名额时排队等待
+func (g *Gate) enqueue(ctx context.Context, goodsId int64) (func(), error) {
+	if g.cfg.Concurrency <= 0 {
+		return func() {}, nil
+	}
+	g.mu.Lock()
+	q, ok := g.queues[goodsId]
+	if !ok {
+		q = &queue{slots: make(chan struct{}, g.cfg.Concurrency)}
+		g.queues[goodsId] = q
+	}
+	select {
+	case q.slots <- struct{}{}:
+		g.mu.Unlock()
+		return func() { g.release(goodsId, q) }, nil
+	default:
+	}
+	if q.waiting >= g.cfg.QueueSize {
+		g.mu.Unlock()
+		return nil, reject(rejectQueueFull, "排队人数过多，请稍后重试")
+	}
+	q.waiting++
+	g.mu.Unlock()
+
+	timer := time.NewTimer(g.cfg.QueueTimeout)
+	defer timer.Stop()
+	var err error
+	select {
+	case q.slots <- struct{}{}:
+	case <-timer.C:
+		err = reject(rejectQueueTimeout, "排队超时，请稍后重试")
+	case <-ctx.Done():
+		err = status.FromContextError(ctx.Err()).Err()
+	}
+	g.mu.Lock()
+	q.waiting--
+	g.removeIdle(goodsId, q)
+	g.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	// 排队期间商品可能已经售罄
+	if g.isSoldOut(goodsId) {
+		g.release(goodsId, q)
+		return nil, reject(rejectSoldOut, "商品已售罄")
+	}
+	return func() { g.release(goodsId, q) }, nil
+}
+
+// release 归还扣库存名额
+func (g *Gate) release(goodsId int64, q *queue) {
+	g.mu.Lock()
+	<-q.slots
+	g.removeIdle(goodsId, q)
+	g.mu.Unlock()
+}
+
+// removeIdle 商品没有正在扣库存和排队的请求时删除它的排队，避免商品越来越多时一直占用内存
+// 调用方需要持有 g.mu
+func (g *Gate) removeIdle(goodsId int64, q *queue) {
+	if q.waiting == 0 && len(q.slots) == 0 && g.queues[goodsId] == q {
+		delete(g.queues, goodsId)
+	}
+}
+
+func (g *Gate) isSoldOut(goodsId int64) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	expireAt, ok := g.soldOut[goodsId]
+	if !ok {
+		return false
+	}
+	if time.Now().After(expireAt) {
+		delete(g.soldOut, goodsId)
+		return false
+	}
+	return true
+}
+
+// markSoldOut 标记商品售罄，同时删除其他已经过期的标记，过期后没有再被查询的商品不会一直留在map中
+func (g *Gate) markSoldOut(goodsId int64) {
+	now := time.Now()
+	g.mu.Lock()
+	for id, expireAt := range g.soldOut {
+		if now.After(expireAt) {
+			delete(g.soldOut, id)
+		}
+	}
+	g.soldOut[goodsId] = now.Add(g.cfg.SoldOutTTL)
+	g.mu.Unlock()
+}
+
+func reject(reason, msg string) error {
+	admissionRejected.WithLabelValues(reason).Inc()
+	return status.Error(codes.ResourceExhausted, msg)
+}
+
+// checkSoldOut 扣库存返回库存不足后查询剩余库存，为0时标记商品售罄
+// 库存不足也可能是剩余的数量少于购买的数量，这时不标记
+func checkSoldOut(ctx context.Context, goodsId int64) {
+	if Admission == nil {
+		return
+	}
+	stock, err := rpc.StockCli.GetStock(ctx, &stockv1.GoodsStockInfo{GoodsId: goodsId})
+	if err != nil {
+		logger.FromContext(ctx).Warn("StockCli.GetStock failed", zap.Int64("goods_id", goodsId), zap.Error(err))
+		return
+	}
+	if stock.GetNum() <= 0 {
+		Admission.markSoldOut(goodsId)
+	}
+}
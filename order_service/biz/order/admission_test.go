@@ -0,0 +1,135 @@
+package order
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/idMiFeng/order_service/config"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestGate(admission config.AdmissionConfig) *Gate {
+	return NewGate(&config.LimitConfig{Admission: admission})
+}
+
+func assertRejected(t *testing.T, err error) {
+	t.Helper()
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("err = %v, want ResourceExhausted", err)
+	}
+}
+
+func (g *Gate) queueCount() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.queues)
+}
+
+func TestGateRateLimited(t *testing.T) {
+	g := NewGate(&config.LimitConfig{User: config.BucketConfig{Rate: 0.001, Burst: 2}})
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		release, err := g.Admit(ctx, 1, 10)
+		if err != nil {
+			t.Fatalf("Admit: %v", err)
+		}
+		release()
+	}
+	_, err := g.Admit(ctx, 1, 10)
+	assertRejected(t, err)
+	// 其他用户不受影响
+	if _, err = g.Admit(ctx, 2, 10); err != nil {
+		t.Fatalf("Admit other user: %v", err)
+	}
+}
+
+// 名额被占用时排队，队列满或排队超时拒绝，名额归还后排队的请求继续
+func TestGateQueue(t *testing.T) {
+	g := newTestGate(config.AdmissionConfig{Concurrency: 1, QueueSize: 1, QueueTimeout: 50 * time.Millisecond})
+	ctx := context.Background()
+	release, err := g.Admit(ctx, 1, 10)
+	if err != nil {
+		t.Fatalf("Admit: %v", err)
+	}
+	// 排队超时
+	_, err = g.Admit(ctx, 2, 10)
+	assertRejected(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		r, err := g.Admit(ctx, 3, 10)
+		if err == nil {
+			r()
+		}
+		done <- err
+	}()
+	// 等待上面的请求开始排队
+	for {
+		g.mu.Lock()
+		waiting := g.queues[10].waiting
+		g.mu.Unlock()
+		if waiting == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	_, err = g.Admit(ctx, 4, 10)
+	assertRejected(t, err)
+	release()
+	if err = <-done; err != nil {
+		t.Fatalf("queued Admit: %v", err)
+	}
+	// 没有请求后删除商品的排队
+	if n := g.queueCount(); n != 0 {
+		t.Fatalf("queues = %d, want 0", n)
+	}
+}
+
+// 排队超时和被取消的请求也会删除空闲的排队
+func TestGateRemoveIdleQueue(t *testing.T) {
+	g := newTestGate(config.AdmissionConfig{Concurrency: 1, QueueSize: 1, QueueTimeout: 10 * time.Millisecond})
+	release, err := g.Admit(context.Background(), 1, 10)
+	if err != nil {
+		t.Fatalf("Admit: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = g.Admit(ctx, 2, 10); status.Code(err) != codes.Canceled {
+		t.Fatalf("err = %v, want Canceled", err)
+	}
+	if n := g.queueCount(); n != 1 {
+		t.Fatalf("queues = %d, want 1", n)
+	}
+	release()
+	if n := g.queueCount(); n != 0 {
+		t.Fatalf("queues = %d, want 0", n)
+	}
+}
+
+func TestGateSoldOut(t *testing.T) {
+	g := newTestGate(config.AdmissionConfig{Concurrency: 1, QueueSize: 1, SoldOutTTL: 20 * time.Millisecond})
+	ctx := context.Background()
+	g.markSoldOut(10)
+	_, err := g.Admit(ctx, 1, 10)
+	assertRejected(t, err)
+	if n := g.queueCount(); n != 0 {
+		t.Fatalf("queues = %d, want 0", n)
+	}
+	time.Sleep(30 * time.Millisecond)
+	// 标记其他商品时删除已经过期的标记
+	g.markSoldOut(11)
+	g.mu.Lock()
+	_, ok := g.soldOut[10]
+	g.mu.Unlock()
+	if ok {
+		t.Fatal("expired sold out mark not removed")
+	}
+	release, err := g.Admit(ctx, 1, 10)
+	if err != nil {
+		t.Fatalf("Admit after sold out expired: %v", err)
+	}
+	release()
+}
@@ -0,0 +1,73 @@
+package order
+
+import (
+	"context"
+	"strconv"
+
+	goodsv1 "github.com/idMiFeng/api/shop/goods/v1"
+	orderv1 "github.com/idMiFeng/api/shop/order/v1"
+	"github.com/idMiFeng/common/auth"
+	"github.com/idMiFeng/order_service/dao/mysql"
+	"github.com/idMiFeng/order_service/model"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// Detail 订单详情，买家只能查看自己的订单
+// 商家的权限已经在认证中按订单所属的商家检查过，不再比较买家
+func Detail(ctx context.Context, param *orderv1.OrderDetailReq) (*orderv1.OrderDetailInfo, error) {
+	o, err := mysql.QueryOrder(ctx, param.OrderId)
+	if err == gorm.ErrRecordNotFound || (err == nil && o.UserId != param.UserId && !merchantScoped(ctx)) {
+		return nil, status.Error(codes.NotFound, "订单不存在")
+	}
+	if err != nil {
+		zap.L().Error("mysql.QueryOrder failed", zap.Int64("order_id", param.OrderId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "query order failed")
+	}
+	details, err := mysql.QueryOrderDetail(ctx, param.OrderId)
+	if err != nil {
+		zap.L().Error("mysql.QueryOrderDetail failed", zap.Int64("order_id", param.OrderId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "query order detail failed")
+	}
+	info := &orderv1.OrderDetailInfo{
+		OrderInfo: &orderv1.OrderInfo{
+			OrderId:    o.OrderId,
+			UserId:     o.UserId,
+			Status:     o.Status,
+			PayChannel: strconv.Itoa(int(o.PayChannel)),
+			PayAmount:  o.PayAmount,
+		},
+		GoodsInfo: make([]*goodsv1.GoodsInfo, 0, len(details)),
+	}
+	// 有交易单号说明已经支付，支付时间才有意义
+	if len(o.TradeId) > 0 {
+		info.OrderInfo.PayTime = timestamppb.New(o.PayTime)
+	}
+	for _, d := range details {
+		info.GoodsInfo = append(info.GoodsInfo, &goodsv1.GoodsInfo{
+			GoodsId: d.GoodsId,
+			Price:   strconv.FormatInt(d.Price, 10),
+		})
+	}
+	return info, nil
+}
+
+// merchantScoped 调用方是否以商家身份通过了订单所属商家的检查
+func merchantScoped(ctx context.Context) bool {
+	id, ok := auth.FromContext(ctx)
+	return ok && id.MerchantScoped
+}
+
+// MerchantOf 订单所属的商家，订单的商品都属于同一个商家时才返回该商家，订单不存在时返回0
+// 注册到认证中，商家只能查看自己商品的订单，包含其他商家商品的订单不允许查看
+func MerchantOf(ctx context.Context, orderId int64) (int64, error) {
+	details, err := mysql.QueryOrderDetail(ctx, orderId)
+	if err != nil {
+		return 0, err
+	}
+	return model.SingleMerchant(details), nil
+}
@@ -0,0 +1,37 @@
+package order
+
+import (
+	"github.com/idMiFeng/order_service/config"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/status"
+)
+
+// 创建订单的结果
+const (
+	createResultCreated   = "created"   // 新建了订单
+	createResultDuplicate = "duplicate" // 幂等键重复，返回第一次请求的结果
+	createResultFailed    = "failed"    // 创建失败
+)
+
+var createTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "order_create_total",
+	Help: "创建订单的次数，按分布式事务模式、结果和状态码区分",
+}, []string{"mode", "result", "code"})
+
+// countCreate 记录一次创建订单的结果，err 不为空时结果为失败
+func countCreate(result string, err error) {
+	if err != nil {
+		result = createResultFailed
+	}
+	createTotal.WithLabelValues(txMode(), result, status.Code(err).String()).Inc()
+}
+
+// txMode 当前使用的分布式事务模式
+func txMode() string {
+	if config.Conf.OrderConfig != nil && len(config.Conf.OrderConfig.TxMode) > 0 {
+		return config.Conf.OrderConfig.TxMode
+	}
+	return config.TxModeRocketMQ
+}
@@ -3,20 +3,23 @@ package order
 import (
 	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"strconv"
+	"sync"
+	"time"
 
+	goodsv1 "github.com/idMiFeng/api/shop/goods/v1"
+	orderv1 "github.com/idMiFeng/api/shop/order/v1"
+	stockv1 "github.com/idMiFeng/api/shop/stock/v1"
+	"github.com/idMiFeng/common/broker"
+	"github.com/idMiFeng/common/tracing"
 	"github.com/idMiFeng/order_service/config"
-	"github.com/idMiFeng/order_service/dao/mq"
 	"github.com/idMiFeng/order_service/dao/mysql"
+	"github.com/idMiFeng/order_service/errno"
 	"github.com/idMiFeng/order_service/model"
-	"github.com/idMiFeng/order_service/proto"
 	"github.com/idMiFeng/order_service/rpc"
 	"github.com/idMiFeng/order_service/third_party/snowflake"
 
-	"github.com/apache/rocketmq-client-go/v2"
-	"github.com/apache/rocketmq-client-go/v2/primitive"
-	"github.com/apache/rocketmq-client-go/v2/producer"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -26,139 +29,288 @@ import (
 // biz层业务代码
 // biz -> dao
 
-// OrderEntity 自定义结构体，实现了两个方法
-// 发送事务消息的时候 RocketMQ 会自动根据情况调用那两个方法
+// 事务消息的属性中携带订单号，回查时据此定位订单
+const propOrderId = "ORDER_ID"
+
+// pending 正在执行本地事务的订单，key为订单号
+// 事务生产者全局只有一个，执行本地事务时通过订单号找到对应的请求参数
+var pending sync.Map
+
+// OrderEntity 一次创建订单请求的上下文
 type OrderEntity struct {
-	OrderId int64           //订单号
-	Param   *proto.OrderReq //订单详细
-	err     error           //报错时返回的错误
+	OrderId int64             //订单号
+	Param   *orderv1.OrderReq //订单详细
+	err     error             //报错时返回的错误
+
+	// 创建订单请求的ctx，本地事务在发送half消息时同步执行，沿用请求的截止时间和调用方身份
+	ctx context.Context
+
+	price          int64 // 商品单价，本地事务中查询商品得到
+	payAmount      int64 // 订单金额，单价*数量
+	orderType      int32 // 订单类型，由商品决定
+	merchantId     int64 // 商品所属的商家
+	stockUncertain bool  // 扣减库存超时或者下游不可用，库存可能已经扣减
+}
+
+// TxListener 订单服务的事务消息监听器，在 main 中随消息中间件一起创建
+// 发送事务消息的时候消息中间件会自动根据情况调用这两个方法
+type TxListener struct{}
+
+var _ broker.TxListener = TxListener{}
+
+// ExecuteLocalTransaction 当发送prepare(half) message 成功后, 这个方法（本地的事务方法）就会被执行
+func (TxListener) ExecuteLocalTransaction(msg *broker.Message) broker.TxState {
+	orderId, err := orderIdOf(msg)
+	if err != nil {
+		zap.L().Error("ExecuteLocalTransaction invalid msg", zap.Error(err))
+		return broker.TxRollback // 还没有扣减库存，丢弃half-message
+	}
+	v, ok := pending.Load(orderId)
+	if !ok {
+		zap.L().Error("ExecuteLocalTransaction order not pending", zap.Int64("order_id", orderId))
+		return broker.TxRollback
+	}
+	o := v.(*OrderEntity)
+	ctx := o.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// 消息属性中带有发送消息的trace
+	return o.execute(tracing.Extract(ctx, msg.Properties))
+}
+
+// CheckLocalTransaction 当 prepare(half) message 没有响应时(一般网络问题)
+// broker 会回查本地事务的状态，此时这个方法会被执行
+func (TxListener) CheckLocalTransaction(msg *broker.Message) broker.TxState {
+	orderId, err := orderIdOf(msg)
+	if err != nil {
+		zap.L().Error("CheckLocalTransaction invalid msg", zap.String("msg_id", msg.MsgId), zap.Error(err))
+		return broker.TxRollback
+	}
+	// 本地事务还在执行中，稍后再查
+	if _, ok := pending.Load(orderId); ok {
+		return broker.TxUnknown
+	}
+	// 检查本地状态是否创建成功订单，订单和订单详情在同一个事务中创建
+	_, err = mysql.QueryOrder(context.Background(), orderId)
+	if err == gorm.ErrRecordNotFound {
+		// 没查询到说明订单创建失败，需要回滚库存
+		return broker.TxCommit
+	}
+	if err != nil {
+		zap.L().Error("CheckLocalTransaction query order failed", zap.Int64("order_id", orderId), zap.Error(err))
+		return broker.TxUnknown
+	}
+	return broker.TxRollback
 }
 
-// 当发送prepare(half) message 成功后, 这个方法（本地的事务方法）就会被执行
-func (o *OrderEntity) ExecuteLocalTransaction(*primitive.Message) primitive.LocalTransactionState {
-	fmt.Println("in ExecuteLocalTransaction...")
+// orderIdOf 优先从消息属性中取订单号，兼容只有消息体的消息
+func orderIdOf(msg *broker.Message) (int64, error) {
+	if v := msg.GetProperty(propOrderId); len(v) > 0 {
+		return strconv.ParseInt(v, 10, 64)
+	}
+	var data model.OrderGoodsStockInfo
+	if err := json.Unmarshal(msg.Body, &data); err != nil {
+		return 0, err
+	}
+	if data.OrderId <= 0 {
+		return 0, errors.New("order_id not found in msg")
+	}
+	return data.OrderId, nil
+}
+
+// execute 执行本地事务：查询商品 -> 扣减库存 -> 创建订单 -> 发送超时消息
+func (o *OrderEntity) execute(ctx context.Context) broker.TxState {
 	if o.Param == nil {
 		zap.L().Error("ExecuteLocalTransaction param is nil")
 		o.err = status.Error(codes.Internal, "invalid OrderEntity")
-		return primitive.CommitMessageState
+		return broker.TxRollback
+	}
+	// 1. 查询商品金额 2. 扣减库存
+	if err := o.reserve(ctx); err != nil {
+		if o.stockUncertain {
+			// 库存可能已经预扣，也可能预扣请求还在路上，投递回滚消息
+			// 库存服务没有预扣记录时记录空回滚，之后到达的预扣被拒绝
+			return broker.TxCommit
+		}
+		// 库存未扣减，丢弃half-message
+		return broker.TxRollback
 	}
+	// 代码能执行到这里说明 扣减库存成功了，
+	// 从这里开始如果本地事务执行失败就需要回滚库存
+	// 3. 创建订单
+	orderData, orderDetail := o.orderModels()
+	// 在本地事务创建订单和订单详情记录
+	err := mysql.CreateOrderWithTransation(ctx, orderData, orderDetail)
+	if err != nil {
+		// 本地事务执行失败了，上一步已经库存扣减成功
+		// 就需要将库存回滚的消息投递出去，下游根据消息进行库存回滚
+		zap.L().Error("CreateOrderWithTransation failed", zap.Error(err))
+		return broker.TxCommit // 将之前发送的hal-message commit
+	}
+	// 发送延迟消息，支付超时时间按订单类型配置
+	// 订单已经创建，请求超时或取消时也要发送
+	err = SendPayTimeout(tracing.Detach(ctx), o.OrderId, time.Now().Add(payTimeout(o.orderType)))
+	if err != nil {
+		// 发送延时消息失败
+		zap.L().Error("send delay msg failed", zap.Error(err))
+		return broker.TxCommit
+	}
+
+	// 走到这里说明 本地事务执行成功
+	// 需要将之前的half-message rollback， 丢弃掉
+	return broker.TxRollback
+}
+
+// reserve 查询商品金额并扣减库存，失败时库存没有扣减，stockUncertain 为true时除外
+func (o *OrderEntity) reserve(ctx context.Context) error {
 	param := o.Param
-	ctx := context.Background()
 	// 1. 查询商品金额（营销）--> RPC连接 goods_service
-	goodsDatail, err := rpc.GoodsCli.GetGoodsDetail(ctx, &proto.GetGoodsDetailReq{
+	goodsDatail, err := rpc.GoodsCli.GetGoodsDetail(ctx, &goodsv1.GetGoodsDetailReq{
 		GoodsId: param.GoodsId,
 		UserId:  param.UserId,
 	})
 	if err != nil {
 		zap.L().Error("GoodsCli.GetGoodsDetail failed", zap.Error(err))
-		// 库存未扣减
 		o.err = status.Error(codes.Internal, err.Error())
-		return primitive.RollbackMessageState
+		return o.err
 	}
-	payAmountStr := goodsDatail.Price
-	payAmount, _ := strconv.ParseInt(payAmountStr, 10, 64)
+	o.price, _ = strconv.ParseInt(goodsDatail.Price, 10, 64)
+	o.payAmount = o.price * param.Num
+	o.merchantId = goodsDatail.MerchantId
+	o.orderType = goodsDatail.OrderType
 
-	// 2. 库存校验及扣减  --> RPC连接 stock_service
-	_, err = rpc.StockCli.ReduceStock(ctx, &proto.GoodsStockInfo{
+	// 2. 库存校验及预扣  --> RPC连接 stock_service
+	// 以订单号作为全局事务id，结果不确定时投递的回滚消息按同一个事务id执行 Cancel
+	// 回滚先于预扣到达时库存服务记录空回滚，之后到达的预扣被拒绝，不会在回滚之后扣减库存
+	_, err = rpc.StockCli.TryReserve(ctx, &stockv1.ReserveReq{
+		TxId:    strconv.FormatInt(o.OrderId, 10),
 		OrderId: o.OrderId,
-		GoodsId: o.Param.GoodsId,
-		Num:     o.Param.Num,
+		GoodsId: param.GoodsId,
+		Num:     param.Num,
 	})
+	if status.Code(err) == codes.ResourceExhausted {
+		zap.L().Warn("StockCli.TryReserve understock", zap.Int64("goods_id", param.GoodsId))
+		checkSoldOut(ctx, param.GoodsId)
+		o.err = status.Error(codes.ResourceExhausted, "库存不足")
+		return o.err
+	}
 	if err != nil {
-		// 库存扣减失败，丢弃half-message
-		zap.L().Error("StockCli.ReduceStock failed", zap.Error(err))
-		o.err = status.Error(codes.Internal, "ReduceStock failed")
-		return primitive.RollbackMessageState
+		zap.L().Error("StockCli.TryReserve failed", zap.Error(err))
+		switch status.Code(err) {
+		case codes.DeadlineExceeded, codes.Canceled, codes.Unavailable:
+			// 请求可能已经到达库存服务
+			o.stockUncertain = true
+		}
+		o.err = status.Error(codes.Internal, "TryReserve failed")
+		return o.err
 	}
-	// 代码能执行到这里说明 扣减库存成功了，
-	// 从这里开始如果本地事务执行失败就需要回滚库存
-	// 3. 创建订单
-	// 生成订单表
-	orderData := model.Order{
+	return nil
+}
+
+// orderModels 生成订单表和订单详情表的记录
+func (o *OrderEntity) orderModels() (*model.Order, *model.OrderDetail) {
+	param := o.Param
+	orderData := &model.Order{
 		OrderId:        o.OrderId,
 		UserId:         param.UserId,
-		PayAmount:      payAmount,
+		OrderType:      o.orderType,
+		PayAmount:      o.payAmount,
 		ReceiveAddress: param.Address,
 		ReceiveName:    param.Name,
 		ReceivePhone:   param.Phone,
-		Status:         100, // 待支付
+		Status:         model.OrderStatusPending, // 待支付
 	}
-	// mysql.CreateOrder(ctx, &orderData)
-	orderDetail := model.OrderDetail{
-		OrderId: o.OrderId,
-		UserId:  param.UserId,
-		GoodsId: param.GoodsId,
-		Num:     param.Num,
+	orderDetail := &model.OrderDetail{
+		OrderId:    o.OrderId,
+		UserId:     param.UserId,
+		GoodsId:    param.GoodsId,
+		MerchantId: o.merchantId,
+		Num:        param.Num,
+
+		Price:     o.price,
+		PayAmount: o.payAmount,
 	}
-	// mysql.CreateOrderDetail(ctx, &orderDetail)
-	// 在本地事务创建订单和订单详情记录
-	err = mysql.CreateOrderWithTransation(ctx, &orderData, &orderDetail)
-	if err != nil {
-		// 本地事务执行失败了，上一步已经库存扣减成功
-		// 就需要将库存回滚的消息投递出去，下游根据消息进行库存回滚
-		zap.L().Error("CreateOrderWithTransation failed", zap.Error(err))
-		return primitive.CommitMessageState // 将之前发送的hal-message commit
+	return orderData, orderDetail
+}
+
+// Create 创建订单
+// 先经过准入控制，被限流或商品已售罄时直接拒绝
+// 带了幂等键的请求先记录幂等键，重试的请求直接返回第一次创建的订单，不会重复扣减库存
+func Create(ctx context.Context, param *orderv1.OrderReq) (resp *orderv1.OrderResp, err error) {
+	result := createResultCreated
+	defer func() { countCreate(result, err) }()
+	if Admission != nil {
+		var release func()
+		if release, err = Admission.Admit(ctx, param.UserId, param.GoodsId); err != nil {
+			return nil, err
+		}
+		defer release()
 	}
-	// 发送延迟消息
-	// 1s 5s 10s 30s 1m 2m 3m 4m 5m 6m 7m 8m 9m 10m 20m 30m 1h 2h
-	data := model.OrderGoodsStockInfo{
-		OrderId: o.OrderId,
-		GoodsId: param.GoodsId,
-		Num:     param.Num,
+	// 3.1 生成订单号
+	orderId := snowflake.GenID()
+	if len(param.RequestId) == 0 {
+		return create(ctx, orderId, param)
 	}
-	b, _ := json.Marshal(data)
-	msg := primitive.NewMessage("xx_order_timeout", b)
-	msg.WithDelayTimeLevel(3)
-	_, err = mq.Producer.SendSync(context.Background(), msg)
+	err = mysql.CreateOrderRequest(ctx, &model.OrderRequest{
+		UserId:    param.UserId,
+		RequestId: param.RequestId,
+		OrderId:   orderId,
+	})
 	if err != nil {
-		// 发送延时消息失败
-		zap.L().Error("send delay msg failed", zap.Error(err))
-		return primitive.CommitMessageState
+		// 幂等键已存在，说明是重试的请求
+		req, qerr := mysql.QueryOrderRequest(ctx, param.UserId, param.RequestId)
+		if qerr != nil {
+			zap.L().Error("mysql.CreateOrderRequest failed", zap.String("request_id", param.RequestId), zap.Error(err))
+			return nil, status.Error(codes.Internal, "create order request failed")
+		}
+		result = createResultDuplicate
+		return existingOrder(ctx, req.OrderId)
 	}
-
-	// 走到这里说明 本地事务执行成功
-	// 需要将之前的half-message rollback， 丢弃掉
-	return primitive.RollbackMessageState
+	resp, err = create(ctx, orderId, param)
+	if err != nil {
+		// 订单确实没有创建时删除幂等记录，客户端可以用同一个幂等键重试
+		if _, qerr := mysql.QueryOrder(ctx, orderId); qerr == gorm.ErrRecordNotFound {
+			mysql.DeleteOrderRequest(ctx, param.UserId, param.RequestId)
+		}
+	}
+	return resp, err
 }
 
-// 当 prepare(half) message 没有响应时(一般网络问题)
-// broker 会回查本地事务的状态，此时这个方法会被执行
-func (o *OrderEntity) CheckLocalTransaction(*primitive.MessageExt) primitive.LocalTransactionState {
-	// 检查本地状态是否创建成功订单
-	_, err := mysql.QueryOrder(context.Background(), o.OrderId)
-	// 需要再查询订单详情表
+// existingOrder 返回幂等键对应的订单
+func existingOrder(ctx context.Context, orderId int64) (*orderv1.OrderResp, error) {
+	o, err := mysql.QueryOrder(ctx, orderId)
 	if err == gorm.ErrRecordNotFound {
-		// 没查询到说明订单创建失败，需要回滚库存
-		return primitive.CommitMessageState
+		// 第一次的请求还在处理中
+		return nil, status.Error(codes.Aborted, "订单创建中，请稍后重试")
 	}
-	return primitive.RollbackMessageState
+	if err != nil {
+		zap.L().Error("mysql.QueryOrder failed", zap.Int64("order_id", orderId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "query order failed")
+	}
+	return &orderv1.OrderResp{
+		OrderId:   o.OrderId,
+		Status:    o.Status,
+		PayAmount: o.PayAmount,
+	}, nil
 }
 
-func Create(ctx context.Context, param *proto.OrderReq) error {
-	// 3.1 生成订单号
-	orderId := snowflake.GenID()
-
+func create(ctx context.Context, orderId int64, param *orderv1.OrderReq) (*orderv1.OrderResp, error) {
+	if config.Conf.OrderConfig != nil {
+		switch config.Conf.OrderConfig.TxMode {
+		case config.TxModeOutbox:
+			return createWithOutbox(ctx, orderId, param)
+		case config.TxModeSaga:
+			return createWithSaga(ctx, orderId, param)
+		}
+	}
 	orderEntity := &OrderEntity{
 		OrderId: orderId,
 		Param:   param,
+		ctx:     ctx,
 	}
-	//创建生产者
-	p, err := rocketmq.NewTransactionProducer(
-		orderEntity,
-		producer.WithNsResolver(primitive.NewPassthroughResolver([]string{config.Conf.RocketMqConfig.Addr})),
-		//producer.WithNsResolver(primitive.NewPassthroughResolver(endPoint)),
-		producer.WithRetry(2),
-		producer.WithGroupName("order_srv_1"), // 生产者组
-	)
-	if err != nil {
-		zap.L().Error("NewTransactionProducer failed", zap.Error(err))
-		return status.Error(codes.Internal, "NewTransactionProducer failed")
-	}
-	err = p.Start()
-	if err != nil {
-		zap.L().Error("start producer error", zap.Error(err))
-		return status.Error(codes.Internal, "start producer error")
-	}
+	pending.Store(orderId, orderEntity)
+	defer pending.Delete(orderId)
 	// 封装消息 orderId GoodsId num
 	data := model.OrderGoodsStockInfo{
 		OrderId: orderId,
@@ -166,24 +318,113 @@ func Create(ctx context.Context, param *proto.OrderReq) error {
 		Num:     param.Num,
 	}
 	body, _ := json.Marshal(data)
-	msg := &primitive.Message{
+	msg := &broker.Message{
 		Topic: config.Conf.RocketMqConfig.Topic.StockRollback, // xx_stock_rollback
 		Body:  body,
 	}
-	// 发送事务消息，对应RocketMQ事务消息第一步发送Half消息，发送消息给MQserver返回成功后后会根据之前创建生产者传入的结构体自动调用结构体的执行本地事务方法
-	res, err := p.SendMessageInTransaction(context.Background(), msg)
+	msg.WithProperty(propOrderId, strconv.FormatInt(orderId, 10))
+	// 发送事务消息，对应RocketMQ事务消息第一步发送Half消息，发送消息给MQserver返回成功后会调用 TxListener 执行本地事务
+	// 事务消息不随请求取消，只带上请求的trace
+	state, err := broker.MQ.PublishInTransaction(tracing.Detach(ctx), msg)
 	if err != nil {
-		zap.L().Error("SendMessageInTransaction failed", zap.Error(err))
-		return status.Error(codes.Internal, "create order failed")
+		zap.L().Error("PublishInTransaction failed", zap.Error(err))
+		return nil, status.Error(codes.Internal, "create order failed")
 	}
-	zap.L().Info("p.SendMessageInTransaction success", zap.Any("res", res))
 	// 执行到这一步说明生产者事务已有结果，如果回滚库存的消息被投递出去给消费者（commit）说明本地事务执行失败，也就是创建订单失败
-	if res.State == primitive.CommitMessageState {
-		return status.Error(codes.Internal, "create order failed")
+	if state == broker.TxCommit {
+		return nil, status.Error(codes.Internal, "create order failed")
 	}
 	// 其他内部错误
 	if orderEntity.err != nil {
-		return orderEntity.err
+		return nil, orderEntity.err
+	}
+	return &orderv1.OrderResp{
+		OrderId:   orderId,
+		Status:    model.OrderStatusPending,
+		PayAmount: orderEntity.payAmount,
+	}, nil
+}
+
+// 订单关闭原因
+const (
+	CancelReasonUser    = "用户取消"
+	CancelReasonTimeout = "支付超时"
+)
+
+// Cancel 用户取消待支付订单
+func Cancel(ctx context.Context, param *orderv1.CancelOrderReq) error {
+	o, err := mysql.QueryOrder(ctx, param.OrderId)
+	if err == gorm.ErrRecordNotFound || (err == nil && o.UserId != param.UserId) {
+		return status.Error(codes.NotFound, "订单不存在")
+	}
+	if err != nil {
+		zap.L().Error("mysql.QueryOrder failed", zap.Int64("order_id", param.OrderId), zap.Error(err))
+		return status.Error(codes.Internal, "query order failed")
+	}
+	if o.Status != model.OrderStatusPending {
+		return status.Error(codes.FailedPrecondition, "订单不是待支付状态，不能取消")
+	}
+	reason := param.Reason
+	if len(reason) == 0 {
+		reason = CancelReasonUser
+	}
+	err = mysql.CloseOrder(ctx, o.OrderId, reason)
+	if errors.Is(err, errno.ErrOrderStatusChanged) {
+		// 与支付回调或超时关单竞争失败
+		return status.Error(codes.FailedPrecondition, "订单状态已变化，不能取消")
+	}
+	if err != nil {
+		zap.L().Error("mysql.CloseOrder failed", zap.Int64("order_id", o.OrderId), zap.Error(err))
+		return status.Error(codes.Internal, "cancel order failed")
+	}
+	// 订单已关闭，不再需要超时关单，回滚库存
+	CancelPayTimeout(ctx, o.OrderId)
+	err = SendStockRollback(ctx, o.OrderId)
+	if err != nil {
+		// 订单超时消息到达时会再投递一次回滚消息，这里只记录日志
+		zap.L().Warn("SendStockRollback failed after cancel", zap.Int64("order_id", o.OrderId), zap.Error(err))
+	}
+	return nil
+}
+
+// CloseTimeout 支付超时关闭订单并回滚库存，返回错误时需要稍后重试
+func CloseTimeout(ctx context.Context, orderId int64) error {
+	err := mysql.CloseOrder(ctx, orderId, CancelReasonTimeout)
+	if errors.Is(err, errno.ErrOrderStatusChanged) {
+		o, err := mysql.QueryOrder(ctx, orderId)
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		// 已支付的订单不处理
+		if o.Status != model.OrderStatusClosed {
+			return nil
+		}
+		// 订单已被用户取消，再投递一次回滚消息兜底，库存服务按 订单号+商品id 幂等
+	} else if err != nil {
+		return err
+	}
+	return SendStockRollback(ctx, orderId)
+}
+
+// SendStockRollback 投递订单所有商品的库存回滚消息
+func SendStockRollback(ctx context.Context, orderId int64) error {
+	details, err := mysql.QueryOrderDetail(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	for _, d := range details {
+		b, _ := json.Marshal(model.OrderGoodsStockInfo{
+			OrderId: d.OrderId,
+			GoodsId: d.GoodsId,
+			Num:     d.Num,
+		})
+		msg := &broker.Message{Topic: config.Conf.RocketMqConfig.Topic.StockRollback, Body: b}
+		if err = broker.MQ.Publish(ctx, msg); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -0,0 +1,106 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	orderv1 "github.com/idMiFeng/api/shop/order/v1"
+	"github.com/idMiFeng/common/tracing"
+	"github.com/idMiFeng/order_service/biz/outbox"
+	"github.com/idMiFeng/order_service/config"
+	"github.com/idMiFeng/order_service/dao/mysql"
+	"github.com/idMiFeng/order_service/errno"
+	"github.com/idMiFeng/order_service/model"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// createWithOutbox 使用本地消息表创建订单
+// 扣减库存前先写入一条延迟投递的库存回滚消息作为保护，进程在扣减库存之后挂掉时到期由中继投递，回滚库存
+// 扣减库存成功后，订单、订单详情和支付超时消息在同一个本地事务中写入，同时取消保护消息
+// 扣减库存的结果不确定时保留保护消息，库存服务按订单号回滚，预扣请求晚于回滚到达时被拒绝
+// 扣减成功但本地事务失败时保护消息改为立即投递
+func createWithOutbox(ctx context.Context, orderId int64, param *orderv1.OrderReq) (*orderv1.OrderResp, error) {
+	o := &OrderEntity{
+		OrderId: orderId,
+		Param:   param,
+	}
+	key := strconv.FormatInt(orderId, 10)
+	guard := stockGuard(ctx, orderId, param)
+	if err := mysql.CreateOutbox(ctx, []*model.Outbox{guard}); err != nil {
+		zap.L().Error("mysql.CreateOutbox failed", zap.Int64("order_id", orderId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "create order failed")
+	}
+	if err := o.reserve(ctx); err != nil {
+		if !o.stockUncertain {
+			// 库存没有扣减，保护消息不需要了，取消失败时到期投递也没有影响
+			if err := mysql.CancelOutbox(tracing.Detach(ctx), guard.ID); err != nil {
+				zap.L().Warn("mysql.CancelOutbox failed", zap.Int64("order_id", orderId), zap.Error(err))
+			}
+		}
+		return nil, o.err
+	}
+	deadline := time.Now().Add(payTimeout(o.orderType))
+	var events []*model.Outbox
+	if TimeoutScheduler != nil {
+		// Redis延迟任务不在本地事务中，先添加任务，订单没创建成功时任务到期后会忽略
+		if err := SendPayTimeout(ctx, orderId, deadline); err != nil {
+			zap.L().Error("SendPayTimeout failed", zap.Int64("order_id", orderId), zap.Error(err))
+			releaseGuard(ctx, orderId, guard)
+			return nil, status.Error(codes.Internal, "create order failed")
+		}
+	} else {
+		b, _ := json.Marshal(model.OrderTimeoutInfo{
+			OrderId:  orderId,
+			Deadline: deadline.UnixMilli(),
+		})
+		events = append(events, outbox.NewEvent(ctx, config.Conf.RocketMqConfig.Topic.PayTimeOut, key, b, nil, deadline))
+	}
+	orderData, orderDetail := o.orderModels()
+	err := mysql.CreateOrderWithOutbox(ctx, orderData, orderDetail, events, guard.ID)
+	if err == nil {
+		return &orderv1.OrderResp{
+			OrderId:   orderId,
+			Status:    model.OrderStatusPending,
+			PayAmount: o.payAmount,
+		}, nil
+	}
+	zap.L().Error("mysql.CreateOrderWithOutbox failed", zap.Int64("order_id", orderId), zap.Error(err))
+	if !errors.Is(err, errno.ErrOutboxRelayed) {
+		releaseGuard(ctx, orderId, guard)
+	}
+	return nil, status.Error(codes.Internal, "create order failed")
+}
+
+// defaultGuardDelay 没有配置时保护消息的延迟
+const defaultGuardDelay = time.Minute
+
+// stockGuard 构造库存回滚的保护消息，到期之前中继不会取走
+func stockGuard(ctx context.Context, orderId int64, param *orderv1.OrderReq) *model.Outbox {
+	delay := defaultGuardDelay
+	if cfg := config.Conf.OutboxConfig; cfg != nil && cfg.Guard > 0 {
+		delay = cfg.Guard
+	}
+	b, _ := json.Marshal(model.OrderGoodsStockInfo{
+		OrderId: orderId,
+		GoodsId: param.GoodsId,
+		Num:     param.Num,
+	})
+	deliverAt := time.Now().Add(delay)
+	e := outbox.NewEvent(ctx, config.Conf.RocketMqConfig.Topic.StockRollback, strconv.FormatInt(orderId, 10), b, nil, deliverAt)
+	e.NextRetryAt = deliverAt
+	return e
+}
+
+// releaseGuard 库存已经扣减但订单没有创建，保护消息改为立即投递
+// 修改失败时保护消息到期后照常投递，回滚不随请求超时或取消
+func releaseGuard(ctx context.Context, orderId int64, guard *model.Outbox) {
+	if err := mysql.ReleaseOutbox(tracing.Detach(ctx), guard.ID); err != nil {
+		zap.L().Error("mysql.ReleaseOutbox failed", zap.Int64("order_id", orderId), zap.Uint("id", guard.ID), zap.Error(err))
+	}
+}
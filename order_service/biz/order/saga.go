@@ -0,0 +1,176 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	goodsv1 "github.com/idMiFeng/api/shop/goods/v1"
+	orderv1 "github.com/idMiFeng/api/shop/order/v1"
+	stockv1 "github.com/idMiFeng/api/shop/stock/v1"
+	"github.com/idMiFeng/order_service/biz/saga"
+	"github.com/idMiFeng/order_service/dao/mysql"
+	"github.com/idMiFeng/order_service/errno"
+	"github.com/idMiFeng/order_service/model"
+	"github.com/idMiFeng/order_service/rpc"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// CancelReasonCreateFailed 创建订单的 saga 补偿时关闭订单的原因
+const CancelReasonCreateFailed = "创建失败"
+
+// createOrderData 创建订单 saga 各步骤共享的数据
+type createOrderData struct {
+	OrderId    int64
+	Param      *orderv1.OrderReq
+	Price      int64 // 查询商品得到的单价
+	PayAmount  int64 // 订单金额，单价*数量
+	MerchantId int64 // 商品所属的商家
+	OrderType  int32 // 订单类型，由商品决定
+	Deadline   int64 // 支付截止时间（毫秒），查询商品后确定，重试时保持不变
+}
+
+// CreateOrderSaga 创建订单：查询价格 -> 扣减库存 -> 创建订单 -> 添加支付超时
+var CreateOrderSaga = &saga.Definition{
+	Name:    "create_order",
+	NewData: func() interface{} { return new(createOrderData) },
+	Steps: []saga.Step{
+		{Name: "price", Action: sagaPrice},
+		{Name: "reserve_stock", Action: sagaReserveStock, Compensate: sagaReleaseStock},
+		{Name: "persist_order", Action: sagaPersistOrder, Compensate: sagaCloseOrder},
+		{Name: "schedule_timeout", Action: sagaScheduleTimeout, Compensate: sagaCancelTimeout},
+	},
+}
+
+// createWithSaga 使用 saga 编排创建订单，saga id 即订单号
+func createWithSaga(ctx context.Context, orderId int64, param *orderv1.OrderReq) (*orderv1.OrderResp, error) {
+	data := &createOrderData{
+		OrderId: orderId,
+		Param:   param,
+	}
+	err := saga.Exec(ctx, CreateOrderSaga, orderId, data)
+	if err != nil {
+		zap.L().Error("create order saga failed", zap.Int64("order_id", orderId), zap.Error(err))
+		// 下游返回的业务错误原样返回
+		var se interface{ GRPCStatus() *status.Status }
+		if errors.As(err, &se) {
+			if s := se.GRPCStatus(); s.Code() != codes.Internal && s.Code() != codes.Unknown {
+				return nil, s.Err()
+			}
+		}
+		return nil, status.Error(codes.Internal, "create order failed")
+	}
+	return &orderv1.OrderResp{
+		OrderId:   orderId,
+		Status:    model.OrderStatusPending,
+		PayAmount: data.PayAmount,
+	}, nil
+}
+
+// sagaPrice 查询商品金额（营销）--> RPC连接 goods_service
+func sagaPrice(ctx context.Context, v interface{}) error {
+	d := v.(*createOrderData)
+	goodsDatail, err := rpc.GoodsCli.GetGoodsDetail(ctx, &goodsv1.GetGoodsDetailReq{
+		GoodsId: d.Param.GoodsId,
+		UserId:  d.Param.UserId,
+	})
+	if err != nil {
+		return err
+	}
+	d.Price, _ = strconv.ParseInt(goodsDatail.Price, 10, 64)
+	d.PayAmount = d.Price * d.Param.Num
+	d.MerchantId = goodsDatail.MerchantId
+	d.OrderType = goodsDatail.OrderType
+	if d.Deadline == 0 {
+		d.Deadline = time.Now().Add(payTimeout(d.OrderType)).UnixMilli()
+	}
+	return nil
+}
+
+// sagaReserveStock 库存校验及预扣  --> RPC连接 stock_service
+// 以 saga id（订单号）为全局事务id调用 TCC 接口，重复调用只扣一次，可以重试
+func sagaReserveStock(ctx context.Context, v interface{}) error {
+	d := v.(*createOrderData)
+	_, err := rpc.StockCli.TryReserve(ctx, reserveReq(d))
+	switch status.Code(err) {
+	case codes.ResourceExhausted:
+		checkSoldOut(ctx, d.Param.GoodsId)
+		return saga.Abort(err)
+	case codes.Aborted, codes.InvalidArgument:
+		// 已经执行过补偿（空回滚），或者与已预扣的数量不一致，重试也不会成功
+		return saga.Abort(err)
+	}
+	return err
+}
+
+// sagaReleaseStock 归还预扣的库存，没有预扣过时库存服务记录空回滚，之后到达的预扣会被拒绝
+func sagaReleaseStock(ctx context.Context, v interface{}) error {
+	d := v.(*createOrderData)
+	_, err := rpc.StockCli.CancelReserve(ctx, reserveReq(d))
+	return err
+}
+
+// reserveReq 预扣库存的请求，全局事务id为订单号
+func reserveReq(d *createOrderData) *stockv1.ReserveReq {
+	return &stockv1.ReserveReq{
+		TxId:    strconv.FormatInt(d.OrderId, 10),
+		OrderId: d.OrderId,
+		GoodsId: d.Param.GoodsId,
+		Num:     d.Param.Num,
+	}
+}
+
+// sagaPersistOrder 在本地事务创建订单和订单详情记录，订单已存在时直接返回
+func sagaPersistOrder(ctx context.Context, v interface{}) error {
+	d := v.(*createOrderData)
+	_, err := mysql.QueryOrder(ctx, d.OrderId)
+	if err == nil {
+		return nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return err
+	}
+	o := &OrderEntity{OrderId: d.OrderId, Param: d.Param, price: d.Price, payAmount: d.PayAmount, merchantId: d.MerchantId, orderType: d.OrderType}
+	orderData, orderDetail := o.orderModels()
+	return mysql.CreateOrderWithTransation(ctx, orderData, orderDetail)
+}
+
+// sagaCloseOrder 关闭待支付的订单，订单不存在或已关闭时忽略
+// 订单在补偿之前已经支付时停止补偿，预扣的库存随支付成功确认扣减，不能再归还
+func sagaCloseOrder(ctx context.Context, v interface{}) error {
+	d := v.(*createOrderData)
+	err := mysql.CloseOrder(ctx, d.OrderId, CancelReasonCreateFailed)
+	if !errors.Is(err, errno.ErrOrderStatusChanged) {
+		return err
+	}
+	o, err := mysql.QueryOrder(ctx, d.OrderId)
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if o.Status == model.OrderStatusClosed {
+		return nil
+	}
+	zap.L().Warn("order paid before saga compensation", zap.Int64("order_id", d.OrderId), zap.Int32("status", o.Status))
+	return saga.ErrCompleted
+}
+
+// sagaScheduleTimeout 添加支付超时
+func sagaScheduleTimeout(ctx context.Context, v interface{}) error {
+	d := v.(*createOrderData)
+	return SendPayTimeout(ctx, d.OrderId, time.UnixMilli(d.Deadline))
+}
+
+// sagaCancelTimeout 删除支付超时任务，RocketMQ延迟消息到期后按订单状态忽略
+func sagaCancelTimeout(ctx context.Context, v interface{}) error {
+	d := v.(*createOrderData)
+	CancelPayTimeout(ctx, d.OrderId)
+	return nil
+}
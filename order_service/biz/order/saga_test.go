@@ -0,0 +1,109 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	orderv1 "github.com/idMiFeng/api/shop/order/v1"
+	stockv1 "github.com/idMiFeng/api/shop/stock/v1"
+	"github.com/idMiFeng/order_service/biz/saga"
+	"github.com/idMiFeng/order_service/dao/mysql"
+	"github.com/idMiFeng/order_service/model"
+	"github.com/idMiFeng/order_service/rpc"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeStock 记录归还过预扣库存的订单
+type fakeStock struct {
+	stockv1.StockClient
+	mu       sync.Mutex
+	released []int64
+}
+
+func (f *fakeStock) CancelReserve(ctx context.Context, in *stockv1.ReserveReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.released = append(f.released, in.OrderId)
+	return &emptypb.Empty{}, nil
+}
+
+// 创建订单的 saga 在创建订单后中断，恢复补偿之前订单已经支付：不关单也不归还库存，saga 标记为成功
+// 还没支付的订单照常关闭并归还库存
+func TestRecoverCreateOrderSagaPaid(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+	if err = db.AutoMigrate(&model.Order{}, &model.OrderDetail{}, &model.SagaInstance{}, &model.SagaStepLog{}); err != nil {
+		t.Fatal(err)
+	}
+	mysql.SetDB(db)
+	stock := &fakeStock{}
+	rpc.StockCli = stock
+	saga.Register(CreateOrderSaga)
+
+	orders := map[int64]int32{1: model.OrderStatusPaid, 2: model.OrderStatusPending}
+	for orderId, st := range orders {
+		b, _ := json.Marshal(&createOrderData{
+			OrderId: orderId,
+			Param:   &orderv1.OrderReq{GoodsId: 3, Num: 1, UserId: 7},
+		})
+		// 添加支付超时之前中断
+		db.Create(&model.SagaInstance{SagaId: orderId, Name: CreateOrderSaga.Name, Status: model.SagaStatusRunning, CurrentStep: 3, Data: string(b)})
+		db.Create(&model.Order{OrderId: orderId, UserId: 7, Status: st})
+	}
+	db.Model(&model.SagaInstance{}).Where("1 = 1").Update("update_at", time.Now().Add(-time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		saga.Run(ctx)
+		close(done)
+	}()
+	want := map[int64]int32{1: model.SagaStatusSucceeded, 2: model.SagaStatusCompensated}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var insts []model.SagaInstance
+		db.Find(&insts)
+		finished := 0
+		for _, inst := range insts {
+			if inst.Status == want[inst.SagaId] {
+				finished++
+			}
+		}
+		if finished == len(want) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("saga not recovered: %+v", insts)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	for orderId, st := range map[int64]int32{1: model.OrderStatusPaid, 2: model.OrderStatusClosed} {
+		o, err := mysql.QueryOrder(context.Background(), orderId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if o.Status != st {
+			t.Fatalf("order %d status = %d, want %d", orderId, o.Status, st)
+		}
+	}
+	stock.mu.Lock()
+	defer stock.mu.Unlock()
+	if len(stock.released) != 1 || stock.released[0] != 2 {
+		t.Fatalf("released stock of orders %v, want [2]", stock.released)
+	}
+}
@@ -0,0 +1,81 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/idMiFeng/common/broker"
+	"github.com/idMiFeng/order_service/config"
+	"github.com/idMiFeng/order_service/dao/redis"
+	"github.com/idMiFeng/order_service/model"
+
+	"go.uber.org/zap"
+)
+
+// defaultPayTimeout 没有配置时的支付超时时间
+const defaultPayTimeout = 30 * time.Minute
+
+// payTimeout 按订单类型取支付超时时间，没有配置的类型使用普通订单的配置
+func payTimeout(orderType int32) time.Duration {
+	if config.Conf.OrderConfig != nil {
+		if d, ok := config.Conf.OrderConfig.PayTimeout[model.OrderTypeName(orderType)]; ok && d > 0 {
+			return d
+		}
+		if d, ok := config.Conf.OrderConfig.PayTimeout[model.OrderTypeName(model.OrderTypeNormal)]; ok && d > 0 {
+			return d
+		}
+	}
+	return defaultPayTimeout
+}
+
+// minDelay 剩余时间小于它时不再延迟，直接处理
+const minDelay = time.Second
+
+// TimeoutScheduler 支付超时使用Redis延迟任务时的调度器，在 main 中初始化
+// 为空时使用RocketMQ延迟消息
+var TimeoutScheduler *redis.Scheduler
+
+// SendPayTimeout 投递订单支付超时的延迟消息
+// 消息中间件不支持这么长的延迟时（RocketMQ最长2h）会提前投递，消费时再继续延迟，直到截止时间
+// 使用Redis延迟任务时以订单号为key，重复调用只会修改执行时间
+func SendPayTimeout(ctx context.Context, orderId int64, deadline time.Time) error {
+	b, _ := json.Marshal(model.OrderTimeoutInfo{
+		OrderId:  orderId,
+		Deadline: deadline.UnixMilli(),
+	})
+	if TimeoutScheduler != nil {
+		return TimeoutScheduler.Schedule(ctx, strconv.FormatInt(orderId, 10), b, deadline)
+	}
+	return broker.MQ.Publish(ctx, &broker.Message{
+		Topic: config.Conf.RocketMqConfig.Topic.PayTimeOut,
+		Body:  b,
+		Delay: time.Until(deadline),
+	})
+}
+
+// CancelPayTimeout 订单已支付或已关闭，删除还没执行的超时任务
+// RocketMQ延迟消息无法撤回，到期后按订单状态忽略
+func CancelPayTimeout(ctx context.Context, orderId int64) {
+	if TimeoutScheduler == nil {
+		return
+	}
+	if _, err := TimeoutScheduler.Cancel(ctx, strconv.FormatInt(orderId, 10)); err != nil {
+		// 任务到期后按订单状态忽略，这里只记录日志
+		zap.L().Warn("cancel pay timeout job failed", zap.Int64("order_id", orderId), zap.Error(err))
+	}
+}
+
+// HandleTimeout 处理支付超时消息，返回错误时需要稍后重试
+func HandleTimeout(ctx context.Context, data *model.OrderTimeoutInfo) error {
+	// 还没到截止时间，继续延迟
+	if data.Deadline > 0 {
+		deadline := time.UnixMilli(data.Deadline)
+		if time.Until(deadline) >= minDelay {
+			zap.L().Debug("order timeout not due, redeliver", zap.Int64("order_id", data.OrderId), zap.Time("deadline", deadline))
+			return SendPayTimeout(ctx, data.OrderId, deadline)
+		}
+	}
+	return CloseTimeout(ctx, data.OrderId)
+}
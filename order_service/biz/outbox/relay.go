@@ -0,0 +1,136 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/idMiFeng/common/broker"
+	"github.com/idMiFeng/common/lifecycle"
+	"github.com/idMiFeng/common/tracing"
+	"github.com/idMiFeng/order_service/config"
+	"github.com/idMiFeng/order_service/dao/mysql"
+	"github.com/idMiFeng/order_service/model"
+
+	"go.uber.org/zap"
+)
+
+// 本地消息表中继
+// 业务数据和消息在同一个本地事务中写入，中继协程轮询待投递的消息并发送到消息队列
+// 投递是至少一次的，消费者需要保证幂等
+// 多个实例的中继通过 ClaimOutbox 的租约分摊消息，同一条消息同一时间只有一个中继在投递
+
+// NewEvent 构造一条本地消息，key相同的消息按写入顺序投递
+// 消息属性中记录ctx中的trace，中继投递时关联到写入消息的请求
+func NewEvent(ctx context.Context, topic, key string, body []byte, props map[string]string, deliverAt time.Time) *model.Outbox {
+	if props == nil {
+		props = make(map[string]string)
+	}
+	tracing.Inject(ctx, props)
+	p, _ := json.Marshal(props)
+	now := time.Now()
+	if deliverAt.IsZero() {
+		deliverAt = now
+	}
+	return &model.Outbox{
+		Topic:       topic,
+		ShardingKey: key,
+		Body:        string(body),
+		Properties:  string(p),
+		DeliverAt:   deliverAt,
+		Status:      model.OutboxStatusPending,
+		NextRetryAt: now,
+	}
+}
+
+// Relay 本地消息中继
+type Relay struct {
+	pub      broker.Publisher
+	interval time.Duration
+	batch    int
+	maxRetry int32
+	lease    time.Duration
+}
+
+// NewRelay 创建中继
+func NewRelay(pub broker.Publisher, cfg *config.OutboxConfig) *Relay {
+	r := &Relay{pub: pub, interval: time.Second, batch: 100, maxRetry: 16, lease: 30 * time.Second}
+	if cfg != nil {
+		if cfg.Interval > 0 {
+			r.interval = cfg.Interval
+		}
+		if cfg.Batch > 0 {
+			r.batch = cfg.Batch
+		}
+		if cfg.MaxRetry > 0 {
+			r.maxRetry = cfg.MaxRetry
+		}
+		if cfg.Lease > 0 {
+			r.lease = cfg.Lease
+		}
+	}
+	return r
+}
+
+// Run 轮询投递，直到 ctx 结束
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.relayOnce(ctx)
+		}
+	}
+}
+
+// relayOnce 投递一批到期的消息
+// 每个 key 只会取到最早的一条，前面的消息没投递成功时后面的消息不会被取到，保证顺序
+func (r *Relay) relayOnce(ctx context.Context) {
+	if !lifecycle.Begin() {
+		return
+	}
+	defer lifecycle.End()
+	events, err := mysql.ClaimOutbox(ctx, r.batch, r.lease)
+	if err != nil {
+		zap.L().Error("mysql.ClaimOutbox failed", zap.Error(err))
+		return
+	}
+	for _, e := range events {
+		err = r.pub.Publish(ctx, toMessage(e))
+		if err == nil {
+			if err = mysql.MarkOutboxSent(ctx, e.ID); err != nil {
+				zap.L().Error("mysql.MarkOutboxSent failed", zap.Uint("id", e.ID), zap.Error(err))
+			}
+			continue
+		}
+		// 超过最大重试次数时放弃这条消息，后面的消息继续投递
+		dead := e.RetryCount+1 >= r.maxRetry
+		zap.L().Warn("relay outbox event failed", zap.Uint("id", e.ID), zap.String("topic", e.Topic), zap.Int32("retry", e.RetryCount), zap.Bool("dead", dead), zap.Error(err))
+		if err = mysql.MarkOutboxRetry(ctx, e.ID, time.Now().Add(backoff(e.RetryCount)), err.Error(), dead); err != nil {
+			zap.L().Error("mysql.MarkOutboxRetry failed", zap.Uint("id", e.ID), zap.Error(err))
+		}
+	}
+}
+
+// backoff 指数退避，最长1分钟
+func backoff(retry int32) time.Duration {
+	d := time.Second << uint(retry)
+	if d <= 0 || d > time.Minute {
+		return time.Minute
+	}
+	return d
+}
+
+func toMessage(e *model.Outbox) *broker.Message {
+	var props map[string]string
+	json.Unmarshal([]byte(e.Properties), &props)
+	return &broker.Message{
+		Topic:      e.Topic,
+		Body:       []byte(e.Body),
+		Properties: props,
+		Delay:      time.Until(e.DeliverAt),
+	}
+}
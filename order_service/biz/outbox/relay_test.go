@@ -0,0 +1,140 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/idMiFeng/common/broker"
+	"github.com/idMiFeng/order_service/config"
+	"github.com/idMiFeng/order_service/dao/mysql"
+	"github.com/idMiFeng/order_service/model"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakePub 记录投递的消息，err 不为nil时投递失败
+type fakePub struct {
+	mu   sync.Mutex
+	msgs []*broker.Message
+	err  error
+}
+
+func (p *fakePub) Publish(ctx context.Context, msg *broker.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	p.msgs = append(p.msgs, msg)
+	return nil
+}
+
+func setup(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	t.Cleanup(func() { sqlDB.Close() })
+	if err = db.AutoMigrate(&model.Outbox{}); err != nil {
+		t.Fatal(err)
+	}
+	mysql.SetDB(db)
+	return db
+}
+
+func outboxRow(t *testing.T, db *gorm.DB, id uint) model.Outbox {
+	var e model.Outbox
+	if err := db.First(&e, id).Error; err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+// 每个key只取最早的一条，取走的消息在租约内不会再被取到，租约到期后重新取走
+func TestClaimOutboxLease(t *testing.T) {
+	db := setup(t)
+	ctx := context.Background()
+	a1 := NewEvent(ctx, "t", "a", []byte("a1"), nil, time.Time{})
+	a2 := NewEvent(ctx, "t", "a", []byte("a2"), nil, time.Time{})
+	b1 := NewEvent(ctx, "t", "b", []byte("b1"), nil, time.Time{})
+	// 还没到期的消息不会被取走
+	later := NewEvent(ctx, "t", "c", []byte("c1"), nil, time.Now().Add(time.Hour))
+	later.NextRetryAt = later.DeliverAt
+	if err := mysql.CreateOutbox(ctx, []*model.Outbox{a1, a2, b1, later}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := mysql.ClaimOutbox(ctx, 10, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("ClaimOutbox: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != a1.ID || got[1].ID != b1.ID {
+		t.Fatalf("claimed %d events, want a1 and b1", len(got))
+	}
+	if got, _ = mysql.ClaimOutbox(ctx, 10, 50*time.Millisecond); len(got) != 0 {
+		t.Fatalf("claimed %d events during lease, want 0", len(got))
+	}
+	time.Sleep(60 * time.Millisecond)
+	if got, _ = mysql.ClaimOutbox(ctx, 10, 50*time.Millisecond); len(got) != 2 {
+		t.Fatalf("claimed %d events after lease, want 2", len(got))
+	}
+	if e := outboxRow(t, db, a1.ID); e.Version != 2 {
+		t.Fatalf("version = %d, want 2", e.Version)
+	}
+	// 被取走过的消息不能再取消
+	if err = mysql.CancelOutbox(ctx, a1.ID); err != nil {
+		t.Fatal(err)
+	}
+	if e := outboxRow(t, db, a1.ID); e.Status != model.OutboxStatusPending {
+		t.Fatalf("status = %d, want pending", e.Status)
+	}
+}
+
+// 投递成功后同一个key的下一条消息才会被取走
+func TestRelayOrder(t *testing.T) {
+	setup(t)
+	ctx := context.Background()
+	a1 := NewEvent(ctx, "t", "a", []byte("a1"), nil, time.Time{})
+	a2 := NewEvent(ctx, "t", "a", []byte("a2"), nil, time.Time{})
+	if err := mysql.CreateOutbox(ctx, []*model.Outbox{a1, a2}); err != nil {
+		t.Fatal(err)
+	}
+	pub := &fakePub{}
+	r := NewRelay(pub, nil)
+	r.relayOnce(ctx)
+	r.relayOnce(ctx)
+	if len(pub.msgs) != 2 || string(pub.msgs[0].Body) != "a1" || string(pub.msgs[1].Body) != "a2" {
+		t.Fatalf("published %d msgs, want a1 then a2", len(pub.msgs))
+	}
+}
+
+// 投递失败时按退避时间重试，超过最大重试次数后不再投递
+func TestRelayRetry(t *testing.T) {
+	db := setup(t)
+	ctx := context.Background()
+	e := NewEvent(ctx, "t", "a", []byte("a1"), nil, time.Time{})
+	if err := mysql.CreateOutbox(ctx, []*model.Outbox{e}); err != nil {
+		t.Fatal(err)
+	}
+	pub := &fakePub{err: errors.New("broker down")}
+	r := NewRelay(pub, &config.OutboxConfig{MaxRetry: 2})
+	r.relayOnce(ctx)
+	got := outboxRow(t, db, e.ID)
+	if got.Status != model.OutboxStatusPending || got.RetryCount != 1 || got.LastError != "broker down" {
+		t.Fatalf("after first failure: status = %d, retry = %d, last error = %q", got.Status, got.RetryCount, got.LastError)
+	}
+	if !got.NextRetryAt.After(time.Now()) {
+		t.Fatal("next retry not delayed")
+	}
+	db.Model(&model.Outbox{}).Where("id = ?", e.ID).Update("next_retry_at", time.Now())
+	r.relayOnce(ctx)
+	if got = outboxRow(t, db, e.ID); got.Status != model.OutboxStatusDead {
+		t.Fatalf("status = %d, want dead", got.Status)
+	}
+}
@@ -0,0 +1,206 @@
+package pay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	orderv1 "github.com/idMiFeng/api/shop/order/v1"
+	"github.com/idMiFeng/common/broker"
+	"github.com/idMiFeng/order_service/biz/order"
+	"github.com/idMiFeng/order_service/config"
+	"github.com/idMiFeng/order_service/dao/mysql"
+	"github.com/idMiFeng/order_service/errno"
+	"github.com/idMiFeng/order_service/model"
+	"github.com/idMiFeng/order_service/third_party/payment"
+	"github.com/idMiFeng/order_service/third_party/snowflake"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// biz层业务代码
+// biz -> dao
+
+// Pay 为待支付订单创建支付单并调用支付渠道
+func Pay(ctx context.Context, param *orderv1.PayReq) (*orderv1.PayResp, error) {
+	o, err := mysql.QueryOrder(ctx, param.OrderId)
+	if err == gorm.ErrRecordNotFound || (err == nil && o.UserId != param.UserId) {
+		return nil, status.Error(codes.NotFound, "订单不存在")
+	}
+	if err != nil {
+		zap.L().Error("mysql.QueryOrder failed", zap.Int64("order_id", param.OrderId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "query order failed")
+	}
+	if o.Status != model.OrderStatusPending {
+		return nil, status.Error(codes.FailedPrecondition, "订单状态不是待支付")
+	}
+	provider, ok := payment.Get(param.PayChannel)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "不支持的支付渠道")
+	}
+	// 生成支付单
+	p := model.Payment{
+		PayId:      snowflake.GenID(),
+		OrderId:    o.OrderId,
+		UserId:     o.UserId,
+		PayAmount:  o.PayAmount,
+		PayChannel: provider.Channel(),
+		Status:     model.PayStatusPending,
+	}
+	err = mysql.CreatePayment(ctx, &p)
+	if err != nil {
+		zap.L().Error("mysql.CreatePayment failed", zap.Int64("order_id", o.OrderId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "create payment failed")
+	}
+	// 调用支付渠道
+	res, err := provider.Pay(ctx, &payment.PayParam{
+		PayId:     p.PayId,
+		OrderId:   p.OrderId,
+		PayAmount: p.PayAmount,
+	})
+	if err != nil {
+		zap.L().Error("provider.Pay failed", zap.String("provider", provider.Name()), zap.Int64("pay_id", p.PayId), zap.Error(err))
+		mysql.UpdatePaymentStatus(ctx, p.PayId, "", model.PayStatusFailed)
+		return nil, status.Error(codes.Unavailable, "pay failed")
+	}
+	// 记录渠道交易单号
+	err = mysql.UpdatePaymentStatus(ctx, p.PayId, res.TradeId, model.PayStatusPending)
+	if err != nil {
+		zap.L().Warn("save trade_id failed", zap.Int64("pay_id", p.PayId), zap.Error(err))
+	}
+	return &orderv1.PayResp{
+		OrderId:   p.OrderId,
+		PayId:     p.PayId,
+		TradeId:   res.TradeId,
+		PayAmount: p.PayAmount,
+		PayUrl:    res.PayUrl,
+	}, nil
+}
+
+// Callback 处理支付渠道的异步回调
+// 渠道可能重复回调，需要保证幂等
+func Callback(ctx context.Context, param *orderv1.PayCallbackReq) error {
+	provider, ok := payment.Get(param.PayChannel)
+	if !ok || param.PayChannel == 0 {
+		return status.Error(codes.InvalidArgument, "不支持的支付渠道")
+	}
+	n := &payment.Notify{
+		OrderId:    param.OrderId,
+		PayId:      param.PayId,
+		TradeId:    param.TradeId,
+		PayAmount:  param.PayAmount,
+		PayChannel: param.PayChannel,
+		Status:     param.Status,
+		Timestamp:  param.Timestamp,
+	}
+	if !provider.Verify(n, param.Sign) {
+		zap.L().Warn("pay callback sign invalid", zap.Int64("pay_id", param.PayId))
+		return status.Error(codes.PermissionDenied, "签名校验失败")
+	}
+	// 签名正确但时间戳过期的回调可能是被截获后重放的
+	if !fresh(param.Timestamp) {
+		zap.L().Warn("pay callback expired", zap.Int64("pay_id", param.PayId), zap.Int64("timestamp", param.Timestamp))
+		return status.Error(codes.PermissionDenied, "回调已过期")
+	}
+	p, err := mysql.QueryPayment(ctx, param.PayId)
+	if err == gorm.ErrRecordNotFound {
+		return status.Error(codes.NotFound, "支付单不存在")
+	}
+	if err != nil {
+		zap.L().Error("mysql.QueryPayment failed", zap.Int64("pay_id", param.PayId), zap.Error(err))
+		return status.Error(codes.Internal, "query payment failed")
+	}
+	if p.OrderId != param.OrderId || p.PayAmount != param.PayAmount {
+		zap.L().Warn("pay callback mismatch", zap.Int64("pay_id", p.PayId), zap.Int64("order_id", param.OrderId), zap.Int64("pay_amount", param.PayAmount))
+		return status.Error(codes.InvalidArgument, "支付单信息不一致")
+	}
+
+	if param.Status != payment.NotifySuccess {
+		// 支付失败，订单保持待支付，用户可以重新发起支付
+		err = mysql.UpdatePaymentStatus(ctx, p.PayId, param.TradeId, model.PayStatusFailed)
+		if err != nil {
+			zap.L().Error("mysql.UpdatePaymentStatus failed", zap.Int64("pay_id", p.PayId), zap.Error(err))
+			return status.Error(codes.Internal, "update payment failed")
+		}
+		return nil
+	}
+
+	switch p.Status {
+	case model.PayStatusNeedRefund:
+		// 重复回调，已经记录为待退款
+		return nil
+	case model.PayStatusSuccess:
+		// 重复回调，订单可能已经被其他支付单支付后关闭，只有本支付单支付的订单才通知确认扣减
+		o, err := mysql.QueryOrder(ctx, p.OrderId)
+		if err != nil {
+			zap.L().Error("mysql.QueryOrder failed", zap.Int64("order_id", p.OrderId), zap.Error(err))
+			return status.Error(codes.Internal, "query order failed")
+		}
+		if o.Status == model.OrderStatusPending || o.Status == model.OrderStatusClosed || o.TradeId != p.TradeId {
+			zap.L().Warn("pay callback for order not paid by this payment", zap.Int64("order_id", p.OrderId), zap.Int64("pay_id", p.PayId), zap.Int32("order_status", o.Status))
+			return nil
+		}
+	default:
+		p.TradeId = param.TradeId
+		err = mysql.PayOrderWithTransaction(ctx, &p, time.Now())
+		if errors.Is(err, errno.ErrOrderStatusChanged) {
+			// 订单已关闭或已被其他支付单支付，钱已经付了，支付单记录为待退款，不通知确认扣减
+			zap.L().Error("order paid after status changed, need refund", zap.Int64("order_id", p.OrderId), zap.Int64("pay_id", p.PayId))
+			if err = mysql.UpdatePaymentStatus(ctx, p.PayId, param.TradeId, model.PayStatusNeedRefund); err != nil {
+				zap.L().Error("mysql.UpdatePaymentStatus failed", zap.Int64("pay_id", p.PayId), zap.Error(err))
+				return status.Error(codes.Internal, "update payment failed")
+			}
+			return nil
+		}
+		if err != nil {
+			zap.L().Error("mysql.PayOrderWithTransaction failed", zap.Int64("order_id", p.OrderId), zap.Error(err))
+			return status.Error(codes.Internal, "pay order failed")
+		}
+		// 订单已支付，删除超时关单的任务
+		order.CancelPayTimeout(ctx, p.OrderId)
+	}
+	// 订单已支付（包括重复回调的情况），通知库存服务确认扣减
+	// 下游按 订单id+商品id 做幂等，重复投递没有影响
+	return publishPaySuccess(ctx, p.OrderId)
+}
+
+// defaultNotifyWindow 没有配置时回调时间戳允许的偏差
+const defaultNotifyWindow = 5 * time.Minute
+
+// fresh 回调的时间戳（秒）是否在允许的范围内，前后都要检查，渠道和本机的时钟可能有偏差
+func fresh(ts int64) bool {
+	window := defaultNotifyWindow
+	if cfg := config.Conf.PaymentConfig; cfg != nil && cfg.NotifyWindow > 0 {
+		window = cfg.NotifyWindow
+	}
+	d := time.Since(time.Unix(ts, 0))
+	return d <= window && d >= -window
+}
+
+// publishPaySuccess 发送支付成功消息，每个订单商品一条
+func publishPaySuccess(ctx context.Context, orderId int64) error {
+	details, err := mysql.QueryOrderDetail(ctx, orderId)
+	if err != nil {
+		zap.L().Error("mysql.QueryOrderDetail failed", zap.Int64("order_id", orderId), zap.Error(err))
+		return status.Error(codes.Internal, "query order detail failed")
+	}
+	for _, d := range details {
+		b, _ := json.Marshal(model.OrderGoodsStockInfo{
+			OrderId: d.OrderId,
+			GoodsId: d.GoodsId,
+			Num:     d.Num,
+		})
+		msg := &broker.Message{Topic: config.Conf.RocketMqConfig.Topic.PaySuccess, Body: b}
+		err = broker.MQ.Publish(ctx, msg)
+		if err != nil {
+			// 返回错误让渠道重试回调
+			zap.L().Error("send pay success msg failed", zap.Int64("order_id", orderId), zap.Error(err))
+			return status.Error(codes.Internal, "send pay success msg failed")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+package pay
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	orderv1 "github.com/idMiFeng/api/shop/order/v1"
+	"github.com/idMiFeng/common/broker"
+	"github.com/idMiFeng/order_service/biz/order"
+	"github.com/idMiFeng/order_service/config"
+	"github.com/idMiFeng/order_service/dao/mysql"
+	"github.com/idMiFeng/order_service/model"
+	"github.com/idMiFeng/order_service/third_party/payment"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	secret             = "test_secret"
+	topicPaySuccess    = "xx_pay_success"
+	topicStockRollback = "xx_stock_rollback"
+)
+
+// fakeStock 模拟库存服务按订单号执行Confirm和Cancel，记录每个订单确认扣减和归还库存的次数
+// Confirm之后的Cancel、Cancel之后的Confirm都不生效，与库存服务的TCC一致
+type fakeStock struct {
+	mu        sync.Mutex
+	state     map[int64]string
+	confirmed map[int64]int
+	returned  map[int64]int
+}
+
+func (s *fakeStock) handle(op string) broker.Handler {
+	return func(ctx context.Context, msg *broker.Message) error {
+		var info model.OrderGoodsStockInfo
+		if err := json.Unmarshal(msg.Body, &info); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := s.state[info.OrderId]; ok {
+			return nil
+		}
+		s.state[info.OrderId] = op
+		if op == "confirm" {
+			s.confirmed[info.OrderId]++
+		} else {
+			s.returned[info.OrderId]++
+		}
+		return nil
+	}
+}
+
+func (s *fakeStock) counts(orderId int64) (confirmed, returned int) {
+	// 内存消息中间件异步投递，等待消息消费
+	time.Sleep(50 * time.Millisecond)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.confirmed[orderId], s.returned[orderId]
+}
+
+// setup 使用SQLite和内存消息中间件，订单1的状态为orderStatus，返回模拟的库存服务
+func setup(t *testing.T, orderStatus int32) (*gorm.DB, *fakeStock) {
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	// SQLite内存库并发写会报表被锁，事务排队执行，订单状态的竞争仍然存在
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	if err = db.AutoMigrate(&model.Order{}, &model.OrderDetail{}, &model.Payment{}); err != nil {
+		t.Fatal(err)
+	}
+	mysql.SetDB(db)
+	seedOrder(db, 1, orderStatus)
+
+	config.Conf.PaymentConfig = &config.PaymentConfig{Provider: "mock", Secret: secret}
+	if err = payment.Init(config.Conf.PaymentConfig); err != nil {
+		t.Fatal(err)
+	}
+	config.Conf.RocketMqConfig = &config.RocketMqConfig{}
+	config.Conf.RocketMqConfig.Topic.PaySuccess = topicPaySuccess
+	config.Conf.RocketMqConfig.Topic.StockRollback = topicStockRollback
+	s := &fakeStock{state: map[int64]string{}, confirmed: map[int64]int{}, returned: map[int64]int{}}
+	m := broker.NewMemory(nil)
+	m.Subscribe(topicPaySuccess, s.handle("confirm"))
+	m.Subscribe(topicStockRollback, s.handle("cancel"))
+	m.Start()
+	broker.MQ = m
+	t.Cleanup(func() { m.Shutdown() })
+	return db, s
+}
+
+// seedOrder 创建用户7的订单和它的支付单，支付单号为订单号*10
+func seedOrder(db *gorm.DB, orderId int64, orderStatus int32) {
+	db.Create(&model.Order{OrderId: orderId, UserId: 7, PayAmount: 100, Status: orderStatus})
+	db.Create(&model.OrderDetail{OrderId: orderId, GoodsId: 2, UserId: 7, Num: 1})
+	db.Create(&model.Payment{PayId: orderId * 10, OrderId: orderId, UserId: 7, PayAmount: 100, PayChannel: payment.ChannelMock})
+}
+
+// callback 构造订单支付成功的回调，ts为0时使用当前时间
+func callback(orderId int64, tradeId string, ts int64) *orderv1.PayCallbackReq {
+	if ts == 0 {
+		ts = time.Now().Unix()
+	}
+	n := &payment.Notify{
+		OrderId:    orderId,
+		PayId:      orderId * 10,
+		TradeId:    tradeId,
+		PayAmount:  100,
+		PayChannel: payment.ChannelMock,
+		Status:     payment.NotifySuccess,
+		Timestamp:  ts,
+	}
+	return &orderv1.PayCallbackReq{
+		OrderId:    n.OrderId,
+		PayId:      n.PayId,
+		TradeId:    n.TradeId,
+		PayAmount:  n.PayAmount,
+		PayChannel: n.PayChannel,
+		Status:     n.Status,
+		Timestamp:  n.Timestamp,
+		Sign:       payment.Sign(secret, n.Params()),
+	}
+}
+
+func paymentStatus(t *testing.T, db *gorm.DB, orderId int64) int32 {
+	var p model.Payment
+	if err := db.Where("pay_id = ?", orderId*10).First(&p).Error; err != nil {
+		t.Fatal(err)
+	}
+	return p.Status
+}
+
+func TestCallbackPaid(t *testing.T) {
+	db, stock := setup(t, model.OrderStatusPending)
+	ctx := context.Background()
+	// 重复回调重复通知，库存服务幂等
+	for i := 0; i < 2; i++ {
+		if err := Callback(ctx, callback(1, "T1", 0)); err != nil {
+			t.Fatalf("Callback: %v", err)
+		}
+	}
+	if st := paymentStatus(t, db, 1); st != model.PayStatusSuccess {
+		t.Fatalf("payment status = %d, want success", st)
+	}
+	o, _ := mysql.QueryOrder(ctx, 1)
+	if o.Status != model.OrderStatusPaid || o.TradeId != "T1" {
+		t.Fatalf("order = %d/%s, want paid/T1", o.Status, o.TradeId)
+	}
+	if confirmed, _ := stock.counts(1); confirmed != 1 {
+		t.Fatalf("confirmed = %d, want 1", confirmed)
+	}
+}
+
+// 订单关闭后才付款，支付单记录为待退款，不通知库存服务确认扣减
+func TestCallbackOrderClosed(t *testing.T) {
+	db, stock := setup(t, model.OrderStatusClosed)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := Callback(ctx, callback(1, "T1", 0)); err != nil {
+			t.Fatalf("Callback: %v", err)
+		}
+	}
+	if st := paymentStatus(t, db, 1); st != model.PayStatusNeedRefund {
+		t.Fatalf("payment status = %d, want need refund", st)
+	}
+	if confirmed, _ := stock.counts(1); confirmed != 0 {
+		t.Fatalf("confirmed = %d, want 0", confirmed)
+	}
+}
+
+// 支付成功的支付单，订单不是由它支付的（老数据），重复回调时不通知
+func TestCallbackDuplicateNotPaidByThis(t *testing.T) {
+	db, stock := setup(t, model.OrderStatusClosed)
+	db.Model(&model.Payment{}).Where("pay_id = ?", 10).Updates(map[string]interface{}{"status": model.PayStatusSuccess, "trade_id": "T1"})
+	if err := Callback(context.Background(), callback(1, "T1", 0)); err != nil {
+		t.Fatalf("Callback: %v", err)
+	}
+	if confirmed, _ := stock.counts(1); confirmed != 0 {
+		t.Fatalf("confirmed = %d, want 0", confirmed)
+	}
+}
+
+// 时间戳超出允许范围的回调被拒绝，签名正确也不处理
+func TestCallbackExpired(t *testing.T) {
+	db, _ := setup(t, model.OrderStatusPending)
+	for _, ts := range []int64{time.Now().Add(-time.Hour).Unix(), time.Now().Add(time.Hour).Unix()} {
+		if err := Callback(context.Background(), callback(1, "T1", ts)); status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("Callback = %v, want PermissionDenied", err)
+		}
+	}
+	if st := paymentStatus(t, db, 1); st != model.PayStatusPending {
+		t.Fatalf("payment status = %d, want pending", st)
+	}
+}
+
+func TestCallbackBadSign(t *testing.T) {
+	setup(t, model.OrderStatusPending)
+	req := callback(1, "T1", 0)
+	req.PayAmount = 1
+	if err := Callback(context.Background(), req); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Callback = %v, want PermissionDenied", err)
+	}
+}
+
+// 用户取消、支付回调和超时关单同时处理同一个订单，只有一个能流转订单状态
+// 关闭时库存只归还一次、付款记录为待退款，支付成功时库存只确认扣减
+func TestCancelRacesCallbackAndTimeout(t *testing.T) {
+	db, stock := setup(t, model.OrderStatusPending)
+	ctx := context.Background()
+	for orderId := int64(2); orderId < 22; orderId++ {
+		seedOrder(db, orderId, model.OrderStatusPending)
+		var (
+			wg        sync.WaitGroup
+			cancelErr error
+		)
+		start := make(chan struct{})
+		// 支付回调要先验签，一半的订单让取消和超时晚一点开始，两种结果都能出现
+		var lag time.Duration
+		if orderId%2 == 0 {
+			lag = time.Millisecond
+		}
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			<-start
+			time.Sleep(lag)
+			cancelErr = order.Cancel(ctx, &orderv1.CancelOrderReq{OrderId: orderId, UserId: 7})
+		}()
+		go func() {
+			defer wg.Done()
+			<-start
+			if err := Callback(ctx, callback(orderId, "T"+strconv.FormatInt(orderId, 10), 0)); err != nil {
+				t.Errorf("Callback: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			<-start
+			time.Sleep(lag)
+			if err := order.HandleTimeout(ctx, &model.OrderTimeoutInfo{OrderId: orderId}); err != nil {
+				t.Errorf("HandleTimeout: %v", err)
+			}
+		}()
+		close(start)
+		wg.Wait()
+
+		o, err := mysql.QueryOrder(ctx, orderId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pay := paymentStatus(t, db, orderId)
+		confirmed, returned := stock.counts(orderId)
+		switch o.Status {
+		case model.OrderStatusPaid:
+			if status.Code(cancelErr) != codes.FailedPrecondition {
+				t.Fatalf("order %d paid, Cancel = %v, want FailedPrecondition", orderId, cancelErr)
+			}
+			if pay != model.PayStatusSuccess || confirmed != 1 || returned != 0 {
+				t.Fatalf("order %d paid: payment = %d, confirmed = %d, returned = %d", orderId, pay, confirmed, returned)
+			}
+		case model.OrderStatusClosed:
+			// 用户取消成功时关闭原因是用户取消，否则是超时关单
+			if (cancelErr == nil) != (o.CancelReason == order.CancelReasonUser) {
+				t.Fatalf("order %d closed by %q, Cancel = %v", orderId, o.CancelReason, cancelErr)
+			}
+			if pay != model.PayStatusNeedRefund || confirmed != 0 || returned != 1 {
+				t.Fatalf("order %d closed: payment = %d, confirmed = %d, returned = %d", orderId, pay, confirmed, returned)
+			}
+		default:
+			t.Fatalf("order %d status = %d", orderId, o.Status)
+		}
+	}
+}
@@ -0,0 +1,486 @@
+package refund
+
+import (
+	"context"
+	"errors"
+
+	orderv1 "github.com/idMiFeng/api/shop/order/v1"
+	stockv1 "github.com/idMiFeng/api/shop/stock/v1"
+	"github.com/idMiFeng/order_service/dao/mysql"
+	"github.com/idMiFeng/order_service/errno"
+	"github.com/idMiFeng/order_service/model"
+	"github.com/idMiFeng/order_service/rpc"
+	"github.com/idMiFeng/order_service/third_party/payment"
+	"github.com/idMiFeng/order_service/third_party/snowflake"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// biz层业务代码
+// biz -> dao
+
+// 售后流程：
+// 申请售后 -> 商家审核 -> (退货退款)买家退货 -> 商家收货 -> 渠道退款 -> 归还库存
+// 退款失败后商家可以重试，也可以关闭售后单让订单恢复到申请售后前的状态
+// 售后单的每一步都记录在对应的表中，订单状态通过 model.CanTransit 约束流转
+
+// Apply 申请售后，items为空时整单退款
+func Apply(ctx context.Context, param *orderv1.ApplyRefundReq) (*orderv1.RefundInfo, error) {
+	if param.RefundType != model.RefundTypeMoney && param.RefundType != model.RefundTypeGoods {
+		return nil, status.Error(codes.InvalidArgument, "退款类型有误")
+	}
+	o, err := mysql.QueryOrder(ctx, param.OrderId)
+	if err == gorm.ErrRecordNotFound || (err == nil && o.UserId != param.UserId) {
+		return nil, status.Error(codes.NotFound, "订单不存在")
+	}
+	if err != nil {
+		zap.L().Error("mysql.QueryOrder failed", zap.Int64("order_id", param.OrderId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "query order failed")
+	}
+	if !model.CanTransit(o.Status, model.OrderStatusAfterSale) {
+		return nil, status.Error(codes.FailedPrecondition, "订单当前状态不能申请售后")
+	}
+	details, err := mysql.QueryOrderDetail(ctx, o.OrderId)
+	if err != nil {
+		zap.L().Error("mysql.QueryOrderDetail failed", zap.Int64("order_id", o.OrderId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "query order detail failed")
+	}
+	refunded, err := mysql.QueryRefundedItems(ctx, o.OrderId)
+	if err != nil {
+		zap.L().Error("mysql.QueryRefundedItems failed", zap.Int64("order_id", o.OrderId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "query refunded items failed")
+	}
+	// 每个商品还能退的数量
+	remain := make(map[int64]int64, len(details))
+	for _, d := range details {
+		remain[d.GoodsId] += d.Num
+	}
+	var refundedAmount int64
+	for _, item := range refunded {
+		remain[item.GoodsId] -= item.Num
+		refundedAmount += item.RefundAmount
+	}
+	// 整单退款就是把剩下的都退掉
+	want := param.Items
+	if len(want) == 0 {
+		for _, d := range details {
+			if remain[d.GoodsId] > 0 {
+				want = append(want, &orderv1.RefundItem{GoodsId: d.GoodsId, Num: remain[d.GoodsId]})
+			}
+		}
+	}
+	if len(want) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "订单已全部退款")
+	}
+
+	refund := &model.Refund{
+		RefundId:    snowflake.GenID(),
+		OrderId:     o.OrderId,
+		UserId:      o.UserId,
+		RefundType:  param.RefundType,
+		Reason:      param.Reason,
+		Status:      model.RefundStatusAudit,
+		OrderStatus: o.Status,
+	}
+	items := make([]*model.RefundItem, 0, len(want))
+	seen := make(map[int64]bool, len(want))
+	for _, w := range want {
+		d := findDetail(details, w.GoodsId)
+		if d == nil || seen[w.GoodsId] || w.Num <= 0 || w.Num > remain[w.GoodsId] {
+			return nil, status.Error(codes.InvalidArgument, "退款商品或数量有误")
+		}
+		seen[w.GoodsId] = true
+		remain[w.GoodsId] -= w.Num
+		item := &model.RefundItem{
+			RefundId:     refund.RefundId,
+			OrderId:      o.OrderId,
+			GoodsId:      w.GoodsId,
+			Num:          w.Num,
+			RefundAmount: lineAmount(&o, details, d, w.Num),
+		}
+		refund.RefundAmount += item.RefundAmount
+		items = append(items, item)
+	}
+	// 这次退完之后没有剩余商品时，把剩余的金额全部退掉，避免按比例计算产生的误差
+	// 退款金额不能超过订单剩余的支付金额
+	if allRefunded(remain) || refund.RefundAmount > o.PayAmount-refundedAmount {
+		refund.RefundAmount = o.PayAmount - refundedAmount
+	}
+
+	err = mysql.CreateRefundWithTransaction(ctx, refund, items)
+	if errors.Is(err, errno.ErrOrderStatusChanged) {
+		return nil, status.Error(codes.Aborted, "订单状态已变化，请重试")
+	}
+	if err != nil {
+		zap.L().Error("mysql.CreateRefundWithTransaction failed", zap.Int64("order_id", o.OrderId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "create refund failed")
+	}
+	return toRefundInfo(refund, items), nil
+}
+
+// Audit 商家审核售后单
+// 退款失败的售后单再次审核通过会重新发起退款，不通过则关闭售后单
+func Audit(ctx context.Context, param *orderv1.AuditRefundReq) (*orderv1.RefundInfo, error) {
+	refund, err := queryRefund(ctx, param.RefundId)
+	if err != nil {
+		return nil, err
+	}
+	if refund.Status == model.RefundStatusFailed {
+		if param.Approve {
+			if err = mysql.RetryRefund(ctx, refund.RefundId); err != nil {
+				return nil, refundStatusError(err)
+			}
+			refund.Status = model.RefundStatusRefunding
+			return execute(ctx, &refund)
+		}
+		return closeRefund(ctx, &refund, param)
+	}
+	if refund.Status != model.RefundStatusAudit {
+		return nil, status.Error(codes.FailedPrecondition, "售后单不是待审核状态")
+	}
+	audit := &model.RefundAudit{
+		RefundId:   refund.RefundId,
+		OperatorId: param.OperatorId,
+		Action:     model.AuditReject,
+		Remark:     param.Remark,
+	}
+	to := int32(model.RefundStatusRejected)
+	if param.Approve {
+		audit.Action = model.AuditApprove
+		to = model.RefundStatusRefunding
+		if refund.RefundType == model.RefundTypeGoods {
+			to = model.RefundStatusReturning // 退货退款需要等买家退货
+		}
+	}
+	err = mysql.AuditRefundWithTransaction(ctx, &refund, audit, to)
+	if err != nil {
+		return nil, refundStatusError(err)
+	}
+	refund.Status = to
+	if to == model.RefundStatusRefunding {
+		return execute(ctx, &refund)
+	}
+	return detail(ctx, &refund)
+}
+
+// closeRefund 关闭退款失败的售后单，订单恢复到申请售后前的状态
+// 渠道已经退款成功的售后单只能重试，不能关闭
+func closeRefund(ctx context.Context, refund *model.Refund, param *orderv1.AuditRefundReq) (*orderv1.RefundInfo, error) {
+	_, err := mysql.QuerySuccessRefundPayment(ctx, refund.RefundId)
+	if err == nil {
+		return nil, status.Error(codes.FailedPrecondition, "售后单已退款成功，只能重试")
+	}
+	if err != gorm.ErrRecordNotFound {
+		zap.L().Error("mysql.QuerySuccessRefundPayment failed", zap.Int64("refund_id", refund.RefundId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "query refund payment failed")
+	}
+	audit := &model.RefundAudit{
+		RefundId:   refund.RefundId,
+		OperatorId: param.OperatorId,
+		Action:     model.AuditReject,
+		Remark:     param.Remark,
+	}
+	if err = mysql.AuditRefundWithTransaction(ctx, refund, audit, model.RefundStatusClosed); err != nil {
+		return nil, refundStatusError(err)
+	}
+	refund.Status = model.RefundStatusClosed
+	return detail(ctx, refund)
+}
+
+// SubmitLogistics 买家填写退货物流
+func SubmitLogistics(ctx context.Context, param *orderv1.ReturnLogisticsReq) (*orderv1.RefundInfo, error) {
+	refund, err := queryRefund(ctx, param.RefundId)
+	if err != nil {
+		return nil, err
+	}
+	if refund.UserId != param.UserId {
+		return nil, status.Error(codes.NotFound, "售后单不存在")
+	}
+	if refund.Status != model.RefundStatusReturning {
+		return nil, status.Error(codes.FailedPrecondition, "售后单不是待退货状态")
+	}
+	err = mysql.CreateRefundLogistics(ctx, &model.RefundLogistics{
+		RefundId:   refund.RefundId,
+		Company:    param.Company,
+		TrackingNo: param.TrackingNo,
+		Status:     model.LogisticsStatusShipped,
+	})
+	if err != nil {
+		return nil, refundStatusError(err)
+	}
+	refund.Status = model.RefundStatusReceiving
+	return detail(ctx, &refund)
+}
+
+// ConfirmReceived 商家确认收到退货，开始退款
+func ConfirmReceived(ctx context.Context, param *orderv1.ConfirmReturnReq) (*orderv1.RefundInfo, error) {
+	refund, err := queryRefund(ctx, param.RefundId)
+	if err != nil {
+		return nil, err
+	}
+	if refund.Status != model.RefundStatusReceiving {
+		return nil, status.Error(codes.FailedPrecondition, "售后单不是待收货状态")
+	}
+	if err = mysql.ReceiveRefundLogistics(ctx, refund.RefundId); err != nil {
+		return nil, refundStatusError(err)
+	}
+	refund.Status = model.RefundStatusRefunding
+	return execute(ctx, &refund)
+}
+
+// Detail 售后单详情
+func Detail(ctx context.Context, param *orderv1.RefundDetailReq) (*orderv1.RefundInfo, error) {
+	refund, err := queryRefund(ctx, param.RefundId)
+	if err != nil {
+		return nil, err
+	}
+	if refund.UserId != param.UserId {
+		return nil, status.Error(codes.NotFound, "售后单不存在")
+	}
+	return detail(ctx, &refund)
+}
+
+// execute 执行退款：渠道退款 -> 归还库存 -> 订单状态流转
+// 任何一步失败售后单都会变为退款失败，商家可以再次审核通过重试，每一步都是幂等的
+// 先退款再归还库存，渠道没有退款成功时库存不会被归还，售后单可以直接关闭
+func execute(ctx context.Context, refund *model.Refund) (*orderv1.RefundInfo, error) {
+	items, err := mysql.QueryRefundItems(ctx, refund.RefundId)
+	if err != nil {
+		zap.L().Error("mysql.QueryRefundItems failed", zap.Int64("refund_id", refund.RefundId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "query refund items failed")
+	}
+	o, err := mysql.QueryOrder(ctx, refund.OrderId)
+	if err != nil {
+		zap.L().Error("mysql.QueryOrder failed", zap.Int64("order_id", refund.OrderId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "query order failed")
+	}
+	// 1. 调用支付渠道退款，重试时已经退款成功的跳过
+	_, err = mysql.QuerySuccessRefundPayment(ctx, refund.RefundId)
+	if err == gorm.ErrRecordNotFound {
+		if err = pay(ctx, refund, &o); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		zap.L().Error("mysql.QuerySuccessRefundPayment failed", zap.Int64("refund_id", refund.RefundId), zap.Error(err))
+		return nil, fail(ctx, refund, "query refund payment failed")
+	}
+	// 2. 归还库存：退货退款收到了退货，或者订单还没有完成（未发货）
+	if refund.RefundType == model.RefundTypeGoods || refund.OrderStatus == model.OrderStatusPaid {
+		for _, item := range items {
+			_, err = rpc.StockCli.ReturnStock(ctx, &stockv1.ReturnStockReq{
+				RefundId: refund.RefundId,
+				OrderId:  refund.OrderId,
+				GoodsId:  item.GoodsId,
+				Num:      item.Num,
+			})
+			if err != nil {
+				zap.L().Error("StockCli.ReturnStock failed", zap.Int64("refund_id", refund.RefundId), zap.Error(err))
+				return nil, fail(ctx, refund, "return stock failed")
+			}
+		}
+	}
+	// 3. 订单状态：全部退完为已退款，否则恢复到申请售后前的状态
+	orderTo := refund.OrderStatus
+	fully, err := fullyRefunded(ctx, o.OrderId, items)
+	if err != nil {
+		zap.L().Error("fullyRefunded failed", zap.Int64("order_id", o.OrderId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "query refunded items failed")
+	}
+	if fully {
+		orderTo = model.OrderStatusRefunded
+	}
+	if err = mysql.FinishRefundWithTransaction(ctx, refund, true, orderTo); err != nil {
+		return nil, refundStatusError(err)
+	}
+	refund.Status = model.RefundStatusSuccess
+	return toRefundInfo(refund, items), nil
+}
+
+// pay 调用支付渠道退款并记录结果，失败时售后单标记为退款失败
+func pay(ctx context.Context, refund *model.Refund, o *model.Order) error {
+	provider, ok := payment.Get(o.PayChannel)
+	if !ok || o.PayChannel == 0 {
+		zap.L().Error("unknown pay channel", zap.Int64("order_id", o.OrderId), zap.Int32("pay_channel", o.PayChannel))
+		return fail(ctx, refund, "unknown pay channel")
+	}
+	rp := &model.RefundPayment{
+		RefundId:     refund.RefundId,
+		OrderId:      o.OrderId,
+		PayChannel:   o.PayChannel,
+		TradeId:      o.TradeId,
+		RefundAmount: refund.RefundAmount,
+		Status:       model.PayStatusPending,
+	}
+	if err := mysql.CreateRefundPayment(ctx, rp); err != nil {
+		zap.L().Error("mysql.CreateRefundPayment failed", zap.Int64("refund_id", refund.RefundId), zap.Error(err))
+		return fail(ctx, refund, "create refund payment failed")
+	}
+	res, err := provider.Refund(ctx, &payment.RefundParam{
+		RefundId:     refund.RefundId,
+		OrderId:      o.OrderId,
+		TradeId:      o.TradeId,
+		PayAmount:    o.PayAmount,
+		RefundAmount: refund.RefundAmount,
+	})
+	if err != nil {
+		zap.L().Error("provider.Refund failed", zap.String("provider", provider.Name()), zap.Int64("refund_id", refund.RefundId), zap.Error(err))
+		rp.Status = model.PayStatusFailed
+		rp.ErrMsg = err.Error()
+		mysql.UpdateRefundPayment(ctx, rp)
+		return fail(ctx, refund, "refund failed")
+	}
+	rp.Status = model.PayStatusSuccess
+	rp.RefundTradeId = res.RefundTradeId
+	if err = mysql.UpdateRefundPayment(ctx, rp); err != nil {
+		// 没有记录成功时重试会再次调用渠道，渠道按售后单号幂等
+		zap.L().Error("mysql.UpdateRefundPayment failed", zap.Int64("refund_id", refund.RefundId), zap.Error(err))
+		return fail(ctx, refund, "update refund payment failed")
+	}
+	return nil
+}
+
+// fail 将售后单标记为退款失败
+func fail(ctx context.Context, refund *model.Refund, msg string) error {
+	if err := mysql.FinishRefundWithTransaction(ctx, refund, false, 0); err != nil {
+		zap.L().Error("mysql.FinishRefundWithTransaction failed", zap.Int64("refund_id", refund.RefundId), zap.Error(err))
+	}
+	return status.Error(codes.Unavailable, msg)
+}
+
+// fullyRefunded 加上本次退款后订单的所有商品是否都已退完
+func fullyRefunded(ctx context.Context, orderId int64, current []*model.RefundItem) (bool, error) {
+	details, err := mysql.QueryOrderDetail(ctx, orderId)
+	if err != nil {
+		return false, err
+	}
+	refunded, err := mysql.QueryRefundedItems(ctx, orderId)
+	if err != nil {
+		return false, err
+	}
+	remain := make(map[int64]int64, len(details))
+	for _, d := range details {
+		remain[d.GoodsId] += d.Num
+	}
+	for _, item := range append(refunded, current...) {
+		remain[item.GoodsId] -= item.Num
+	}
+	return allRefunded(remain), nil
+}
+
+func allRefunded(remain map[int64]int64) bool {
+	for _, n := range remain {
+		if n > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func findDetail(details []*model.OrderDetail, goodsId int64) *model.OrderDetail {
+	for _, d := range details {
+		if d.GoodsId == goodsId {
+			return d
+		}
+	}
+	return nil
+}
+
+// lineAmount 按数量计算商品的退款金额，即该商品的支付金额按退款数量折算
+// 订单商品的支付金额是该商品的总金额（单价*数量），老订单的商品也是如此，只是没有记录单价
+// 老订单只有一个商品时用订单实际支付的金额，更早的订单商品没有记录支付金额
+func lineAmount(o *model.Order, details []*model.OrderDetail, d *model.OrderDetail, num int64) int64 {
+	if d.Num == 0 {
+		return 0
+	}
+	total := d.PayAmount
+	if d.Price == 0 && len(details) == 1 {
+		total = o.PayAmount
+	}
+	return total * num / d.Num
+}
+
+func queryRefund(ctx context.Context, refundId int64) (model.Refund, error) {
+	refund, err := mysql.QueryRefund(ctx, refundId)
+	if err == gorm.ErrRecordNotFound {
+		return refund, status.Error(codes.NotFound, "售后单不存在")
+	}
+	if err != nil {
+		zap.L().Error("mysql.QueryRefund failed", zap.Int64("refund_id", refundId), zap.Error(err))
+		return refund, status.Error(codes.Internal, "query refund failed")
+	}
+	return refund, nil
+}
+
+// MerchantOf 售后单所属的商家，即售后商品的商家，售后单不存在或者售后商品属于多个商家时返回0
+// 注册到认证中，商家只能审核自己商品的售后
+func MerchantOf(ctx context.Context, refundId int64) (int64, error) {
+	refund, err := mysql.QueryRefund(ctx, refundId)
+	if err == gorm.ErrRecordNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	items, err := mysql.QueryRefundItems(ctx, refundId)
+	if err != nil {
+		return 0, err
+	}
+	details, err := mysql.QueryOrderDetail(ctx, refund.OrderId)
+	if err != nil {
+		return 0, err
+	}
+	// 只看售后的商品，售后的商品都属于同一个商家时才返回该商家
+	goods := make(map[int64]bool, len(items))
+	for _, item := range items {
+		goods[item.GoodsId] = true
+	}
+	refunded := make([]*model.OrderDetail, 0, len(items))
+	for _, d := range details {
+		if goods[d.GoodsId] {
+			refunded = append(refunded, d)
+		}
+	}
+	return model.SingleMerchant(refunded), nil
+}
+
+// refundStatusError 并发修改导致的状态变化返回 Aborted，调用方可以重试
+func refundStatusError(err error) error {
+	if errors.Is(err, errno.ErrRefundStatusChanged) || errors.Is(err, errno.ErrOrderStatusChanged) {
+		return status.Error(codes.Aborted, "售后单状态已变化，请重试")
+	}
+	zap.L().Error("update refund failed", zap.Error(err))
+	return status.Error(codes.Internal, "update refund failed")
+}
+
+func detail(ctx context.Context, refund *model.Refund) (*orderv1.RefundInfo, error) {
+	items, err := mysql.QueryRefundItems(ctx, refund.RefundId)
+	if err != nil {
+		zap.L().Error("mysql.QueryRefundItems failed", zap.Int64("refund_id", refund.RefundId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "query refund items failed")
+	}
+	return toRefundInfo(refund, items), nil
+}
+
+func toRefundInfo(refund *model.Refund, items []*model.RefundItem) *orderv1.RefundInfo {
+	info := &orderv1.RefundInfo{
+		RefundId:     refund.RefundId,
+		OrderId:      refund.OrderId,
+		UserId:       refund.UserId,
+		RefundType:   refund.RefundType,
+		RefundAmount: refund.RefundAmount,
+		Reason:       refund.Reason,
+		Status:       refund.Status,
+		Items:        make([]*orderv1.RefundItem, 0, len(items)),
+	}
+	for _, item := range items {
+		info.Items = append(info.Items, &orderv1.RefundItem{
+			GoodsId:      item.GoodsId,
+			Num:          item.Num,
+			RefundAmount: item.RefundAmount,
+		})
+	}
+	return info
+}
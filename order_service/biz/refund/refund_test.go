@@ -0,0 +1,93 @@
+package refund
+
+import (
+	"context"
+	"testing"
+
+	orderv1 "github.com/idMiFeng/api/shop/order/v1"
+	"github.com/idMiFeng/order_service/dao/mysql"
+	"github.com/idMiFeng/order_service/model"
+	"github.com/idMiFeng/order_service/third_party/snowflake"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestLineAmount(t *testing.T) {
+	one := []*model.OrderDetail{{GoodsId: 1, Num: 3, PayAmount: 300}}
+	two := []*model.OrderDetail{
+		{GoodsId: 1, Num: 3, PayAmount: 300},
+		{GoodsId: 2, Num: 2, PayAmount: 500},
+	}
+	priced := []*model.OrderDetail{{GoodsId: 1, Num: 3, Price: 100, PayAmount: 300}}
+	tests := []struct {
+		name    string
+		order   *model.Order
+		details []*model.OrderDetail
+		d       *model.OrderDetail
+		num     int64
+		want    int64
+	}{
+		{"priced all", &model.Order{PayAmount: 280}, priced, priced[0], 3, 300},
+		{"priced part", &model.Order{PayAmount: 280}, priced, priced[0], 1, 100},
+		// 老订单只有一个商品时按订单实际支付的金额
+		{"legacy single", &model.Order{PayAmount: 270}, one, one[0], 1, 90},
+		// 老订单多个商品时支付金额就是该商品的总金额，不再乘数量
+		{"legacy multi all", &model.Order{PayAmount: 800}, two, two[0], 3, 300},
+		{"legacy multi part", &model.Order{PayAmount: 800}, two, two[1], 1, 250},
+		{"no num", &model.Order{}, one, &model.OrderDetail{}, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lineAmount(tt.order, tt.details, tt.d, tt.num); got != tt.want {
+				t.Fatalf("lineAmount = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// 部分退款按商品金额折算，最后一次退款退掉剩余的全部金额
+func TestApply(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+	if err = db.AutoMigrate(&model.Order{}, &model.OrderDetail{}, &model.Refund{}, &model.RefundItem{}); err != nil {
+		t.Fatal(err)
+	}
+	mysql.SetDB(db)
+	if err = snowflake.Init("", 1); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&model.Order{OrderId: 1, UserId: 7, PayAmount: 790, Status: model.OrderStatusPaid})
+	db.Create(&model.OrderDetail{OrderId: 1, UserId: 7, GoodsId: 1, Num: 3, PayAmount: 300})
+	db.Create(&model.OrderDetail{OrderId: 1, UserId: 7, GoodsId: 2, Num: 2, PayAmount: 500})
+	ctx := context.Background()
+
+	info, err := Apply(ctx, &orderv1.ApplyRefundReq{
+		OrderId:    1,
+		UserId:     7,
+		RefundType: model.RefundTypeMoney,
+		Items:      []*orderv1.RefundItem{{GoodsId: 2, Num: 1}},
+	})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if info.RefundAmount != 250 {
+		t.Fatalf("refund amount = %d, want 250", info.RefundAmount)
+	}
+	// 模拟第一次退款已完成，订单回到已支付
+	db.Model(&model.Refund{}).Where("refund_id = ?", info.RefundId).Update("status", model.RefundStatusSuccess)
+	db.Model(&model.Order{}).Where("order_id = ?", 1).Update("status", model.OrderStatusPaid)
+
+	info, err = Apply(ctx, &orderv1.ApplyRefundReq{OrderId: 1, UserId: 7, RefundType: model.RefundTypeMoney})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if info.RefundAmount != 540 {
+		t.Fatalf("refund amount = %d, want 540", info.RefundAmount)
+	}
+}
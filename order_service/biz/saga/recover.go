@@ -0,0 +1,76 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/idMiFeng/common/lifecycle"
+	"github.com/idMiFeng/order_service/dao/mysql"
+	"github.com/idMiFeng/order_service/model"
+
+	"go.uber.org/zap"
+)
+
+// Run 启动时恢复一次未完成的 saga，之后定期检查，直到 ctx 结束
+func Run(ctx context.Context) {
+	recoverOnce(ctx)
+	ticker := time.NewTicker(cfg.RecoverInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			recoverOnce(ctx)
+		}
+	}
+}
+
+// recoverOnce 恢复一段时间没有进展的 saga
+// 正向执行中断的 saga 不再继续执行，从中断的步骤开始补偿
+func recoverOnce(ctx context.Context) {
+	if !lifecycle.Begin() {
+		return
+	}
+	defer lifecycle.End()
+	list, err := mysql.ListUnfinishedSagas(ctx, time.Now().Add(-cfg.RecoverAfter), 100)
+	if err != nil {
+		zap.L().Error("mysql.ListUnfinishedSagas failed", zap.Error(err))
+		return
+	}
+	for _, inst := range list {
+		ok, err := mysql.ClaimSaga(ctx, inst.SagaId, inst.Version)
+		if err != nil || !ok {
+			continue // 被其他副本抢到了
+		}
+		def := lookup(inst.Name)
+		if def == nil {
+			zap.L().Error("saga definition not found", zap.String("saga", inst.Name), zap.Int64("saga_id", inst.SagaId))
+			continue
+		}
+		data := def.NewData()
+		if err = json.Unmarshal([]byte(inst.Data), data); err != nil {
+			zap.L().Error("unmarshal saga data failed", zap.Int64("saga_id", inst.SagaId), zap.Error(err))
+			continue
+		}
+		// 抢占后版本号加一
+		e := &executor{def: def, sagaId: inst.SagaId, version: inst.Version + 1, data: data}
+		from := int(inst.CurrentStep)
+		if inst.Status == model.SagaStatusRunning {
+			if from >= len(def.Steps) {
+				// 所有步骤都成功了，只是没来得及更新状态
+				e.update(ctx, map[string]interface{}{"status": model.SagaStatusSucceeded})
+				continue
+			}
+			if e.update(ctx, map[string]interface{}{"status": model.SagaStatusCompensating}) {
+				continue
+			}
+		}
+		if from >= len(def.Steps) {
+			from = len(def.Steps) - 1
+		}
+		zap.L().Info("recover saga", zap.String("saga", inst.Name), zap.Int64("saga_id", inst.SagaId), zap.Int("from", from))
+		e.compensate(ctx, from)
+	}
+}
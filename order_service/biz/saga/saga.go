@@ -0,0 +1,281 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/idMiFeng/common/tracing"
+	"github.com/idMiFeng/order_service/config"
+	"github.com/idMiFeng/order_service/dao/mysql"
+	"github.com/idMiFeng/order_service/errno"
+	"github.com/idMiFeng/order_service/model"
+
+	"go.uber.org/zap"
+)
+
+// saga 编排
+// 每个步骤有正向操作和补偿操作，按顺序执行正向操作，某一步失败后倒序执行补偿
+// 实例和每一步的执行日志保存在 MySQL 中，服务崩溃后由恢复协程继续补偿
+// 同一时刻只有持有实例版本号的执行者能写入，恢复协程抢占后原执行者停止
+// 补偿操作需要幂等，并且要能处理对应的正向操作没有执行或只执行了一部分的情况
+
+// Step saga 的一个步骤，data 为 Definition.NewData 创建的数据
+type Step struct {
+	Name       string
+	Action     func(ctx context.Context, data interface{}) error
+	Compensate func(ctx context.Context, data interface{}) error // 为空表示不需要补偿
+}
+
+// Definition saga 定义
+type Definition struct {
+	Name    string
+	NewData func() interface{} // 创建步骤共享的数据，需要能被 json 序列化
+	Steps   []Step
+}
+
+// abortError 不需要重试的错误
+type abortError struct {
+	err error
+}
+
+func (e abortError) Error() string { return e.err.Error() }
+func (e abortError) Unwrap() error { return e.err }
+
+// Abort 包装不需要重试的错误，比如参数错误或者重复执行会有副作用的操作
+func Abort(err error) error {
+	if err == nil {
+		return nil
+	}
+	return abortError{err}
+}
+
+// ErrCompleted 补偿操作返回该错误表示业务已经继续推进（比如订单已经支付），不能再补偿
+// 之前的步骤不再补偿，实例标记为执行成功
+var ErrCompleted = errors.New("saga completed by business, stop compensating")
+
+var (
+	defs = make(map[string]*Definition)
+	mu   sync.RWMutex
+
+	cfg = &config.SagaConfig{
+		MaxRetry:        3,
+		Backoff:         100 * time.Millisecond,
+		MaxBackoff:      2 * time.Second,
+		RecoverInterval: 30 * time.Second,
+		RecoverAfter:    time.Minute,
+	}
+)
+
+// Init 加载配置，没有配置的项使用默认值
+func Init(c *config.SagaConfig) {
+	if c == nil {
+		return
+	}
+	if c.MaxRetry > 0 {
+		cfg.MaxRetry = c.MaxRetry
+	}
+	if c.Backoff > 0 {
+		cfg.Backoff = c.Backoff
+	}
+	if c.MaxBackoff > 0 {
+		cfg.MaxBackoff = c.MaxBackoff
+	}
+	if c.RecoverInterval > 0 {
+		cfg.RecoverInterval = c.RecoverInterval
+	}
+	if c.RecoverAfter > 0 {
+		cfg.RecoverAfter = c.RecoverAfter
+	}
+}
+
+// Register 注册 saga 定义，恢复时按名字找到定义
+func Register(def *Definition) {
+	mu.Lock()
+	defer mu.Unlock()
+	defs[def.Name] = def
+}
+
+func lookup(name string) *Definition {
+	mu.RLock()
+	defer mu.RUnlock()
+	return defs[name]
+}
+
+// executor 执行一个 saga 实例
+// 实例和步骤日志的每次写入都以执行者持有的版本号为条件并把版本号加一
+// 恢复协程抢占实例后版本号改变，原执行者的写入影响0行，随即停止执行
+type executor struct {
+	def     *Definition
+	sagaId  int64
+	version int16
+	data    interface{}
+}
+
+// Exec 创建并执行 saga，返回正向操作失败的错误，此时已经尽力完成了补偿
+// 补偿时发现业务已经继续推进（ErrCompleted）时返回nil
+func Exec(ctx context.Context, def *Definition, sagaId int64, data interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	err = mysql.CreateSagaInstance(ctx, &model.SagaInstance{
+		SagaId: sagaId,
+		Name:   def.Name,
+		Status: model.SagaStatusRunning,
+		Data:   string(b),
+	})
+	if err != nil {
+		return err
+	}
+	e := &executor{def: def, sagaId: sagaId, data: data}
+	return e.forward(ctx, 0)
+}
+
+// forward 从第 from 步开始执行正向操作
+func (e *executor) forward(ctx context.Context, from int) error {
+	for i := from; i < len(e.def.Steps); i++ {
+		step := e.def.Steps[i]
+		err := e.runStep(ctx, i, step.Name, model.SagaActionForward, step.Action)
+		if errors.Is(err, errno.ErrSagaLost) {
+			return err
+		}
+		if err != nil {
+			// 失败的步骤可能已经部分执行，从它开始补偿，补偿不受请求 ctx 的影响
+			cctx := tracing.Detach(ctx)
+			lost := e.update(cctx, map[string]interface{}{
+				"status":       model.SagaStatusCompensating,
+				"current_step": i,
+				"last_error":   truncate(err.Error()),
+			})
+			if !lost && e.compensate(cctx, i) == model.SagaStatusSucceeded {
+				return nil
+			}
+			return err
+		}
+		b, _ := json.Marshal(e.data)
+		if e.update(ctx, map[string]interface{}{
+			"current_step": i + 1,
+			"data":         string(b),
+		}) {
+			return errno.ErrSagaLost
+		}
+	}
+	if e.update(ctx, map[string]interface{}{
+		"status": model.SagaStatusSucceeded,
+	}) {
+		return errno.ErrSagaLost
+	}
+	return nil
+}
+
+// compensate 从第 from 步开始倒序补偿，返回实例的状态
+// 补偿失败时保持补偿中的状态，由恢复协程继续；补偿操作返回 ErrCompleted 时停止补偿，实例标记为执行成功
+func (e *executor) compensate(ctx context.Context, from int) int32 {
+	for i := from; i >= 0; i-- {
+		step := e.def.Steps[i]
+		if step.Compensate != nil {
+			err := e.runStep(ctx, i, step.Name, model.SagaActionCompensate, step.Compensate)
+			if errors.Is(err, errno.ErrSagaLost) {
+				return model.SagaStatusCompensating
+			}
+			if errors.Is(err, ErrCompleted) {
+				zap.L().Warn("saga completed while compensating", zap.String("saga", e.def.Name), zap.Int64("saga_id", e.sagaId), zap.String("step", step.Name))
+				if e.update(ctx, map[string]interface{}{
+					"status":     model.SagaStatusSucceeded,
+					"last_error": truncate(err.Error()),
+				}) {
+					return model.SagaStatusCompensating
+				}
+				return model.SagaStatusSucceeded
+			}
+			if err != nil {
+				zap.L().Error("saga compensate failed", zap.String("saga", e.def.Name), zap.Int64("saga_id", e.sagaId), zap.String("step", step.Name), zap.Error(err))
+				e.update(ctx, map[string]interface{}{
+					"current_step": i,
+					"last_error":   truncate(err.Error()),
+				})
+				return model.SagaStatusCompensating
+			}
+		}
+		if e.update(ctx, map[string]interface{}{
+			"current_step": i - 1,
+		}) {
+			return model.SagaStatusCompensating
+		}
+	}
+	if e.update(ctx, map[string]interface{}{
+		"status": model.SagaStatusCompensated,
+	}) {
+		return model.SagaStatusCompensating
+	}
+	return model.SagaStatusCompensated
+}
+
+// runStep 执行一个操作，失败时按指数退避重试，每次执行都记录日志
+// 记录日志时发现实例已被抢占，返回 errno.ErrSagaLost，不再重试
+func (e *executor) runStep(ctx context.Context, index int, name string, action int32, fn func(context.Context, interface{}) error) error {
+	backoff := cfg.Backoff
+	var err error
+	for retry := 0; ; retry++ {
+		err = fn(ctx, e.data)
+		l := &model.SagaStepLog{
+			SagaId:    e.sagaId,
+			StepIndex: int32(index),
+			StepName:  name,
+			Action:    action,
+			Status:    model.SagaStepSuccess,
+			Retry:     int32(retry),
+		}
+		if err != nil {
+			l.Status = model.SagaStepFailed
+			l.Error = truncate(err.Error())
+		}
+		if e.write(func() error { return mysql.CreateSagaStepLog(ctx, e.version, l) }, "mysql.CreateSagaStepLog failed") {
+			return errno.ErrSagaLost
+		}
+		if err == nil || errors.As(err, &abortError{}) || errors.Is(err, ErrCompleted) || retry >= cfg.MaxRetry {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %v", ctx.Err(), err)
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > cfg.MaxBackoff {
+			backoff = cfg.MaxBackoff
+		}
+	}
+}
+
+// update 更新实例，返回 true 表示实例已被抢占，执行者需要停止
+// 其他失败只记录日志，恢复时最多重复执行已经成功的步骤，步骤需要幂等
+func (e *executor) update(ctx context.Context, fields map[string]interface{}) bool {
+	return e.write(func() error { return mysql.UpdateSagaInstance(ctx, e.sagaId, e.version, fields) }, "mysql.UpdateSagaInstance failed", zap.Any("fields", fields))
+}
+
+// write 执行一次按版本号的写入，成功后版本号加一，返回 true 表示实例已被抢占
+func (e *executor) write(fn func() error, msg string, fields ...zap.Field) bool {
+	err := fn()
+	if err == nil {
+		e.version++
+		return false
+	}
+	fields = append(fields, zap.Int64("saga_id", e.sagaId), zap.Error(err))
+	if errors.Is(err, errno.ErrSagaLost) {
+		zap.L().Warn("saga claimed by others, stop executing", fields...)
+		return true
+	}
+	zap.L().Error(msg, fields...)
+	return false
+}
+
+func truncate(s string) string {
+	if len(s) > 255 {
+		return s[:255]
+	}
+	return s
+}
@@ -0,0 +1,218 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/idMiFeng/order_service/dao/mysql"
+	"github.com/idMiFeng/order_service/model"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setup(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	t.Cleanup(func() { sqlDB.Close() })
+	if err = db.AutoMigrate(&model.SagaInstance{}, &model.SagaStepLog{}); err != nil {
+		t.Fatal(err)
+	}
+	mysql.SetDB(db)
+	cfg.MaxRetry = 1
+	cfg.Backoff = time.Millisecond
+	cfg.MaxBackoff = time.Millisecond
+	return db
+}
+
+type testData struct {
+	N int
+}
+
+// recorder 记录各步骤执行的顺序
+type recorder struct {
+	calls []string
+}
+
+func (r *recorder) step(name string, err error) func(context.Context, interface{}) error {
+	return func(ctx context.Context, v interface{}) error {
+		r.calls = append(r.calls, name)
+		v.(*testData).N++
+		return err
+	}
+}
+
+func (r *recorder) definition(name string, failAt int) *Definition {
+	def := &Definition{
+		Name:    name,
+		NewData: func() interface{} { return new(testData) },
+	}
+	for i := 0; i < 3; i++ {
+		var err error
+		if i == failAt {
+			err = Abort(errors.New("failed"))
+		}
+		def.Steps = append(def.Steps, Step{
+			Name:       fmt.Sprint(i),
+			Action:     r.step(fmt.Sprintf("do%d", i), err),
+			Compensate: r.step(fmt.Sprintf("undo%d", i), nil),
+		})
+	}
+	return def
+}
+
+func instance(t *testing.T, db *gorm.DB, sagaId int64) *model.SagaInstance {
+	var inst model.SagaInstance
+	if err := db.Where("saga_id = ?", sagaId).First(&inst).Error; err != nil {
+		t.Fatal(err)
+	}
+	return &inst
+}
+
+func TestExecSucceeded(t *testing.T) {
+	db := setup(t)
+	r := &recorder{}
+	data := &testData{}
+	if err := Exec(context.Background(), r.definition("ok", -1), 1, data); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(r.calls) != "[do0 do1 do2]" {
+		t.Fatalf("calls = %v", r.calls)
+	}
+	inst := instance(t, db, 1)
+	if inst.Status != model.SagaStatusSucceeded || inst.CurrentStep != 3 || inst.Data != `{"N":3}` {
+		t.Fatalf("instance = %+v", inst)
+	}
+	var logs int64
+	db.Model(&model.SagaStepLog{}).Where("saga_id = ?", 1).Count(&logs)
+	if logs != 3 {
+		t.Fatalf("step logs = %d, want 3", logs)
+	}
+}
+
+// 失败的步骤和之前的步骤倒序补偿
+func TestExecCompensated(t *testing.T) {
+	db := setup(t)
+	r := &recorder{}
+	if err := Exec(context.Background(), r.definition("fail", 1), 2, &testData{}); err == nil {
+		t.Fatal("want error")
+	}
+	if fmt.Sprint(r.calls) != "[do0 do1 undo1 undo0]" {
+		t.Fatalf("calls = %v", r.calls)
+	}
+	if inst := instance(t, db, 2); inst.Status != model.SagaStatusCompensated {
+		t.Fatalf("status = %d, want compensated", inst.Status)
+	}
+}
+
+// 补偿时业务已经继续推进，停止补偿，实例标记为成功，不再重试
+func TestCompensateCompleted(t *testing.T) {
+	db := setup(t)
+	r := &recorder{}
+	def := r.definition("completed", 2)
+	def.Steps[1].Compensate = func(context.Context, interface{}) error {
+		r.calls = append(r.calls, "undo1")
+		return ErrCompleted
+	}
+	if err := Exec(context.Background(), def, 8, &testData{}); err != nil {
+		t.Fatalf("Exec = %v, want nil", err)
+	}
+	if fmt.Sprint(r.calls) != "[do0 do1 do2 undo2 undo1]" {
+		t.Fatalf("calls = %v", r.calls)
+	}
+	if inst := instance(t, db, 8); inst.Status != model.SagaStatusSucceeded {
+		t.Fatalf("status = %d, want succeeded", inst.Status)
+	}
+}
+
+// 请求 ctx 取消后补偿继续执行
+func TestCompensateIgnoresRequestCancel(t *testing.T) {
+	db := setup(t)
+	r := &recorder{}
+	ctx, cancel := context.WithCancel(context.Background())
+	def := r.definition("cancel", -1)
+	def.Steps[1].Action = func(context.Context, interface{}) error {
+		cancel()
+		return Abort(context.Canceled)
+	}
+	if err := Exec(ctx, def, 3, &testData{}); err == nil {
+		t.Fatal("want error")
+	}
+	if inst := instance(t, db, 3); inst.Status != model.SagaStatusCompensated {
+		t.Fatalf("status = %d, want compensated", inst.Status)
+	}
+}
+
+// 执行中被恢复协程抢占后，原执行者不再写入，也不执行补偿
+func TestExecStopsWhenClaimed(t *testing.T) {
+	db := setup(t)
+	r := &recorder{}
+	def := r.definition("claimed", 1)
+	def.Steps[1].Action = func(ctx context.Context, v interface{}) error {
+		r.calls = append(r.calls, "do1")
+		inst := instance(t, db, 4)
+		if ok, err := mysql.ClaimSaga(ctx, 4, inst.Version); !ok || err != nil {
+			t.Fatalf("claim failed: %v", err)
+		}
+		return Abort(errors.New("failed"))
+	}
+	if err := Exec(context.Background(), def, 4, &testData{}); err == nil {
+		t.Fatal("want error")
+	}
+	if fmt.Sprint(r.calls) != "[do0 do1]" {
+		t.Fatalf("calls = %v", r.calls)
+	}
+	inst := instance(t, db, 4)
+	if inst.Status != model.SagaStatusRunning || inst.CurrentStep != 1 {
+		t.Fatalf("instance = %+v", inst)
+	}
+	var logs int64
+	db.Model(&model.SagaStepLog{}).Where("saga_id = ?", 4).Count(&logs)
+	if logs != 1 {
+		t.Fatalf("step logs = %d, want 1", logs)
+	}
+}
+
+// 中断的 saga 从中断的步骤开始补偿，恢复时数据按保存的数据还原
+func TestRecover(t *testing.T) {
+	db := setup(t)
+	r := &recorder{}
+	def := r.definition("recover", -1)
+	Register(def)
+	old := time.Now().Add(-time.Hour)
+	insts := []*model.SagaInstance{
+		{SagaId: 5, Name: def.Name, Status: model.SagaStatusRunning, CurrentStep: 2, Data: `{"N":2}`},
+		{SagaId: 6, Name: def.Name, Status: model.SagaStatusRunning, CurrentStep: 3, Data: `{"N":3}`},
+		{SagaId: 7, Name: def.Name, Status: model.SagaStatusRunning, CurrentStep: 1, Data: `{"N":1}`},
+	}
+	for _, inst := range insts {
+		if err := db.Create(inst).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 7 最近还有更新，执行者可能还在执行，不恢复
+	db.Model(&model.SagaInstance{}).Where("saga_id in ?", []int64{5, 6}).Update("update_at", old)
+	recoverOnce(context.Background())
+	if fmt.Sprint(r.calls) != "[undo2 undo1 undo0]" {
+		t.Fatalf("calls = %v", r.calls)
+	}
+	want := map[int64]int32{5: model.SagaStatusCompensated, 6: model.SagaStatusSucceeded, 7: model.SagaStatusRunning}
+	for id, st := range want {
+		if inst := instance(t, db, id); inst.Status != st {
+			t.Fatalf("saga %d status = %d, want %d", id, inst.Status, st)
+		}
+	}
+	// 已经完成的 saga 不会再次恢复
+	r.calls = nil
+	recoverOnce(context.Background())
+	if len(r.calls) != 0 {
+		t.Fatalf("calls = %v", r.calls)
+	}
+}
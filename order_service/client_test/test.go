@@ -1,7 +1,7 @@
 package main
 
 import (
-	"github.com/idMiFeng/order_service/proto"
+	stockv1 "github.com/idMiFeng/api/shop/stock/v1"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -9,7 +9,7 @@ import (
 
 var (
 	conn   *grpc.ClientConn
-	client proto.StockClient
+	client stockv1.StockClient
 )
 
 func init() {
@@ -18,7 +18,7 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
-	client = proto.NewStockClient(conn)
+	client = stockv1.NewStockClient(conn)
 }
 
 func main() {
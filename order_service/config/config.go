@@ -1,115 +1,170 @@
 package config
 
 import (
-	"fmt"
+	"time"
 
-	"github.com/fsnotify/fsnotify"
-	"github.com/spf13/viper"
+	"github.com/idMiFeng/common/broker"
+	"github.com/idMiFeng/common/config"
 )
 
 // Conf 定义全局的变量
 var Conf = new(SrvConfig)
 
-// viper.GetXxx()读取的方式
-// 注意：
-// Viper使用的是 `mapstructure`
-
 // SrvConfig 服务配置
 type SrvConfig struct {
-	Name    string `mapstructure:"name"`
-	Mode    string `mapstructure:"mode"`
-	Version string `mapstructure:"version"`
-	// snowflake
-	StartTime string `mapstructure:"start_time"`
-	MachineID int64  `mapstructure:"machine_id"`
-
-	IP   string `mapstructure:"ip"`
-	Port int    `mapstructure:"port"`
-
-	*LogConfig      `mapstructure:"log"`
-	*MySQLConfig    `mapstructure:"mysql"`
-	*RedisConfig    `mapstructure:"redis"`
-	*ConsulConfig   `mapstructure:"consul"`
-	*RocketMqConfig `mapstructure:"rocketmq"`
+	config.Base `mapstructure:",squash"`
+
+	*RocketMqConfig  `mapstructure:"rocketmq"`
+	*PaymentConfig   `mapstructure:"payment"`
+	*OrderConfig     `mapstructure:"order"`
+	*OutboxConfig    `mapstructure:"outbox"`
+	*SchedulerConfig `mapstructure:"scheduler"`
+	*SagaConfig      `mapstructure:"saga"`
+	*LimitConfig     `mapstructure:"limit"`
 
 	*GoodsService `mapstructure:"goods_service"`
 	*StockService `mapstructure:"stock_service"`
 }
 
 type GoodsService struct {
-	Name string `mapstructure:"name"`
+	Name          string                  `mapstructure:"name"`
+	*ClientConfig `mapstructure:"client"` // 超时、重试、对冲和熔断
 }
 
 type StockService struct {
-	Name string `mapstructure:"name"`
+	Name          string `mapstructure:"name"`
+	*ClientConfig `mapstructure:"client"`
 }
 
-type MySQLConfig struct {
-	Host         string `mapstructure:"host"`
-	User         string `mapstructure:"user"`
-	Password     string `mapstructure:"password"`
-	DB           string `mapstructure:"dbname"`
-	Port         int    `mapstructure:"port"`
-	MaxOpenConns int    `mapstructure:"max_open_conns"`
-	MaxIdleConns int    `mapstructure:"max_idle_conns"`
+// 通用配置定义在common/config
+type (
+	MySQLConfig    = config.MySQLConfig
+	RedisConfig    = config.RedisConfig
+	LogConfig      = config.LogConfig
+	RegistryConfig = config.RegistryConfig
+	TraceConfig    = config.TraceConfig
+	AuthConfig     = config.AuthConfig
+	ClientConfig   = config.ClientConfig
+)
+
+type RocketMqConfig struct {
+	Addr            string `mapstructure:"addr"`
+	GroupId         string `mapstructure:"group_id"`
+	TxGroupId       string `mapstructure:"tx_group_id"`       // 事务消息生产者组
+	ConsumerGroupId string `mapstructure:"consumer_group_id"` // 消费者组
+	MaxReconsume    int32  `mapstructure:"max_reconsume"`     // 最大重试次数，超过后投递到死信topic
+	Topic           struct {
+		PayTimeOut    string `mapstructure:"pay_timeout"`
+		StockRollback string `mapstructure:"stock_rollback"`
+		PaySuccess    string `mapstructure:"pay_success"`
+		DeadLetter    string `mapstructure:"dead_letter"`
+	} `mapstructure:"topic"`
 }
 
-type RedisConfig struct {
-	Host         string `mapstructure:"host"`
-	Password     string `mapstructure:"password"`
-	Port         int    `mapstructure:"port"`
-	DB           int    `mapstructure:"db"`
-	PoolSize     int    `mapstructure:"pool_size"`
-	MinIdleConns int    `mapstructure:"min_idle_conns"`
+// Broker 消息中间件的连接配置
+func (c *RocketMqConfig) Broker() *broker.Config {
+	return &broker.Config{
+		Addr:            c.Addr,
+		GroupId:         c.GroupId,
+		TxGroupId:       c.TxGroupId,
+		ConsumerGroupId: c.ConsumerGroupId,
+		MaxReconsume:    c.MaxReconsume,
+		DeadLetter:      c.Topic.DeadLetter,
+	}
 }
 
-type LogConfig struct {
-	Level      string `mapstructure:"level"`
-	Filename   string `mapstructure:"filename"`
-	MaxSize    int    `mapstructure:"max_size"`
-	MaxAge     int    `mapstructure:"max_age"`
-	MaxBackups int    `mapstructure:"max_backups"`
+// 创建订单的分布式事务模式
+const (
+	TxModeRocketMQ = "rocketmq" // RocketMQ事务消息
+	TxModeOutbox   = "outbox"   // 本地消息表
+	TxModeSaga     = "saga"     // saga编排
+)
+
+// 支付超时的实现方式
+const (
+	TimeoutModeRocketMQ = "rocketmq" // RocketMQ延迟消息
+	TimeoutModeRedis    = "redis"    // Redis延迟任务
+)
+
+// OrderConfig 订单配置
+type OrderConfig struct {
+	TxMode      string `mapstructure:"tx_mode"`      // rocketmq/outbox/saga，默认rocketmq
+	TimeoutMode string `mapstructure:"timeout_mode"` // rocketmq/redis，默认rocketmq
+	// 各订单类型的支付超时时间，key为订单类型名：normal/flash_sale
+	PayTimeout map[string]time.Duration `mapstructure:"pay_timeout"`
 }
 
-type ConsulConfig struct {
-	Addr string `mapstructure:"addr"`
+// OutboxConfig 本地消息表中继配置
+type OutboxConfig struct {
+	Interval time.Duration `mapstructure:"interval"`  // 轮询间隔
+	Batch    int           `mapstructure:"batch"`     // 每次投递的条数
+	MaxRetry int32         `mapstructure:"max_retry"` // 最大重试次数
+	Lease    time.Duration `mapstructure:"lease"`     // 取走消息的租约，超过后其他中继可以重新投递
+	Guard    time.Duration `mapstructure:"guard"`     // 扣减库存前写入的回滚消息的延迟，要大于扣减库存到创建订单的最长耗时
 }
 
-type RocketMqConfig struct {
-	Addr    string `mapstructure:"addr"`
-	GroupId string `mapstructure:"group_id"`
-	Topic   struct {
-		PayTimeOut    string `mapstructure:"pay_timeout"`
-		StockRollback string `mapstructure:"stock_rollback"`
-	} `mapstructure:"topic"`
+// SchedulerConfig Redis延迟任务配置
+type SchedulerConfig struct {
+	Interval   time.Duration `mapstructure:"interval"`    // 轮询间隔
+	Batch      int           `mapstructure:"batch"`       // 每次领取的任务数
+	Visibility time.Duration `mapstructure:"visibility"`  // 领取后超过这个时间没有确认，任务会被重新执行
+	RetryDelay time.Duration `mapstructure:"retry_delay"` // 执行失败后的重试间隔
 }
 
-// Init 整个服务配置文件初始化的方法
-func Init(filePath string) (err error) {
-	// 方式1：直接指定配置文件路径（相对路径或者绝对路径）
-	// 相对路径：相对执行的可执行文件的相对路径
-	// viper.SetConfigFile("./conf/config.yaml")
-	viper.SetConfigFile(filePath)
-
-	err = viper.ReadInConfig() // 读取配置信息
-	if err != nil {
-		// 读取配置信息失败
-		fmt.Printf("viper.ReadInConfig failed, err:%v\n", err)
-		return
-	}
-	// 如果使用的是 viper.GetXxx()方式使用配置的话，就无须下面的操作
+// SagaConfig saga编排配置
+type SagaConfig struct {
+	MaxRetry        int           `mapstructure:"max_retry"`        // 每个操作的最大重试次数
+	Backoff         time.Duration `mapstructure:"backoff"`          // 第一次重试的间隔，之后翻倍
+	MaxBackoff      time.Duration `mapstructure:"max_backoff"`      // 最大重试间隔
+	RecoverInterval time.Duration `mapstructure:"recover_interval"` // 检查未完成saga的间隔
+	RecoverAfter    time.Duration `mapstructure:"recover_after"`    // 超过这个时间没有进展的saga由恢复协程接管
+}
 
-	// 把读取到的配置信息反序列化到 Conf 变量中
-	if err := viper.Unmarshal(Conf); err != nil {
-		fmt.Printf("viper.Unmarshal failed, err:%v\n", err)
-	}
+// LimitConfig 创建订单的限流和排队配置，秒杀时保护MySQL和库存的分布式锁
+type LimitConfig struct {
+	Global BucketConfig `mapstructure:"global"` // 所有请求共用一个桶
+	User   BucketConfig `mapstructure:"user"`   // 每个用户一个桶
+	Goods  BucketConfig `mapstructure:"goods"`  // 每个商品一个桶
 
-	viper.WatchConfig() // 配置文件监听
-	viper.OnConfigChange(func(in fsnotify.Event) {
-		fmt.Println("配置文件修改了...")
-		if err := viper.Unmarshal(Conf); err != nil {
-			fmt.Printf("viper.Unmarshal failed, err:%v\n", err)
+	Admission AdmissionConfig `mapstructure:"admission"`
+}
+
+// Distributed 是否有在Redis中计数的令牌桶
+func (c *LimitConfig) Distributed() bool {
+	for _, b := range []BucketConfig{c.Global, c.User, c.Goods} {
+		if b.Rate > 0 && b.Distributed {
+			return true
 		}
-	})
-	return
+	}
+	return false
+}
+
+// BucketConfig 令牌桶配置
+type BucketConfig struct {
+	Rate        float64 `mapstructure:"rate"`        // 每秒补充的令牌数，为0时不限流
+	Burst       int     `mapstructure:"burst"`       // 桶的容量，允许的突发请求数
+	Distributed bool    `mapstructure:"distributed"` // 在Redis中计数，所有副本共享同一个桶
+}
+
+// AdmissionConfig 同一个商品的排队配置
+type AdmissionConfig struct {
+	Concurrency  int           `mapstructure:"concurrency"`   // 同一个商品同时创建订单的请求数，为0时不排队
+	QueueSize    int           `mapstructure:"queue_size"`    // 排队的最大请求数，超过时直接拒绝
+	QueueTimeout time.Duration `mapstructure:"queue_timeout"` // 排队的最长时间
+	SoldOutTTL   time.Duration `mapstructure:"sold_out_ttl"`  // 商品库存为0后直接拒绝请求的时间，补货后最多这么久恢复下单
+}
+
+// PaymentConfig 支付配置
+type PaymentConfig struct {
+	Provider     string        `mapstructure:"provider"`      // 默认支付渠道
+	Secret       string        `mapstructure:"secret"`        // 回调签名密钥
+	NotifyURL    string        `mapstructure:"notify_url"`    // 支付结果回调地址
+	NotifyWindow time.Duration `mapstructure:"notify_window"` // 回调时间戳与当前时间相差超过该值时拒绝，防止回调被截获后重放
+
+	Mock struct {
+		Result string        `mapstructure:"result"` // 模拟支付结果：success/fail/none
+		Delay  time.Duration `mapstructure:"delay"`  // 模拟回调延迟
+
+		RefundResult string `mapstructure:"refund_result"` // 模拟退款结果：success/fail
+	} `mapstructure:"mock"`
 }
@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"context"
+	"github.com/idMiFeng/order_service/errno"
 	"github.com/idMiFeng/order_service/model"
 
 	"gorm.io/gorm"
@@ -52,3 +53,65 @@ func CreateOrderWithTransation(ctx context.Context, order *model.Order, orderDet
 			return nil
 		})
 }
+
+func QueryOrderRequest(ctx context.Context, userId int64, requestId string) (model.OrderRequest, error) {
+	var data model.OrderRequest
+	err := db.WithContext(ctx).
+		Model(&model.OrderRequest{}).
+		Where("user_id = ? and request_id = ?", userId, requestId).
+		First(&data).Error
+	return data, err
+}
+
+// CreateOrderRequest 记录幂等键，(user_id, request_id) 唯一索引冲突时返回错误
+func CreateOrderRequest(ctx context.Context, data *model.OrderRequest) error {
+	return db.WithContext(ctx).
+		Model(&model.OrderRequest{}).
+		Create(data).Error
+}
+
+func DeleteOrderRequest(ctx context.Context, userId int64, requestId string) error {
+	return db.WithContext(ctx).
+		Where("user_id = ? and request_id = ?", userId, requestId).
+		Delete(&model.OrderRequest{}).Error
+}
+
+// CloseOrder 关闭待支付订单
+// 用户取消、支付超时、支付回调之间通过订单状态的条件更新保证只有一方成功
+func CloseOrder(ctx context.Context, orderId int64, reason string) error {
+	return transitOrderStatus(db.WithContext(ctx), orderId, model.OrderStatusPending, model.OrderStatusClosed, map[string]interface{}{
+		"cancel_reason": reason,
+	})
+}
+
+// QueryOrderDetail 查询订单的所有商品
+func QueryOrderDetail(ctx context.Context, orderId int64) ([]*model.OrderDetail, error) {
+	var data []*model.OrderDetail
+	err := db.WithContext(ctx).
+		Model(&model.OrderDetail{}).
+		Where("order_id = ?", orderId).
+		Find(&data).Error
+	return data, err
+}
+
+// transitOrderStatus 在事务中按订单状态机流转订单状态
+// 只有当前状态为 from 时才会更新，订单状态已经变化时返回 errno.ErrOrderStatusChanged
+func transitOrderStatus(tx *gorm.DB, orderId int64, from, to int32, fields map[string]interface{}) error {
+	if !model.CanTransit(from, to) {
+		return errno.ErrOrderStatusTransit
+	}
+	if fields == nil {
+		fields = make(map[string]interface{})
+	}
+	fields["status"] = to
+	res := tx.Model(&model.Order{}).
+		Where("order_id = ? and status = ?", orderId, from).
+		Updates(fields)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errno.ErrOrderStatusChanged
+	}
+	return nil
+}
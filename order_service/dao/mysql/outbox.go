@@ -0,0 +1,121 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/idMiFeng/order_service/errno"
+	"github.com/idMiFeng/order_service/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateOrderWithOutbox 在同一个事务中创建订单、订单详情和本地消息
+// guardId 为扣减库存前写入的库存回滚消息，订单创建成功时取消；已经被中继取走时订单不再创建
+func CreateOrderWithOutbox(ctx context.Context, order *model.Order, orderDetail *model.OrderDetail, events []*model.Outbox, guardId uint) error {
+	return db.WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			res := tx.Model(&model.Outbox{}).
+				Where("id = ? and status = ? and version = 0", guardId, model.OutboxStatusPending).
+				Update("status", model.OutboxStatusCancelled)
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return errno.ErrOutboxRelayed
+			}
+			if err := tx.Create(order).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(orderDetail).Error; err != nil {
+				return err
+			}
+			if len(events) == 0 {
+				return nil
+			}
+			return tx.Create(&events).Error
+		})
+}
+
+// CreateOutbox 单独写入本地消息
+func CreateOutbox(ctx context.Context, events []*model.Outbox) error {
+	return db.WithContext(ctx).
+		Model(&model.Outbox{}).
+		Create(&events).Error
+}
+
+// CancelOutbox 取消还没有被中继取走的消息
+func CancelOutbox(ctx context.Context, id uint) error {
+	return db.WithContext(ctx).
+		Model(&model.Outbox{}).
+		Where("id = ? and status = ? and version = 0", id, model.OutboxStatusPending).
+		Update("status", model.OutboxStatusCancelled).Error
+}
+
+// ReleaseOutbox 还没有被中继取走的延迟消息改为立即投递
+func ReleaseOutbox(ctx context.Context, id uint) error {
+	now := time.Now()
+	return db.WithContext(ctx).
+		Model(&model.Outbox{}).
+		Where("id = ? and status = ? and version = 0", id, model.OutboxStatusPending).
+		Updates(map[string]interface{}{
+			"deliver_at":    now,
+			"next_retry_at": now,
+		}).Error
+}
+
+// ClaimOutbox 按写入顺序取出到期的待投递消息，每个 key 只取最早的一条，保证同一个 key 的消息按顺序投递
+// 取出的消息 next_retry_at 推迟 lease 作为租约，租约内其他中继不会再取到，中继挂掉后租约到期重新投递
+// 每次取走 version 加1，version 为0说明消息还没有被中继取走过
+func ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]*model.Outbox, error) {
+	var data []*model.Outbox
+	err := db.WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			now := time.Now()
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+				Model(&model.Outbox{}).
+				Where("status = ? and next_retry_at <= ?", model.OutboxStatusPending, now).
+				Where("not exists (select 1 from xx_outbox b where b.sharding_key = xx_outbox.sharding_key and b.status = ? and b.id < xx_outbox.id)", model.OutboxStatusPending).
+				Order("id").
+				Limit(limit).
+				Find(&data).Error
+			if err != nil || len(data) == 0 {
+				return err
+			}
+			ids := make([]uint, 0, len(data))
+			for _, e := range data {
+				ids = append(ids, e.ID)
+			}
+			return tx.Model(&model.Outbox{}).
+				Where("id in ?", ids).
+				Updates(map[string]interface{}{
+					"next_retry_at": now.Add(lease),
+					"version":       gorm.Expr("version + 1"),
+				}).Error
+		})
+	return data, err
+}
+
+func MarkOutboxSent(ctx context.Context, id uint) error {
+	return db.WithContext(ctx).
+		Model(&model.Outbox{}).
+		Where("id = ? and status = ?", id, model.OutboxStatusPending).
+		Update("status", model.OutboxStatusSent).Error
+}
+
+// MarkOutboxRetry 记录投递失败，dead 为 true 时不再重试
+func MarkOutboxRetry(ctx context.Context, id uint, nextRetryAt time.Time, lastError string, dead bool) error {
+	fields := map[string]interface{}{
+		"retry_count":   gorm.Expr("retry_count + 1"),
+		"next_retry_at": nextRetryAt,
+		"last_error":    lastError,
+	}
+	if dead {
+		fields["status"] = model.OutboxStatusDead
+	}
+	return db.WithContext(ctx).
+		Model(&model.Outbox{}).
+		Where("id = ? and status = ?", id, model.OutboxStatusPending).
+		Updates(fields).Error
+}
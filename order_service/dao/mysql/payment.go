@@ -0,0 +1,56 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/idMiFeng/order_service/model"
+
+	"gorm.io/gorm"
+)
+
+func CreatePayment(ctx context.Context, data *model.Payment) error {
+	return db.WithContext(ctx).
+		Model(&model.Payment{}).
+		Create(data).Error
+}
+
+func QueryPayment(ctx context.Context, payId int64) (model.Payment, error) {
+	var data model.Payment
+	err := db.WithContext(ctx).
+		Model(&model.Payment{}).
+		Where("pay_id = ?", payId).
+		First(&data).Error
+	return data, err
+}
+
+// UpdatePaymentStatus 只更新待支付的支付单
+func UpdatePaymentStatus(ctx context.Context, payId int64, tradeId string, status int32) error {
+	return db.WithContext(ctx).
+		Model(&model.Payment{}).
+		Where("pay_id = ? and status = ?", payId, model.PayStatusPending).
+		Updates(map[string]interface{}{"trade_id": tradeId, "status": status}).Error
+}
+
+// PayOrderWithTransaction 支付成功，在同一个事务中更新支付单和订单
+// 订单只能从待支付变为已支付，订单状态已经变化时返回 errno.ErrOrderStatusChanged
+func PayOrderWithTransaction(ctx context.Context, p *model.Payment, payTime time.Time) error {
+	return db.WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			err := transitOrderStatus(tx, p.OrderId, model.OrderStatusPending, model.OrderStatusPaid, map[string]interface{}{
+				"trade_id":    p.TradeId,
+				"pay_channel": p.PayChannel,
+				"pay_time":    payTime,
+			})
+			if err != nil {
+				return err
+			}
+			return tx.Model(&model.Payment{}).
+				Where("pay_id = ?", p.PayId).
+				Updates(map[string]interface{}{
+					"trade_id": p.TradeId,
+					"status":   model.PayStatusSuccess,
+					"pay_time": payTime,
+				}).Error
+		})
+}
@@ -0,0 +1,164 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/idMiFeng/order_service/errno"
+	"github.com/idMiFeng/order_service/model"
+
+	"gorm.io/gorm"
+)
+
+// 售后相关的数据库操作
+// 售后单的每一步状态变化都和对应的记录表、订单状态在同一个事务中完成
+
+func QueryRefund(ctx context.Context, refundId int64) (model.Refund, error) {
+	var data model.Refund
+	err := db.WithContext(ctx).
+		Model(&model.Refund{}).
+		Where("refund_id = ?", refundId).
+		First(&data).Error
+	return data, err
+}
+
+func QueryRefundItems(ctx context.Context, refundId int64) ([]*model.RefundItem, error) {
+	var data []*model.RefundItem
+	err := db.WithContext(ctx).
+		Model(&model.RefundItem{}).
+		Where("refund_id = ?", refundId).
+		Find(&data).Error
+	return data, err
+}
+
+// QueryRefundedItems 查询订单已经退款成功的商品
+func QueryRefundedItems(ctx context.Context, orderId int64) ([]*model.RefundItem, error) {
+	var data []*model.RefundItem
+	err := db.WithContext(ctx).
+		Model(&model.RefundItem{}).
+		Joins("join xx_refund on xx_refund.refund_id = xx_refund_item.refund_id").
+		Where("xx_refund_item.order_id = ? and xx_refund.status = ?", orderId, model.RefundStatusSuccess).
+		Find(&data).Error
+	return data, err
+}
+
+// updateRefundStatus 只有当前状态为 from 时才会更新
+func updateRefundStatus(tx *gorm.DB, refundId int64, from, to int32) error {
+	res := tx.Model(&model.Refund{}).
+		Where("refund_id = ? and status = ?", refundId, from).
+		Update("status", to)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errno.ErrRefundStatusChanged
+	}
+	return nil
+}
+
+// CreateRefundWithTransaction 创建售后单，订单进入售后中
+func CreateRefundWithTransaction(ctx context.Context, refund *model.Refund, items []*model.RefundItem) error {
+	return db.WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			err := transitOrderStatus(tx, refund.OrderId, refund.OrderStatus, model.OrderStatusAfterSale, nil)
+			if err != nil {
+				return err
+			}
+			if err = tx.Create(refund).Error; err != nil {
+				return err
+			}
+			return tx.Create(&items).Error
+		})
+}
+
+// AuditRefundWithTransaction 记录商家审核结果并流转售后单状态
+// 拒绝或关闭时订单恢复到申请售后前的状态
+func AuditRefundWithTransaction(ctx context.Context, refund *model.Refund, audit *model.RefundAudit, to int32) error {
+	return db.WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			err := updateRefundStatus(tx, refund.RefundId, refund.Status, to)
+			if err != nil {
+				return err
+			}
+			if err = tx.Create(audit).Error; err != nil {
+				return err
+			}
+			if to == model.RefundStatusRejected || to == model.RefundStatusClosed {
+				return transitOrderStatus(tx, refund.OrderId, model.OrderStatusAfterSale, refund.OrderStatus, nil)
+			}
+			return nil
+		})
+}
+
+// CreateRefundLogistics 买家填写退货物流
+func CreateRefundLogistics(ctx context.Context, logistics *model.RefundLogistics) error {
+	return db.WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			err := updateRefundStatus(tx, logistics.RefundId, model.RefundStatusReturning, model.RefundStatusReceiving)
+			if err != nil {
+				return err
+			}
+			return tx.Create(logistics).Error
+		})
+}
+
+// ReceiveRefundLogistics 商家确认收到退货
+func ReceiveRefundLogistics(ctx context.Context, refundId int64) error {
+	return db.WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			err := updateRefundStatus(tx, refundId, model.RefundStatusReceiving, model.RefundStatusRefunding)
+			if err != nil {
+				return err
+			}
+			return tx.Model(&model.RefundLogistics{}).
+				Where("refund_id = ?", refundId).
+				Update("status", model.LogisticsStatusReceived).Error
+		})
+}
+
+// RetryRefund 退款失败的售后单重新进入退款中
+func RetryRefund(ctx context.Context, refundId int64) error {
+	return updateRefundStatus(db.WithContext(ctx), refundId, model.RefundStatusFailed, model.RefundStatusRefunding)
+}
+
+func CreateRefundPayment(ctx context.Context, data *model.RefundPayment) error {
+	return db.WithContext(ctx).
+		Model(&model.RefundPayment{}).
+		Create(data).Error
+}
+
+// QuerySuccessRefundPayment 查询售后单渠道退款成功的记录，重试时已经退款成功的不再调用渠道
+func QuerySuccessRefundPayment(ctx context.Context, refundId int64) (model.RefundPayment, error) {
+	var data model.RefundPayment
+	err := db.WithContext(ctx).
+		Model(&model.RefundPayment{}).
+		Where("refund_id = ? and status = ?", refundId, model.PayStatusSuccess).
+		First(&data).Error
+	return data, err
+}
+
+func UpdateRefundPayment(ctx context.Context, data *model.RefundPayment) error {
+	return db.WithContext(ctx).
+		Model(&model.RefundPayment{}).
+		Where("id = ?", data.ID).
+		Updates(map[string]interface{}{
+			"refund_trade_id": data.RefundTradeId,
+			"status":          data.Status,
+			"err_msg":         data.ErrMsg,
+		}).Error
+}
+
+// FinishRefundWithTransaction 退款执行结束
+// 成功时订单流转到 orderTo，失败时订单保持售后中，等待重试
+func FinishRefundWithTransaction(ctx context.Context, refund *model.Refund, success bool, orderTo int32) error {
+	return db.WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			if !success {
+				return updateRefundStatus(tx, refund.RefundId, model.RefundStatusRefunding, model.RefundStatusFailed)
+			}
+			err := updateRefundStatus(tx, refund.RefundId, model.RefundStatusRefunding, model.RefundStatusSuccess)
+			if err != nil {
+				return err
+			}
+			return transitOrderStatus(tx, refund.OrderId, model.OrderStatusAfterSale, orderTo, nil)
+		})
+}
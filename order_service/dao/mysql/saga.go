@@ -0,0 +1,77 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/idMiFeng/order_service/errno"
+	"github.com/idMiFeng/order_service/model"
+
+	"gorm.io/gorm"
+)
+
+func CreateSagaInstance(ctx context.Context, data *model.SagaInstance) error {
+	return db.WithContext(ctx).
+		Model(&model.SagaInstance{}).
+		Create(data).Error
+}
+
+// UpdateSagaInstance 更新 saga 实例的状态、当前步骤和数据
+// 只有版本号仍为 version 时才更新，同时版本号加一，实例已被其他执行者抢占时返回 errno.ErrSagaLost
+func UpdateSagaInstance(ctx context.Context, sagaId int64, version int16, fields map[string]interface{}) error {
+	return updateSagaInstance(db.WithContext(ctx), sagaId, version, fields)
+}
+
+func updateSagaInstance(tx *gorm.DB, sagaId int64, version int16, fields map[string]interface{}) error {
+	updates := make(map[string]interface{}, len(fields)+2)
+	for k, v := range fields {
+		updates[k] = v
+	}
+	updates["version"] = gorm.Expr("version + 1")
+	updates["update_at"] = time.Now()
+	res := tx.Model(&model.SagaInstance{}).
+		Where("saga_id = ? and version = ?", sagaId, version).
+		Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errno.ErrSagaLost
+	}
+	return nil
+}
+
+// CreateSagaStepLog 记录步骤的执行日志，在同一个事务中按版本号更新实例，实例已被其他执行者抢占时不记录
+func CreateSagaStepLog(ctx context.Context, version int16, data *model.SagaStepLog) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := updateSagaInstance(tx, data.SagaId, version, nil); err != nil {
+			return err
+		}
+		return tx.Model(&model.SagaStepLog{}).
+			Create(data).Error
+	})
+}
+
+// ListUnfinishedSagas 查询 before 之后没有更新过的未完成的 saga
+func ListUnfinishedSagas(ctx context.Context, before time.Time, limit int) ([]*model.SagaInstance, error) {
+	var data []*model.SagaInstance
+	err := db.WithContext(ctx).
+		Model(&model.SagaInstance{}).
+		Where("status in ? and update_at < ?", []int32{model.SagaStatusRunning, model.SagaStatusCompensating}, before).
+		Order("id").
+		Limit(limit).
+		Find(&data).Error
+	return data, err
+}
+
+// ClaimSaga 按版本号抢占 saga，多个副本同时恢复时只有一个能抢到
+func ClaimSaga(ctx context.Context, sagaId int64, version int16) (bool, error) {
+	res := db.WithContext(ctx).
+		Model(&model.SagaInstance{}).
+		Where("saga_id = ? and version = ?", sagaId, version).
+		Updates(map[string]interface{}{
+			"version":   gorm.Expr("version + 1"),
+			"update_at": time.Now(),
+		})
+	return res.RowsAffected == 1, res.Error
+}
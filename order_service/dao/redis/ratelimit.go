@@ -0,0 +1,10 @@
+package redis
+
+import (
+	"github.com/idMiFeng/common/ratelimit"
+)
+
+// NewRateLimiter 创建所有副本共享的令牌桶，name 区分不同用途的桶
+func NewRateLimiter(name string, rate float64, burst int) ratelimit.Limiter {
+	return ratelimit.NewRedis(rc, name, rate, burst)
+}
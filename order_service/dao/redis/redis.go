@@ -0,0 +1,34 @@
+package redis
+
+import (
+	"context"
+
+	commonredis "github.com/idMiFeng/common/redis"
+	"github.com/idMiFeng/order_service/config"
+
+	"github.com/go-redis/redis/v8"
+)
+
+var rc *redis.Client
+
+func Init(cfg *config.RedisConfig) (err error) {
+	rc, err = commonredis.Open(cfg)
+	return
+}
+
+// SetClient 使用已经建立的连接，单元测试中替换为miniredis
+func SetClient(c *redis.Client) {
+	rc = c
+}
+
+// Ping 检查Redis是否可用
+func Ping(ctx context.Context) error {
+	return rc.Ping(ctx).Err()
+}
+
+func Close() error {
+	if rc == nil {
+		return nil
+	}
+	return rc.Close()
+}
@@ -0,0 +1,264 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/idMiFeng/common/errno"
+	"github.com/idMiFeng/common/lifecycle"
+	"github.com/idMiFeng/order_service/config"
+
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+// 基于 Redis 有序集合的延迟任务
+// {name}:pending    待执行的任务，score 为执行时间（毫秒）
+// {name}:processing 执行中的任务，score 为可见性超时时间，超时没有确认的任务重新放回 pending
+// {name}:data       任务的数据
+// 领取任务用 lua 脚本原子完成，多个副本同时轮询时一个任务只会被一个副本领取
+// 执行是至少一次的，处理函数需要保证幂等
+// 每个任务开始执行前续期一次可见性超时，批量中靠后的任务不会因为前面的任务耗时而被重复执行
+
+// JobHandler 执行任务，返回错误时稍后重试
+// 返回 errno.ErrPoisonMsg 时任务交给 DeadLetterHandler 保存后删除，不再重试
+type JobHandler func(ctx context.Context, key string, payload []byte) error
+
+// DeadLetterHandler 保存无法处理的任务，返回错误时稍后重试
+type DeadLetterHandler func(ctx context.Context, key string, payload []byte, reason error) error
+
+// Scheduler 延迟任务调度器
+type Scheduler struct {
+	pending    string
+	processing string
+	data       string
+
+	handler    JobHandler
+	deadLetter DeadLetterHandler
+	interval   time.Duration
+	batch      int
+	visibility time.Duration
+	retryDelay time.Duration
+}
+
+// NewScheduler 创建调度器，name 相同的调度器共享同一组任务
+func NewScheduler(name string, h JobHandler, cfg *config.SchedulerConfig) *Scheduler {
+	// 使用 hash tag 保证在 Redis Cluster 中几个 key 落在同一个 slot
+	s := &Scheduler{
+		pending:    fmt.Sprintf("{%s}:pending", name),
+		processing: fmt.Sprintf("{%s}:processing", name),
+		data:       fmt.Sprintf("{%s}:data", name),
+		handler:    h,
+		interval:   time.Second,
+		batch:      100,
+		visibility: 30 * time.Second,
+		retryDelay: 5 * time.Second,
+	}
+	if cfg != nil {
+		if cfg.Interval > 0 {
+			s.interval = cfg.Interval
+		}
+		if cfg.Batch > 0 {
+			s.batch = cfg.Batch
+		}
+		if cfg.Visibility > 0 {
+			s.visibility = cfg.Visibility
+		}
+		if cfg.RetryDelay > 0 {
+			s.retryDelay = cfg.RetryDelay
+		}
+	}
+	return s
+}
+
+// scheduleScript 保存任务数据并设置执行时间，执行中的任务会在确认时保留新的数据
+var scheduleScript = redis.NewScript(`
+redis.call('HSET', KEYS[3], ARGV[1], ARGV[3])
+redis.call('ZREM', KEYS[2], ARGV[1])
+return redis.call('ZADD', KEYS[1], ARGV[2], ARGV[1])
+`)
+
+// rescheduleScript 只修改已存在的任务
+var rescheduleScript = redis.NewScript(`
+if redis.call('HEXISTS', KEYS[3], ARGV[1]) == 0 then
+	return 0
+end
+redis.call('ZREM', KEYS[2], ARGV[1])
+redis.call('ZADD', KEYS[1], ARGV[2], ARGV[1])
+return 1
+`)
+
+// cancelScript 删除任务
+var cancelScript = redis.NewScript(`
+redis.call('ZREM', KEYS[1], ARGV[1])
+redis.call('ZREM', KEYS[2], ARGV[1])
+return redis.call('HDEL', KEYS[3], ARGV[1])
+`)
+
+// claimScript 把超时未确认的任务放回 pending，再领取到期的任务
+// 返回 key、可见性超时时间、数据 依次排列的数组
+var claimScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local expired = redis.call('ZRANGEBYSCORE', KEYS[2], '-inf', now, 'LIMIT', 0, ARGV[3])
+for _, key in ipairs(expired) do
+	redis.call('ZREM', KEYS[2], key)
+	redis.call('ZADD', KEYS[1], 'NX', now, key)
+end
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', now, 'LIMIT', 0, ARGV[3])
+local res = {}
+for _, key in ipairs(due) do
+	redis.call('ZREM', KEYS[1], key)
+	local data = redis.call('HGET', KEYS[3], key)
+	if data then
+		redis.call('ZADD', KEYS[2], ARGV[2], key)
+		table.insert(res, key)
+		table.insert(res, ARGV[2])
+		table.insert(res, data)
+	end
+end
+return res
+`)
+
+// ackScript 确认任务执行成功，执行期间任务被重新调度或取消时不删除
+var ackScript = redis.NewScript(`
+if redis.call('ZSCORE', KEYS[2], ARGV[1]) ~= ARGV[2] then
+	return 0
+end
+redis.call('ZREM', KEYS[2], ARGV[1])
+if redis.call('ZSCORE', KEYS[1], ARGV[1]) == false then
+	redis.call('HDEL', KEYS[3], ARGV[1])
+end
+return 1
+`)
+
+// nackScript 任务执行失败，稍后重试
+var nackScript = redis.NewScript(`
+if redis.call('ZSCORE', KEYS[2], ARGV[1]) ~= ARGV[2] then
+	return 0
+end
+redis.call('ZREM', KEYS[2], ARGV[1])
+redis.call('ZADD', KEYS[1], 'NX', ARGV[3], ARGV[1])
+return 1
+`)
+
+// renewScript 任务开始执行前续期，任务已经被重新调度、取消或被其他副本领取时返回0
+var renewScript = redis.NewScript(`
+if redis.call('ZSCORE', KEYS[2], ARGV[1]) ~= ARGV[2] then
+	return 0
+end
+redis.call('ZADD', KEYS[2], ARGV[3], ARGV[1])
+return 1
+`)
+
+// SetDeadLetter 设置无法处理的任务的保存方式，没有设置时只记录日志后丢弃
+func (s *Scheduler) SetDeadLetter(h DeadLetterHandler) {
+	s.deadLetter = h
+}
+
+func (s *Scheduler) keys() []string {
+	return []string{s.pending, s.processing, s.data}
+}
+
+// Schedule 添加任务，key 已存在时覆盖数据和执行时间
+func (s *Scheduler) Schedule(ctx context.Context, key string, payload []byte, at time.Time) error {
+	return scheduleScript.Run(ctx, rc, s.keys(), key, at.UnixMilli(), payload).Err()
+}
+
+// Reschedule 修改任务的执行时间，任务不存在时返回 false
+func (s *Scheduler) Reschedule(ctx context.Context, key string, at time.Time) (bool, error) {
+	n, err := rescheduleScript.Run(ctx, rc, s.keys(), key, at.UnixMilli()).Int()
+	return n == 1, err
+}
+
+// Cancel 取消任务，任务不存在时返回 false
+func (s *Scheduler) Cancel(ctx context.Context, key string) (bool, error) {
+	n, err := cancelScript.Run(ctx, rc, s.keys(), key).Int()
+	return n == 1, err
+}
+
+// Run 轮询执行到期的任务，直到 ctx 结束
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runOnce(ctx)
+		}
+	}
+}
+
+// runOnce 领取并执行一批到期的任务
+func (s *Scheduler) runOnce(ctx context.Context) {
+	// 服务退出中不再领取任务，进行中的一批执行完再退出
+	if !lifecycle.Begin() {
+		return
+	}
+	defer lifecycle.End()
+	now := time.Now()
+	lease := strconv.FormatInt(now.Add(s.visibility).UnixMilli(), 10)
+	res, err := claimScript.Run(ctx, rc, s.keys(), now.UnixMilli(), lease, s.batch).Slice()
+	if err != nil {
+		zap.L().Error("claim delay jobs failed", zap.String("key", s.pending), zap.Error(err))
+		return
+	}
+	for i := 0; i+2 < len(res); i += 3 {
+		key, _ := res[i].(string)
+		payload, _ := res[i+2].(string)
+		s.runJob(ctx, key, []byte(payload), lease)
+	}
+}
+
+// runJob 续期后执行一个任务，lease 是领取时的可见性超时时间
+func (s *Scheduler) runJob(ctx context.Context, key string, payload []byte, lease string) {
+	renewed := strconv.FormatInt(time.Now().Add(s.visibility).UnixMilli(), 10)
+	ok, err := renewScript.Run(ctx, rc, s.keys(), key, lease, renewed).Int()
+	if err != nil {
+		// 没有续期的任务在可见性超时后会再执行
+		zap.L().Warn("renew delay job failed", zap.String("job", key), zap.Error(err))
+		return
+	}
+	if ok == 0 {
+		// 等待期间任务已经不属于这次领取，交给新的持有者处理
+		zap.L().Info("delay job lease lost, skip", zap.String("job", key))
+		return
+	}
+	lease = renewed
+	jobCtx, cancel := context.WithTimeout(ctx, s.visibility)
+	err = s.handler(jobCtx, key, payload)
+	cancel()
+	if errors.Is(err, errno.ErrPoisonMsg) {
+		err = s.saveDeadLetter(ctx, key, payload, err)
+	}
+	if err == nil {
+		err = ackScript.Run(ctx, rc, s.keys(), key, lease).Err()
+		if err != nil {
+			// 没有确认的任务在可见性超时后会再执行一次
+			zap.L().Warn("ack delay job failed", zap.String("job", key), zap.Error(err))
+		}
+		return
+	}
+	zap.L().Warn("run delay job failed, retry later", zap.String("job", key), zap.Error(err))
+	retryAt := time.Now().Add(s.retryDelay).UnixMilli()
+	if err = nackScript.Run(ctx, rc, s.keys(), key, lease, retryAt).Err(); err != nil {
+		zap.L().Warn("nack delay job failed", zap.String("job", key), zap.Error(err))
+	}
+}
+
+// saveDeadLetter 保存无法处理的任务，保存成功后任务按执行成功确认
+func (s *Scheduler) saveDeadLetter(ctx context.Context, key string, payload []byte, reason error) error {
+	if s.deadLetter == nil {
+		zap.L().Error("drop poison delay job", zap.String("job", key), zap.ByteString("payload", payload), zap.Error(reason))
+		return nil
+	}
+	if err := s.deadLetter(ctx, key, payload, reason); err != nil {
+		return fmt.Errorf("save dead letter failed: %w", err)
+	}
+	zap.L().Warn("poison delay job saved to dead letter", zap.String("job", key), zap.Error(reason))
+	return nil
+}
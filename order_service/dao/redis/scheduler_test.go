@@ -0,0 +1,102 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/idMiFeng/common/errno"
+	"github.com/idMiFeng/order_service/config"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func setupRedis(t *testing.T) *miniredis.Miniredis {
+	mr := miniredis.RunT(t)
+	c := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { c.Close() })
+	SetClient(c)
+	return mr
+}
+
+// 无法处理的任务保存到死信后删除，不再重试
+func TestPoisonJobToDeadLetter(t *testing.T) {
+	setupRedis(t)
+	ctx := context.Background()
+	s := NewScheduler("test_poison", func(ctx context.Context, key string, payload []byte) error {
+		return fmt.Errorf("%w: bad payload", errno.ErrPoisonMsg)
+	}, &config.SchedulerConfig{RetryDelay: time.Millisecond})
+	var saved []string
+	s.SetDeadLetter(func(ctx context.Context, key string, payload []byte, reason error) error {
+		saved = append(saved, key+"="+string(payload))
+		return nil
+	})
+	if err := s.Schedule(ctx, "1", []byte("{"), time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	s.runOnce(ctx)
+	if len(saved) != 1 || saved[0] != "1={" {
+		t.Fatalf("dead letter = %v, want [1={]", saved)
+	}
+	if ok, _ := s.Cancel(ctx, "1"); ok {
+		t.Fatal("poison job should be removed after saved to dead letter")
+	}
+}
+
+// 保存死信失败时任务稍后重试
+func TestPoisonJobRetryWhenSaveFailed(t *testing.T) {
+	setupRedis(t)
+	ctx := context.Background()
+	s := NewScheduler("test_poison_retry", func(ctx context.Context, key string, payload []byte) error {
+		return errno.ErrPoisonMsg
+	}, nil)
+	s.SetDeadLetter(func(ctx context.Context, key string, payload []byte, reason error) error {
+		return errors.New("db down")
+	})
+	if err := s.Schedule(ctx, "1", []byte("{"), time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	s.runOnce(ctx)
+	n, err := rc.ZCard(ctx, s.pending).Result()
+	if err != nil || n != 1 {
+		t.Fatalf("pending = %d, %v, want 1", n, err)
+	}
+}
+
+// 批量中靠后的任务开始执行前续期，租约已经被其他副本领取时跳过，不会重复执行
+func TestRenewLeaseBeforeEachJob(t *testing.T) {
+	setupRedis(t)
+	ctx := context.Background()
+	cfg := &config.SchedulerConfig{Visibility: 200 * time.Millisecond}
+	var other *Scheduler
+	var runs []string
+	h := func(ctx context.Context, key string, payload []byte) error {
+		runs = append(runs, key)
+		if key == "1" {
+			// 第一个任务执行超过可见性超时，期间其他副本轮询，领取了超时的两个任务
+			time.Sleep(300 * time.Millisecond)
+			other.runOnce(context.Background())
+		}
+		return nil
+	}
+	s := NewScheduler("test_renew", h, cfg)
+	other = NewScheduler("test_renew", func(ctx context.Context, key string, payload []byte) error {
+		runs = append(runs, "other:"+key)
+		return nil
+	}, cfg)
+	at := time.Now().Add(-time.Second)
+	for _, key := range []string{"1", "2"} {
+		if err := s.Schedule(ctx, key, []byte(key), at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.runOnce(ctx)
+	// 任务2已经由其他副本领取执行，原副本续期失败后跳过
+	want := []string{"1", "other:1", "other:2"}
+	if fmt.Sprint(runs) != fmt.Sprint(want) {
+		t.Fatalf("runs = %v, want %v", runs, want)
+	}
+}
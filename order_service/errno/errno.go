@@ -1,9 +1,19 @@
 package errno
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/idMiFeng/common/errno"
+)
 
 var (
-	ErrQueryFailed = errors.New("query db failed")
+	// 通用错误定义在common/errno
+	ErrQueryFailed = errno.ErrQueryFailed
+	ErrPoisonMsg   = errno.ErrPoisonMsg
 
-	ErrUnderstock = errors.New("understock")
+	ErrOrderStatusChanged  = errors.New("order status changed")     // 订单状态已被修改
+	ErrOrderStatusTransit  = errors.New("invalid order transition") // 订单状态不允许这样流转
+	ErrRefundStatusChanged = errors.New("refund status changed")    // 售后单状态已被修改
+	ErrOutboxRelayed       = errors.New("outbox event relayed")     // 本地消息已经被中继取走
+	ErrSagaLost            = errors.New("saga claimed by others")   // saga 实例已经被其他执行者抢占
 )
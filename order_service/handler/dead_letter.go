@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"context"
+
+	deadletterv1 "github.com/idMiFeng/api/shop/deadletter/v1"
+	"github.com/idMiFeng/common/broker"
+	"github.com/idMiFeng/common/deadletter"
+	"github.com/idMiFeng/common/logger"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// DeadLetterhandle 保存死信topic中的消息
+func DeadLetterhandle(ctx context.Context, msg *broker.Message) error {
+	return deadletter.Save(ctx, msg)
+}
+
+// ListDeadLetter 查询死信消息
+func (s *OrderSrv) ListDeadLetter(ctx context.Context, req *deadletterv1.ListDeadLetterReq) (*deadletterv1.DeadLetterList, error) {
+	resp, err := deadletter.List(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("deadletter.List failed", zap.Error(err))
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ReplayDeadLetter 把死信消息重新投递到原始topic
+func (s *OrderSrv) ReplayDeadLetter(ctx context.Context, req *deadletterv1.ReplayDeadLetterReq) (*emptypb.Empty, error) {
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
+	}
+	err := deadletter.Replay(ctx, req.GetId())
+	if err != nil {
+		logger.FromContext(ctx).Error("deadletter.Replay failed", zap.Int64("id", req.GetId()), zap.Error(err))
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -4,15 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	orderv1 "github.com/idMiFeng/api/shop/order/v1"
+	"github.com/idMiFeng/common/broker"
+	"github.com/idMiFeng/common/deadletter"
+	"github.com/idMiFeng/common/logger"
 	"github.com/idMiFeng/order_service/biz/order"
+	"github.com/idMiFeng/order_service/biz/pay"
+	"github.com/idMiFeng/order_service/biz/refund"
 	"github.com/idMiFeng/order_service/config"
-	"github.com/idMiFeng/order_service/dao/mq"
-	"github.com/idMiFeng/order_service/dao/mysql"
+	"github.com/idMiFeng/order_service/errno"
 	"github.com/idMiFeng/order_service/model"
-	"github.com/idMiFeng/order_service/proto"
 
-	"github.com/apache/rocketmq-client-go/v2/consumer"
-	"github.com/apache/rocketmq-client-go/v2/primitive"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -20,61 +22,206 @@ import (
 )
 
 type OrderSrv struct {
-	proto.UnimplementedOrderServer
+	orderv1.UnimplementedOrderServer
 }
 
 // CreateOrder 创建订单
 // 生成订单号 查询商品信息（营销中心算价） 扣库存 生成支付信息 调用收货地址 通知商家
 // 简化版：生成订单号 查询商品信息 扣库存
 // 1. 生成订单号 2.查询商品信息 3.扣库存
-func (s *OrderSrv) CreateOrder(ctx context.Context, req *proto.OrderReq) (*emptypb.Empty, error) {
-	fmt.Println("in CreateOrder ... ")
+func (s *OrderSrv) CreateOrder(ctx context.Context, req *orderv1.OrderReq) (*orderv1.OrderResp, error) {
+	logger.FromContext(ctx).Debug("CreateOrder", zap.Int64("user_id", req.GetUserId()))
 	// 参数处理
 	if req.GetUserId() <= 0 {
 		// 无效的请求
 		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
 	}
 	// 业务处理
-	err := order.Create(ctx, req)
+	resp, err := order.Create(ctx, req)
 	if err != nil {
-		zap.L().Error("order.Create failed", zap.Error(err))
-		return nil, status.Error(codes.Internal, "内部错误")
+		logger.FromContext(ctx).Error("order.Create failed", zap.Error(err))
+		return nil, err
 	}
 
+	return resp, nil
+}
+
+// OrderDetail 订单详情
+func (s *OrderSrv) OrderDetail(ctx context.Context, req *orderv1.OrderDetailReq) (*orderv1.OrderDetailInfo, error) {
+	// 参数处理
+	if req.GetOrderId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
+	}
+	// 业务处理
+	resp, err := order.Detail(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("order.Detail failed", zap.Int64("order_id", req.GetOrderId()), zap.Error(err))
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CancelOrder 用户取消待支付订单
+func (s *OrderSrv) CancelOrder(ctx context.Context, req *orderv1.CancelOrderReq) (*emptypb.Empty, error) {
+	// 参数处理
+	if req.GetOrderId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
+	}
+	// 业务处理
+	err := order.Cancel(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("order.Cancel failed", zap.Int64("order_id", req.GetOrderId()), zap.Error(err))
+		return nil, err
+	}
 	return &emptypb.Empty{}, nil
 }
 
-// OrderTimeouthandle 处理 订单超时事件
-func OrderTimeouthandle(ctx context.Context, msgs ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
-	for i := range msgs {
-		var data model.OrderGoodsStockInfo
-		err := json.Unmarshal(msgs[i].Body, &data)
-		if err != nil {
-			zap.L().Error("json.Unmarshal RollbackMsg failed", zap.Error(err))
-			continue
-		}
-		// 查订单表
-		// 1. 如果订单为已支付状态则不处理
-		// 2. 如果订单为未支付状态则发送一条回滚库存的消息
-		o, err := mysql.QueryOrder(ctx, data.OrderId)
-		if err != nil {
-			zap.L().Error("mysql.QueryOrder failed", zap.Error(err))
-			return consumer.ConsumeRetryLater, nil // 稍后再试
-		}
-		if o.OrderId == data.OrderId && o.Status == 100 { // 待支付
-			msg := &primitive.Message{
-				Topic: config.Conf.RocketMqConfig.Topic.StockRollback,
-				Body:  msgs[i].Body,
-			}
-			_, err = mq.Producer.SendSync(context.Background(), msg)
-			if err != nil {
-				zap.L().Error("send rollback msg failed", zap.Error(err))
-				return consumer.ConsumeRetryLater, nil // 稍后再试
-			}
-			// 发送回滚库存成功，将订单状态设置为关闭
-			o.Status = 300
-			mysql.UpdateOrder(ctx, o)
-		}
-	}
-	return consumer.ConsumeSuccess, nil
+// Pay 发起支付
+func (s *OrderSrv) Pay(ctx context.Context, req *orderv1.PayReq) (*orderv1.PayResp, error) {
+	// 参数处理
+	if req.GetOrderId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
+	}
+	// 业务处理
+	resp, err := pay.Pay(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("pay.Pay failed", zap.Int64("order_id", req.GetOrderId()), zap.Error(err))
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PayCallback 支付渠道回调，通过 gateway 的 /v1/pay/callback 暴露给支付渠道
+func (s *OrderSrv) PayCallback(ctx context.Context, req *orderv1.PayCallbackReq) (*emptypb.Empty, error) {
+	// 参数处理
+	if req.GetPayId() <= 0 || len(req.GetSign()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
+	}
+	// 业务处理
+	err := pay.Callback(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("pay.Callback failed", zap.Int64("pay_id", req.GetPayId()), zap.Error(err))
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ApplyRefund 申请售后
+func (s *OrderSrv) ApplyRefund(ctx context.Context, req *orderv1.ApplyRefundReq) (*orderv1.RefundInfo, error) {
+	// 参数处理
+	if req.GetOrderId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
+	}
+	// 业务处理
+	resp, err := refund.Apply(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("refund.Apply failed", zap.Int64("order_id", req.GetOrderId()), zap.Error(err))
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AuditRefund 商家审核售后
+func (s *OrderSrv) AuditRefund(ctx context.Context, req *orderv1.AuditRefundReq) (*orderv1.RefundInfo, error) {
+	// 参数处理
+	if req.GetRefundId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
+	}
+	// 业务处理
+	resp, err := refund.Audit(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("refund.Audit failed", zap.Int64("refund_id", req.GetRefundId()), zap.Error(err))
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SubmitReturnLogistics 买家填写退货物流
+func (s *OrderSrv) SubmitReturnLogistics(ctx context.Context, req *orderv1.ReturnLogisticsReq) (*orderv1.RefundInfo, error) {
+	// 参数处理
+	if req.GetRefundId() <= 0 || req.GetUserId() <= 0 || len(req.GetTrackingNo()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
+	}
+	// 业务处理
+	resp, err := refund.SubmitLogistics(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("refund.SubmitLogistics failed", zap.Int64("refund_id", req.GetRefundId()), zap.Error(err))
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ConfirmReturnReceived 商家确认收到退货
+func (s *OrderSrv) ConfirmReturnReceived(ctx context.Context, req *orderv1.ConfirmReturnReq) (*orderv1.RefundInfo, error) {
+	// 参数处理
+	if req.GetRefundId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
+	}
+	// 业务处理
+	resp, err := refund.ConfirmReceived(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("refund.ConfirmReceived failed", zap.Int64("refund_id", req.GetRefundId()), zap.Error(err))
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RefundDetail 售后单详情
+func (s *OrderSrv) RefundDetail(ctx context.Context, req *orderv1.RefundDetailReq) (*orderv1.RefundInfo, error) {
+	// 参数处理
+	if req.GetRefundId() <= 0 || req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
+	}
+	// 业务处理
+	resp, err := refund.Detail(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("refund.Detail failed", zap.Int64("refund_id", req.GetRefundId()), zap.Error(err))
+		return nil, err
+	}
+	return resp, nil
+}
+
+// OrderTimeouthandle 处理 订单超时事件，返回错误时消息稍后重新投递
+func OrderTimeouthandle(ctx context.Context, msg *broker.Message) error {
+	var data model.OrderTimeoutInfo
+	err := json.Unmarshal(msg.Body, &data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errno.ErrPoisonMsg, err)
+	}
+	// 0. 没到支付截止时间的消息继续延迟
+	// 1. 如果订单为已支付状态则不处理
+	// 2. 如果订单为未支付状态则关闭订单并发送回滚库存的消息
+	err = order.HandleTimeout(ctx, &data)
+	if err != nil {
+		logger.FromContext(ctx).Error("order.HandleTimeout failed", zap.Int64("order_id", data.OrderId), zap.Error(err))
+		return err // 稍后再试
+	}
+	return nil
+}
+
+// OrderTimeoutJob 处理 Redis 延迟任务中的订单超时事件，返回错误时稍后重试
+func OrderTimeoutJob(ctx context.Context, key string, payload []byte) error {
+	var data model.OrderTimeoutInfo
+	err := json.Unmarshal(payload, &data)
+	if err != nil {
+		// 数据有问题的任务重试也没用，保存到死信
+		logger.FromContext(ctx).Error("json.Unmarshal OrderTimeoutJob failed", zap.String("job", key), zap.Error(err))
+		return fmt.Errorf("%w: %v", errno.ErrPoisonMsg, err)
+	}
+	err = order.HandleTimeout(ctx, &data)
+	if err != nil {
+		logger.FromContext(ctx).Error("order.HandleTimeout failed", zap.Int64("order_id", data.OrderId), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// OrderTimeoutDeadLetter 保存无法处理的订单超时任务
+// 原始topic记为支付超时的topic，重放时由 OrderTimeouthandle 处理
+func OrderTimeoutDeadLetter(ctx context.Context, key string, payload []byte, reason error) error {
+	msg := &broker.Message{Body: payload}
+	msg.WithProperty(broker.PropOriginTopic, config.Conf.RocketMqConfig.Topic.PayTimeOut)
+	msg.WithProperty(broker.PropOriginMsgId, "xx_order_timeout:"+key)
+	msg.WithProperty(broker.PropDeadReason, reason.Error())
+	return deadletter.Save(ctx, msg)
 }
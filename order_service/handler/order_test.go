@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	goodsv1 "github.com/idMiFeng/api/shop/goods/v1"
+	orderv1 "github.com/idMiFeng/api/shop/order/v1"
+	stockv1 "github.com/idMiFeng/api/shop/stock/v1"
+	"github.com/idMiFeng/common/broker"
+	"github.com/idMiFeng/order_service/biz/order"
+	"github.com/idMiFeng/order_service/config"
+	"github.com/idMiFeng/order_service/dao/mysql"
+	"github.com/idMiFeng/order_service/model"
+	"github.com/idMiFeng/order_service/rpc"
+	"github.com/idMiFeng/order_service/third_party/snowflake"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	topicPayTimeout    = "xx_pay_timeout"
+	topicStockRollback = "xx_stock_rollback"
+)
+
+type fakeGoods struct {
+	goodsv1.GoodsClient
+}
+
+func (fakeGoods) GetGoodsDetail(ctx context.Context, in *goodsv1.GetGoodsDetailReq, opts ...grpc.CallOption) (*goodsv1.GoodsDetail, error) {
+	return &goodsv1.GoodsDetail{GoodsId: in.GoodsId, Price: "100", MerchantId: 9}, nil
+}
+
+// fakeStock 记录预扣过库存的订单
+type fakeStock struct {
+	stockv1.StockClient
+	reduced chan *stockv1.ReserveReq
+	err     error // 不为nil时预扣返回该错误
+}
+
+func (f fakeStock) TryReserve(ctx context.Context, in *stockv1.ReserveReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	f.reduced <- in
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// testDB 当前测试使用的数据库
+var testDB *gorm.DB
+
+// setup 使用SQLite和内存消息中间件，返回库存服务收到的回滚消息
+func setup(t *testing.T) (chan *stockv1.ReserveReq, chan model.OrderGoodsStockInfo) {
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	t.Cleanup(func() { sqlDB.Close() })
+	if err = db.AutoMigrate(&model.Order{}, &model.OrderDetail{}, &model.OrderRequest{}, &model.Outbox{}); err != nil {
+		t.Fatal(err)
+	}
+	// 与建表语句一致，幂等键唯一
+	if err = db.Exec("CREATE UNIQUE INDEX uk_user_request ON xx_order_request(user_id, request_id)").Error; err != nil {
+		t.Fatal(err)
+	}
+	mysql.SetDB(db)
+	testDB = db
+	if err = snowflake.Init("", 1); err != nil {
+		t.Fatal(err)
+	}
+	config.Conf.RocketMqConfig = &config.RocketMqConfig{}
+	config.Conf.RocketMqConfig.Topic.PayTimeOut = topicPayTimeout
+	config.Conf.RocketMqConfig.Topic.StockRollback = topicStockRollback
+	config.Conf.OrderConfig = &config.OrderConfig{
+		PayTimeout: map[string]time.Duration{"normal": 50 * time.Millisecond},
+	}
+
+	reduced := make(chan *stockv1.ReserveReq, 1)
+	rpc.GoodsCli = fakeGoods{}
+	rpc.StockCli = fakeStock{reduced: reduced}
+
+	rollback := make(chan model.OrderGoodsStockInfo, 1)
+	m := broker.NewMemory(order.TxListener{})
+	m.Subscribe(topicPayTimeout, OrderTimeouthandle)
+	m.Subscribe(topicStockRollback, func(ctx context.Context, msg *broker.Message) error {
+		var data model.OrderGoodsStockInfo
+		if err := json.Unmarshal(msg.Body, &data); err != nil {
+			return err
+		}
+		rollback <- data
+		return nil
+	})
+	m.Start()
+	broker.MQ = m
+	t.Cleanup(func() { m.Shutdown() })
+	return reduced, rollback
+}
+
+// 创建订单后不支付，超时后订单关闭并投递库存回滚消息
+func TestCreateTimeoutRollback(t *testing.T) {
+	reduced, rollback := setup(t)
+
+	resp, err := (&OrderSrv{}).CreateOrder(context.Background(), &orderv1.OrderReq{GoodsId: 1, Num: 2, UserId: 7})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if resp.PayAmount != 200 {
+		t.Errorf("pay amount = %d, want 200", resp.PayAmount)
+	}
+	if r := <-reduced; r.OrderId != resp.OrderId || r.TxId != strconv.FormatInt(resp.OrderId, 10) || r.Num != 2 {
+		t.Errorf("reduced %v, want order %d num 2", r, resp.OrderId)
+	}
+
+	select {
+	case r := <-rollback:
+		want := model.OrderGoodsStockInfo{OrderId: resp.OrderId, GoodsId: 1, Num: 2}
+		if r != want {
+			t.Errorf("rollback %+v, want %+v", r, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no stock rollback after pay timeout")
+	}
+	o, err := mysql.QueryOrder(context.Background(), resp.OrderId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Status != model.OrderStatusClosed {
+		t.Errorf("order status = %d, want %d", o.Status, model.OrderStatusClosed)
+	}
+}
+
+// 订单在超时前已支付，超时消息到达时不关单也不回滚库存
+func TestPaidBeforeTimeout(t *testing.T) {
+	reduced, rollback := setup(t)
+	config.Conf.OrderConfig.PayTimeout["normal"] = 200 * time.Millisecond
+
+	resp, err := (&OrderSrv{}).CreateOrder(context.Background(), &orderv1.OrderReq{GoodsId: 1, Num: 1, UserId: 7})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	<-reduced
+	err = mysql.UpdateOrder(context.Background(), model.Order{OrderId: resp.OrderId, Status: model.OrderStatusPaid})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case r := <-rollback:
+		t.Fatalf("unexpected rollback %+v", r)
+	case <-time.After(500 * time.Millisecond):
+	}
+	o, err := mysql.QueryOrder(context.Background(), resp.OrderId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Status != model.OrderStatusPaid {
+		t.Errorf("order status = %d, want %d", o.Status, model.OrderStatusPaid)
+	}
+}
+
+// 预扣库存超时，结果不确定，创建订单失败并投递回滚消息，由库存服务按订单号回滚或者记录空回滚
+func TestReserveUncertainRollback(t *testing.T) {
+	reduced, rollback := setup(t)
+	rpc.StockCli = fakeStock{reduced: reduced, err: status.Error(codes.DeadlineExceeded, "timeout")}
+
+	_, err := (&OrderSrv{}).CreateOrder(context.Background(), &orderv1.OrderReq{GoodsId: 1, Num: 2, UserId: 7})
+	if err == nil {
+		t.Fatal("CreateOrder succeeded, want error")
+	}
+	r := <-reduced
+	select {
+	case got := <-rollback:
+		want := model.OrderGoodsStockInfo{OrderId: r.OrderId, GoodsId: 1, Num: 2}
+		if got != want {
+			t.Errorf("rollback %+v, want %+v", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no stock rollback after uncertain reserve")
+	}
+	if _, err = mysql.QueryOrder(context.Background(), r.OrderId); err != gorm.ErrRecordNotFound {
+		t.Errorf("QueryOrder = %v, want not found", err)
+	}
+}
+
+// 同一个幂等键重试时返回第一次创建的订单，不会再次预扣库存
+func TestCreateIdempotent(t *testing.T) {
+	reduced, _ := setup(t)
+	req := &orderv1.OrderReq{GoodsId: 1, Num: 1, UserId: 7, RequestId: "r1"}
+
+	first, err := (&OrderSrv{}).CreateOrder(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	<-reduced
+	second, err := (&OrderSrv{}).CreateOrder(context.Background(), req)
+	if err != nil {
+		t.Fatalf("retry CreateOrder: %v", err)
+	}
+	if second.OrderId != first.OrderId || second.PayAmount != first.PayAmount || second.Status != model.OrderStatusPending {
+		t.Errorf("retry = %+v, want %+v", second, first)
+	}
+	if len(reduced) != 0 {
+		t.Error("retry reserved stock again")
+	}
+	// 其他用户使用同样的幂等键不受影响
+	other, err := (&OrderSrv{}).CreateOrder(context.Background(), &orderv1.OrderReq{GoodsId: 1, Num: 1, UserId: 8, RequestId: "r1"})
+	if err != nil {
+		t.Fatalf("CreateOrder other user: %v", err)
+	}
+	<-reduced
+	if other.OrderId == first.OrderId {
+		t.Error("other user got the same order")
+	}
+}
+
+// 订单没有创建成功时删除幂等记录，同一个幂等键可以重试
+func TestCreateIdempotentRetryAfterFailure(t *testing.T) {
+	reduced, _ := setup(t)
+	rpc.StockCli = fakeStock{reduced: reduced, err: status.Error(codes.ResourceExhausted, "understock")}
+	req := &orderv1.OrderReq{GoodsId: 1, Num: 1, UserId: 7, RequestId: "r1"}
+
+	if _, err := (&OrderSrv{}).CreateOrder(context.Background(), req); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("CreateOrder = %v, want ResourceExhausted", err)
+	}
+	<-reduced
+	rpc.StockCli = fakeStock{reduced: reduced}
+	resp, err := (&OrderSrv{}).CreateOrder(context.Background(), req)
+	if err != nil {
+		t.Fatalf("retry CreateOrder: %v", err)
+	}
+	if r := <-reduced; r.OrderId != resp.OrderId {
+		t.Errorf("reserved order %d, want %d", r.OrderId, resp.OrderId)
+	}
+}
+
+// 本地消息表模式：订单创建成功时取消库存回滚的保护消息，预扣结果不确定时保留保护消息
+func TestCreateWithOutboxGuard(t *testing.T) {
+	reduced, _ := setup(t)
+	config.Conf.OrderConfig.TxMode = config.TxModeOutbox
+	ctx := context.Background()
+
+	resp, err := (&OrderSrv{}).CreateOrder(ctx, &orderv1.OrderReq{GoodsId: 1, Num: 1, UserId: 7})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	<-reduced
+	assertOutbox(t, strconv.FormatInt(resp.OrderId, 10), map[string]int32{
+		topicStockRollback: model.OutboxStatusCancelled,
+		topicPayTimeout:    model.OutboxStatusPending,
+	})
+
+	rpc.StockCli = fakeStock{reduced: reduced, err: status.Error(codes.DeadlineExceeded, "timeout")}
+	if _, err = (&OrderSrv{}).CreateOrder(ctx, &orderv1.OrderReq{GoodsId: 1, Num: 1, UserId: 7}); err == nil {
+		t.Fatal("CreateOrder succeeded, want error")
+	}
+	r := <-reduced
+	assertOutbox(t, strconv.FormatInt(r.OrderId, 10), map[string]int32{
+		topicStockRollback: model.OutboxStatusPending,
+	})
+}
+
+// assertOutbox 检查订单的本地消息，want 为每个topic的消息状态
+func assertOutbox(t *testing.T, key string, want map[string]int32) {
+	t.Helper()
+	var events []model.Outbox
+	if err := testDB.Where("sharding_key = ?", key).Find(&events).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != len(want) {
+		t.Fatalf("outbox events = %d, want %d", len(events), len(want))
+	}
+	for _, e := range events {
+		if s, ok := want[e.Topic]; !ok || e.Status != s {
+			t.Errorf("outbox %s status = %d, want %d", e.Topic, e.Status, s)
+		}
+	}
+}
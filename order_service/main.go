@@ -2,158 +2,106 @@ package main
 
 import (
 	"context"
-	"flag"
-	"fmt"
-	"log"
-	"net"
-	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
 
+	orderv1 "github.com/idMiFeng/api/shop/order/v1"
+	"github.com/idMiFeng/common/auth"
+	"github.com/idMiFeng/common/bootstrap"
+	"github.com/idMiFeng/common/broker"
+	"github.com/idMiFeng/common/healthcheck"
+	"github.com/idMiFeng/order_service/biz/order"
+	"github.com/idMiFeng/order_service/biz/outbox"
+	"github.com/idMiFeng/order_service/biz/refund"
+	"github.com/idMiFeng/order_service/biz/saga"
 	"github.com/idMiFeng/order_service/config"
-	"github.com/idMiFeng/order_service/dao/mq"
 	"github.com/idMiFeng/order_service/dao/mysql"
+	"github.com/idMiFeng/order_service/dao/redis"
 	"github.com/idMiFeng/order_service/handler"
-	"github.com/idMiFeng/order_service/logger"
-	"github.com/idMiFeng/order_service/proto"
-	"github.com/idMiFeng/order_service/registry"
 	"github.com/idMiFeng/order_service/rpc"
+	"github.com/idMiFeng/order_service/third_party/payment"
 	"github.com/idMiFeng/order_service/third_party/snowflake"
 
-	"github.com/apache/rocketmq-client-go/v2"
-	"github.com/apache/rocketmq-client-go/v2/consumer"
-	"github.com/apache/rocketmq-client-go/v2/primitive"
-	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime" // !!!!
 	"go.uber.org/zap"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/health"
-	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func main() {
-	// 可以把启动时的一些列操作放到 bootstap/init 包
-
-	var cfn string
-	// 0.从命令行获取可能的conf路径
-	flag.StringVar(&cfn, "conf", "./conf/config.yaml", "指定配置文件路径")
-	flag.Parse()
-	// 1. 加载配置文件
-	err := config.Init(cfn)
-	if err != nil {
-		panic(err) // 程序启动时加载配置文件失败直接退出
-	}
-	// 2. 加载日志
-	err = logger.Init(config.Conf.LogConfig, config.Conf.Mode)
-	if err != nil {
-		panic(err) // 程序启动时初始化日志模块失败直接退出
-	}
-	// 3. 初始化MySQL
-	err = mysql.Init(config.Conf.MySQLConfig)
-	if err != nil {
-		panic(err) // 程序启动时初始化MySQL失败直接退出
-	}
-	// 4. 初始化Consul
-	err = registry.Init(config.Conf.ConsulConfig.Addr)
-	if err != nil {
-		panic(err) // 程序启动时初始化注册中心失败直接退出
+	// 加载配置文件、日志，初始化注册中心
+	app := bootstrap.New(config.Conf)
+	// 初始化MySQL
+	bootstrap.Must(mysql.Init(config.Conf.MySQLConfig))
+	app.OnShutdown(mysql.Close)
+	app.AddCheck("mysql", mysql.Ping)
+	// 初始化RPC客户端，消费者停止后再关闭连接
+	conns, err := rpc.InitSrvClient()
+	bootstrap.Must(err)
+	for _, conn := range conns {
+		app.OnShutdown(conn.Close)
 	}
+	// 初始化snowflake
+	bootstrap.Must(snowflake.Init(config.Conf.StartTime, config.Conf.MachineID))
 
-	// 5.初始化RPC客户端
-	err = rpc.InitSrvClient()
-	if err != nil {
-		panic(err)
+	// 初始化rocketmq，创建订单使用事务消息
+	broker.MQ, err = broker.NewRocketMQ(config.Conf.RocketMqConfig.Broker(), order.TxListener{})
+	bootstrap.Must(err)
+	// 退出时先停止拉取消息，等进行中的消息处理完再关闭生产者
+	app.OnStop(broker.MQ.StopConsume)
+	app.OnShutdown(broker.MQ.Shutdown)
+	app.AddCheck("rocketmq", healthcheck.TCP(config.Conf.RocketMqConfig.Addr))
+	// 后台协程在退出时停止，要先于消费者和生产者关闭
+	bgCtx, stopBg := context.WithCancel(context.Background())
+	// 本地消息表模式下启动中继协程投递消息
+	if config.Conf.OrderConfig != nil && config.Conf.OrderConfig.TxMode == config.TxModeOutbox {
+		go outbox.NewRelay(broker.MQ, config.Conf.OutboxConfig).Run(bgCtx)
 	}
-
-	// 6. 初始化snowflake
-	err = snowflake.Init(config.Conf.StartTime, config.Conf.MachineID)
-	if err != nil {
-		panic(err)
+	// 支付超时使用Redis延迟任务，或者限流在Redis中计数时需要连接Redis
+	redisTimeout := config.Conf.OrderConfig != nil && config.Conf.OrderConfig.TimeoutMode == config.TimeoutModeRedis
+	if redisTimeout || (config.Conf.LimitConfig != nil && config.Conf.LimitConfig.Distributed()) {
+		bootstrap.Must(redis.Init(config.Conf.RedisConfig))
+		app.OnShutdown(redis.Close)
+		app.AddCheck("redis", redis.Ping)
 	}
-
-	// 7. 初始化rocketmq
-	err = mq.Init()
-	if err != nil {
-		panic(err)
+	if redisTimeout {
+		order.TimeoutScheduler = redis.NewScheduler("xx_order_timeout", handler.OrderTimeoutJob, config.Conf.SchedulerConfig)
+		order.TimeoutScheduler.SetDeadLetter(handler.OrderTimeoutDeadLetter)
+		go order.TimeoutScheduler.Run(bgCtx)
 	}
-	// 监听订单超时的消息
-	c, _ := rocketmq.NewPushConsumer(
-		consumer.WithGroupName("order_srv_1"),
-		consumer.WithNsResolver(primitive.NewPassthroughResolver([]string{"127.0.0.1:9876"})),
-	)
-	// 订阅topic
-	err = c.Subscribe("xx_pay_timeout", consumer.MessageSelector{}, handler.OrderTimeouthandle)
-	if err != nil {
-		fmt.Println(err.Error())
-	}
-	// Note: start after subscribe
-	err = c.Start()
-	if err != nil {
-		panic(err)
+	// 创建订单的限流和排队
+	if config.Conf.LimitConfig != nil {
+		order.Admission = order.NewGate(config.Conf.LimitConfig)
 	}
-	// 监听端口
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", config.Conf.Port))
-	if err != nil {
-		panic(err)
+	// saga编排模式下恢复未完成的saga
+	if config.Conf.OrderConfig != nil && config.Conf.OrderConfig.TxMode == config.TxModeSaga {
+		saga.Init(config.Conf.SagaConfig)
+		saga.Register(order.CreateOrderSaga)
+		go saga.Run(bgCtx)
 	}
-	// 创建gRPC服务
-	s := grpc.NewServer()
-	// 健康检查
-	grpc_health_v1.RegisterHealthServer(s, health.NewServer())
-	proto.RegisterOrderServer(s, &handler.OrderSrv{})
-	// 商品服务注册RPC服务
-	// 启动gRPC服务
-	go func() {
-		err = s.Serve(lis)
-		if err != nil {
-			panic(err)
-		}
-	}()
+	app.OnShutdown(func() error {
+		stopBg()
+		return nil
+	})
+	// 初始化支付渠道
+	bootstrap.Must(payment.Init(config.Conf.PaymentConfig))
 
-	// 注册服务到consul
-	registry.Reg.RegisterService(config.Conf.Name, config.Conf.IP, config.Conf.Port, nil)
-
-	zap.L().Info("service start...")
-
-	// Create a client connection to the gRPC server we just started
-	// This is where the gRPC-Gateway proxies the requests
-	conn, err := grpc.DialContext( // RPC客户端
-		context.Background(),
-		fmt.Sprintf("%s:%d", config.Conf.IP, config.Conf.Port),
-		grpc.WithBlock(),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	// 监听订单超时的消息
+	// 订阅topic，与创建订单时投递延迟消息的topic一致
+	err = broker.MQ.Subscribe(config.Conf.RocketMqConfig.Topic.PayTimeOut, handler.OrderTimeouthandle)
 	if err != nil {
-		log.Fatalln("Failed to dial server:", err)
+		zap.L().Error("subscribe pay timeout failed", zap.Error(err))
 	}
-
-	gwmux := runtime.NewServeMux()
-	// Register Greeter
-	err = proto.RegisterOrderHandler(context.Background(), gwmux, conn)
+	// 监听死信消息，保存后可以查看和重放
+	err = broker.MQ.Subscribe(config.Conf.RocketMqConfig.Topic.DeadLetter, handler.DeadLetterhandle)
 	if err != nil {
-		log.Fatalln("Failed to register gateway:", err)
+		zap.L().Error("subscribe dead letter failed", zap.Error(err))
 	}
+	// Note: start after subscribe
+	bootstrap.Must(broker.MQ.Start())
 
-	gwServer := &http.Server{
-		Addr:    ":8093",
-		Handler: gwmux,
-	}
+	// 商家只能操作自己商品的订单和售后单
+	auth.RegisterOwner("order", order.MerchantOf)
+	auth.RegisterOwner("refund", refund.MerchantOf)
 
-	zap.L().Info("Serving gRPC-Gateway on http://0.0.0.0:8093")
-	go func() {
-		err := gwServer.ListenAndServe()
-		if err != nil {
-			log.Printf("gwServer.ListenAndServe failed, err: %v", err)
-			return
-		}
-	}()
+	// 订单服务注册RPC服务
+	orderv1.RegisterOrderServer(app.Server, &handler.OrderSrv{})
+	app.RegisterGateway(orderv1.RegisterOrderHandler)
 
-	// 服务退出时要注销服务
-	quit := make(chan os.Signal)
-	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
-	<-quit // 正常会hang在此处
-	// 退出时注销服务
-	serviceId := fmt.Sprintf("%s-%s-%d", config.Conf.Name, config.Conf.IP, config.Conf.Port)
-	registry.Reg.Deregister(serviceId)
+	app.Run()
 }
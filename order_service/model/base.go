@@ -20,3 +20,9 @@ type OrderGoodsStockInfo struct {
 	GoodsId int64
 	Num     int64
 }
+
+// OrderTimeoutInfo 订单支付超时消息
+type OrderTimeoutInfo struct {
+	OrderId  int64
+	Deadline int64 // 支付截止时间（毫秒时间戳），没到期的消息会继续延迟投递
+}
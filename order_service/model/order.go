@@ -1,15 +1,23 @@
 package model
 
+import "time"
+
 // ORM
 // struct -> table
 
 type Order struct {
 	BaseModel // 嵌入默认的7个字段
 
-	OrderId   int64
-	UserId    int64
-	PayAmount int64
-	Status    int32
+	OrderId    int64
+	UserId     int64
+	OrderType  int32 // 订单类型，决定支付超时时间
+	PayAmount  int64
+	Status     int32
+	TradeId    string    // 支付渠道交易单号
+	PayChannel int32     // 支付方式
+	PayTime    time.Time `gorm:"default:CURRENT_TIMESTAMP"` // 支付时间
+
+	CancelReason string // 关闭原因
 
 	ReceiveAddress string
 	ReceiveName    string
@@ -3,12 +3,28 @@ package model
 type OrderDetail struct {
 	BaseModel // 嵌入默认的7个字段
 
-	OrderId int64
-	GoodsId int64
-	UserId  int64
-	Num     int64
+	OrderId    int64
+	GoodsId    int64
+	MerchantId int64 // 商品所属的商家
+	UserId     int64
+	Num        int64
+
+	Price     int64 // 下单时的单价（分），老订单为0
+	PayAmount int64 // 该商品的支付金额（分），即 单价*数量
 }
 
 func (OrderDetail) TableName() string {
 	return "xx_order_detail"
 }
+
+// SingleMerchant 订单商品所属的商家，商品属于多个商家或者商家未知时返回0
+func SingleMerchant(details []*OrderDetail) int64 {
+	var merchantId int64
+	for _, d := range details {
+		if d.MerchantId == 0 || (merchantId != 0 && d.MerchantId != merchantId) {
+			return 0
+		}
+		merchantId = d.MerchantId
+	}
+	return merchantId
+}
@@ -0,0 +1,16 @@
+package model
+
+// OrderRequest 创建订单请求的幂等记录
+// 同一个用户的同一个 request_id 只会创建一个订单
+type OrderRequest struct {
+	BaseModel // 嵌入默认的7个字段
+
+	UserId    int64
+	RequestId string
+	OrderId   int64
+}
+
+// TableName 声明表名
+func (OrderRequest) TableName() string {
+	return "xx_order_request"
+}
@@ -0,0 +1,45 @@
+package model
+
+// 订单类型
+const (
+	OrderTypeNormal    = 0 // 普通订单
+	OrderTypeFlashSale = 1 // 秒杀订单
+)
+
+// OrderTypeName 订单类型对应的配置名
+func OrderTypeName(orderType int32) string {
+	switch orderType {
+	case OrderTypeFlashSale:
+		return "flash_sale"
+	default:
+		return "normal"
+	}
+}
+
+// 订单状态
+const (
+	OrderStatusPending   = 100 // 创建订单/待支付
+	OrderStatusPaid      = 200 // 已支付
+	OrderStatusClosed    = 300 // 交易关闭
+	OrderStatusFinished  = 400 // 完成
+	OrderStatusAfterSale = 500 // 售后中
+	OrderStatusRefunded  = 600 // 已全额退款
+)
+
+// orderTransitions 订单状态机，key为当前状态，value为允许流转到的状态
+var orderTransitions = map[int32][]int32{
+	OrderStatusPending:   {OrderStatusPaid, OrderStatusClosed},
+	OrderStatusPaid:      {OrderStatusFinished, OrderStatusAfterSale},
+	OrderStatusFinished:  {OrderStatusAfterSale},
+	OrderStatusAfterSale: {OrderStatusPaid, OrderStatusFinished, OrderStatusRefunded},
+}
+
+// CanTransit 判断订单能否从 from 状态流转到 to 状态
+func CanTransit(from, to int32) bool {
+	for _, s := range orderTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// 本地消息状态
+const (
+	OutboxStatusPending   = 0 // 待投递
+	OutboxStatusSent      = 1 // 已投递
+	OutboxStatusDead      = 2 // 超过最大重试次数，需要人工处理
+	OutboxStatusCancelled = 3 // 已取消，不再投递
+)
+
+// Outbox 本地消息表，和业务数据在同一个事务中写入，由中继协程投递到消息队列
+type Outbox struct {
+	BaseModel // 嵌入默认的7个字段
+
+	Topic       string
+	ShardingKey string // 相同 key 的消息按写入顺序投递
+	Body        string
+	Properties  string    // json格式的消息属性
+	DeliverAt   time.Time // 期望消费者收到消息的时间，用于延迟消息
+	Status      int32
+	RetryCount  int32
+	NextRetryAt time.Time // 到期后才会被中继取走，取走时推迟作为租约
+	LastError   string
+}
+
+// TableName 声明表名
+func (Outbox) TableName() string {
+	return "xx_outbox"
+}
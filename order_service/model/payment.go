@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// 支付单状态
+const (
+	PayStatusPending = 0 // 待支付
+	PayStatusSuccess = 1 // 支付成功
+	PayStatusFailed  = 2 // 支付失败
+
+	PayStatusNeedRefund = 3 // 已付款，但订单已关闭或已被其他支付单支付，需要退款
+)
+
+// Payment 支付单，一个订单可以发起多次支付
+type Payment struct {
+	BaseModel // 嵌入默认的7个字段
+
+	PayId      int64
+	OrderId    int64
+	UserId     int64
+	PayAmount  int64
+	PayChannel int32
+	TradeId    string // 支付渠道交易单号
+	Status     int32
+	PayTime    time.Time `gorm:"default:CURRENT_TIMESTAMP"` // 支付时间
+}
+
+// TableName 声明表名
+func (Payment) TableName() string {
+	return "xx_payment"
+}
@@ -0,0 +1,54 @@
+package model
+
+// 退款类型
+const (
+	RefundTypeMoney = 1 // 仅退款
+	RefundTypeGoods = 2 // 退货退款
+)
+
+// 售后单状态
+const (
+	RefundStatusAudit     = 10 // 待商家审核
+	RefundStatusRejected  = 20 // 商家已拒绝
+	RefundStatusReturning = 30 // 待买家退货
+	RefundStatusReceiving = 40 // 买家已退货，待商家收货
+	RefundStatusRefunding = 50 // 退款中
+	RefundStatusSuccess   = 60 // 退款成功
+	RefundStatusFailed    = 70 // 退款失败
+	RefundStatusClosed    = 80 // 退款失败后商家关闭，订单恢复到申请售后前的状态
+)
+
+// Refund 售后单
+type Refund struct {
+	BaseModel // 嵌入默认的7个字段
+
+	RefundId     int64
+	OrderId      int64
+	UserId       int64
+	RefundType   int32
+	RefundAmount int64 // 退款金额（分）
+	Reason       string
+	Status       int32
+	OrderStatus  int32 // 申请售后前的订单状态，售后结束后据此恢复
+}
+
+// TableName 声明表名
+func (Refund) TableName() string {
+	return "xx_refund"
+}
+
+// RefundItem 售后单商品
+type RefundItem struct {
+	BaseModel // 嵌入默认的7个字段
+
+	RefundId     int64
+	OrderId      int64
+	GoodsId      int64
+	Num          int64
+	RefundAmount int64 // 退款金额（分）
+}
+
+// TableName 声明表名
+func (RefundItem) TableName() string {
+	return "xx_refund_item"
+}
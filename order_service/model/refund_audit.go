@@ -0,0 +1,22 @@
+package model
+
+// 商家审核结果
+const (
+	AuditApprove = 1 // 同意
+	AuditReject  = 2 // 拒绝
+)
+
+// RefundAudit 售后单审核记录
+type RefundAudit struct {
+	BaseModel // 嵌入默认的7个字段
+
+	RefundId   int64
+	OperatorId int64 // 审核人
+	Action     int32
+	Remark     string
+}
+
+// TableName 声明表名
+func (RefundAudit) TableName() string {
+	return "xx_refund_audit"
+}
@@ -0,0 +1,22 @@
+package model
+
+// 退货物流状态
+const (
+	LogisticsStatusShipped  = 1 // 买家已寄出
+	LogisticsStatusReceived = 2 // 商家已签收
+)
+
+// RefundLogistics 退货物流
+type RefundLogistics struct {
+	BaseModel // 嵌入默认的7个字段
+
+	RefundId   int64
+	Company    string // 物流公司
+	TrackingNo string // 运单号
+	Status     int32
+}
+
+// TableName 声明表名
+func (RefundLogistics) TableName() string {
+	return "xx_refund_logistics"
+}
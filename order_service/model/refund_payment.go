@@ -0,0 +1,20 @@
+package model
+
+// RefundPayment 退款执行记录，每次调用支付渠道退款记一条
+type RefundPayment struct {
+	BaseModel // 嵌入默认的7个字段
+
+	RefundId      int64
+	OrderId       int64
+	PayChannel    int32
+	TradeId       string // 原支付交易单号
+	RefundTradeId string // 渠道退款单号
+	RefundAmount  int64
+	Status        int32 // 同支付单状态：0处理中 1成功 2失败
+	ErrMsg        string
+}
+
+// TableName 声明表名
+func (RefundPayment) TableName() string {
+	return "xx_refund_payment"
+}
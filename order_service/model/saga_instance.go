@@ -0,0 +1,26 @@
+package model
+
+// saga 实例状态
+const (
+	SagaStatusRunning      = 0 // 正向执行中
+	SagaStatusCompensating = 1 // 补偿中
+	SagaStatusSucceeded    = 2 // 全部步骤执行成功
+	SagaStatusCompensated  = 3 // 补偿完成
+)
+
+// SagaInstance saga 实例，记录执行到哪一步和各步骤共享的数据，服务重启后据此恢复
+type SagaInstance struct {
+	BaseModel // 嵌入默认的7个字段
+
+	SagaId      int64
+	Name        string // saga 定义的名字
+	Status      int32
+	CurrentStep int32  // 正向执行时为下一个要执行的步骤，补偿时为下一个要补偿的步骤
+	Data        string // json格式的步骤数据
+	LastError   string
+}
+
+// TableName 声明表名
+func (SagaInstance) TableName() string {
+	return "xx_saga_instance"
+}
@@ -0,0 +1,31 @@
+package model
+
+// saga 步骤的操作类型
+const (
+	SagaActionForward    = 1 // 正向操作
+	SagaActionCompensate = 2 // 补偿操作
+)
+
+// saga 步骤执行结果
+const (
+	SagaStepSuccess = 1
+	SagaStepFailed  = 2
+)
+
+// SagaStepLog saga 步骤每次执行的日志
+type SagaStepLog struct {
+	BaseModel // 嵌入默认的7个字段
+
+	SagaId    int64
+	StepIndex int32
+	StepName  string
+	Action    int32
+	Status    int32
+	Retry     int32 // 第几次重试，第一次执行为0
+	Error     string
+}
+
+// TableName 声明表名
+func (SagaStepLog) TableName() string {
+	return "xx_saga_step_log"
+}
@@ -3,56 +3,78 @@ package rpc
 import (
 	"errors"
 	"fmt"
+
+	goodsv1 "github.com/idMiFeng/api/shop/goods/v1"
+	stockv1 "github.com/idMiFeng/api/shop/stock/v1"
+	"github.com/idMiFeng/common/client"
+	"github.com/idMiFeng/common/interceptor"
+	"github.com/idMiFeng/common/registry"
 	"github.com/idMiFeng/order_service/config"
-	"github.com/idMiFeng/order_service/proto"
 
-	_ "github.com/mbobakov/grpc-consul-resolver"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-// 正常来说应该在请求的时候访问注册中心得到ip和端口注册rpc客户端调用服务，这里用全局变量来设置客户端，如果出现服务宕机，就不可用了
-const ()
-
 // 初始化其他服务的RPC客户端
 
 var (
-	GoodsCli proto.GoodsClient // 商品服务
-	StockCli proto.StockClient // 库存服务
+	GoodsCli goodsv1.GoodsClient // 商品服务
+	StockCli stockv1.StockClient // 库存服务
 )
 
-func InitSrvClient() error {
+// InitSrvClient 连接商品服务和库存服务，返回的连接在退出时关闭
+func InitSrvClient() ([]*grpc.ClientConn, error) {
 	if len(config.Conf.GoodsService.Name) == 0 {
-		return errors.New("invalid GoodsService.Name")
+		return nil, errors.New("invalid GoodsService.Name")
 	}
 	if len(config.Conf.StockService.Name) == 0 {
-		return errors.New("invalid StockService.Name")
+		return nil, errors.New("invalid StockService.Name")
+	}
+	// 通过注册中心实现服务发现，resolver监听服务实例的变化，实例上下线后自动更新连接
+	opts, err := dialOptions(goodsv1.Goods_ServiceDesc.ServiceName, config.Conf.GoodsService.ClientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("goods_srv client config: %w", err)
 	}
-	// consul实现服务发现
-	// 程序启动的时候请求consul获取一个可以用的商品服务地址
 	goodsConn, err := grpc.Dial(
-		fmt.Sprintf("consul://%s/%s?wait=14s", config.Conf.ConsulConfig.Addr, config.Conf.GoodsService.Name),
-		// 指定round_robin策略
-		grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy": "round_robin"}`),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		registry.Target(config.Conf.GoodsService.Name),
+		opts...,
 	)
 	if err != nil {
-		fmt.Printf("dial goods_srv failed, err:%v\n", err)
-		return err
+		zap.L().Error("dial goods_srv failed", zap.Error(err))
+		return nil, err
 	}
-	GoodsCli = proto.NewGoodsClient(goodsConn)
+	GoodsCli = goodsv1.NewGoodsClient(goodsConn)
 
+	opts, err = dialOptions(stockv1.Stock_ServiceDesc.ServiceName, config.Conf.StockService.ClientConfig)
+	if err != nil {
+		goodsConn.Close()
+		return nil, fmt.Errorf("stock_srv client config: %w", err)
+	}
 	stockConn, err := grpc.Dial(
-		// consul服务
-		fmt.Sprintf("consul://%s/%s?wait=14s", config.Conf.ConsulConfig.Addr, config.Conf.StockService.Name),
-		// 指定round_robin策略
-		grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy": "round_robin"}`),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		registry.Target(config.Conf.StockService.Name),
+		opts...,
 	)
 	if err != nil {
-		fmt.Printf("dial stock_srv failed, err:%v\n", err)
-		return err
+		zap.L().Error("dial stock_srv failed", zap.Error(err))
+		goodsConn.Close()
+		return nil, err
+	}
+	StockCli = stockv1.NewStockClient(stockConn)
+	return []*grpc.ClientConn{goodsConn, stockConn}, nil
+}
+
+// dialOptions 连接其他服务的公共选项，service 为proto中的服务全名
+func dialOptions(service string, cfg *config.ClientConfig) ([]grpc.DialOption, error) {
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+	// 传递请求id，记录调用耗时
+	opts = append(opts, interceptor.DialOptions()...)
+	// round_robin负载均衡，按配置设置超时、重试、对冲和熔断
+	policy, err := client.DialOptions(service, cfg)
+	if err != nil {
+		return nil, err
 	}
-	StockCli = proto.NewStockClient(stockConn)
-	return nil
+	return append(opts, policy...), nil
 }
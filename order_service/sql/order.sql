@@ -9,11 +9,13 @@ CREATE TABLE `xx_order`(
 
                         `user_id` BIGINT(20) UNSIGNED NOT NULL COMMENT '用户id',
                         `order_id` BIGINT(20) UNSIGNED NOT NULL COMMENT '订单id',
+                        `order_type` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '订单类型：0普通 1秒杀',
                         `trade_id` VARCHAR(128) NOT NULL DEFAULT '' COMMENT '交易单号',
                         `pay_channel` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '支付方式',
-                        `status` INT UNSIGNED NOT NULL DEFAULT '0' COMMENT '订单状态:100创建订单/待支付 200已支付 300交易关闭 400完成',
+                        `status` INT UNSIGNED NOT NULL DEFAULT '0' COMMENT '订单状态:100创建订单/待支付 200已支付 300交易关闭 400完成 500售后中 600已全额退款',
                         `pay_amount` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '支付金额（分）',
                         `pay_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '支付时间',
+                        `cancel_reason` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '关闭原因',
 
                         `receive_address` VARCHAR(128) NOT NULL DEFAULT '' COMMENT '收货地址',
                         `receive_name` VARCHAR(128) NOT NULL DEFAULT '' COMMENT '收货人',
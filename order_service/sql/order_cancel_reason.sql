@@ -0,0 +1,4 @@
+-- 订单的关闭原因：用户取消、支付超时、创建失败，老订单为空
+ALTER TABLE `xx_order`
+    ADD COLUMN `cancel_reason` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '关闭原因' AFTER `pay_time`,
+    MODIFY COLUMN `status` INT UNSIGNED NOT NULL DEFAULT '0' COMMENT '订单状态:100创建订单/待支付 200已支付 300交易关闭 400完成 500售后中 600已全额退款';
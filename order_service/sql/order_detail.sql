@@ -10,6 +10,7 @@ CREATE TABLE `xx_order_detail`(
                                  `user_id` BIGINT(20) UNSIGNED NOT NULL COMMENT '用户id',
                                  `order_id` BIGINT(20) UNSIGNED NOT NULL COMMENT '订单id',
                                  `goods_id` BIGINT(20) UNSIGNED NOT NULL COMMENT '商品id',
+                                 `merchant_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '商品所属的商家id',
 
                                  `title` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '名称',
                                  `market_price` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '市场价/划线价（分）',
@@ -0,0 +1,9 @@
+-- 订单商品所属的商家，商家只能查看自己商品的订单和审核售后
+ALTER TABLE `xx_order_detail`
+    ADD COLUMN `merchant_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '商品所属的商家id' AFTER `goods_id`;
+
+-- 按商品回填老订单的商家，需要先执行 goods_service/sql/goods_merchant_id.sql 并回填商品的商家
+-- 订单表和商品表在同一个库中，回填不到的订单商品保持0，商家无法查看
+UPDATE `xx_order_detail` d JOIN `xx_goods` g ON d.`goods_id` = g.`goods_id`
+SET d.`merchant_id` = g.`merchant_id`
+WHERE d.`merchant_id` = 0 AND g.`merchant_id` != 0;
@@ -0,0 +1,3 @@
+-- 订单类型，按下单时商品的售卖方式决定支付超时等规则，老订单都是普通订单
+ALTER TABLE `xx_order`
+    ADD COLUMN `order_type` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '订单类型：0普通 1秒杀' AFTER `order_id`;
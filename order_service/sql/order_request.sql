@@ -0,0 +1,17 @@
+CREATE TABLE `xx_order_request`(
+                        `id` BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '主键',
+                        `create_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+                        `create_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                        `update_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+                        `update_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                        `version` SMALLINT(5) UNSIGNED NOT NULL DEFAULT '0' COMMENT '乐观锁版本号',
+                        `is_del` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '是否删除：0正常1删除',
+
+                        `user_id` BIGINT(20) UNSIGNED NOT NULL COMMENT '用户id',
+                        `request_id` VARCHAR(64) NOT NULL COMMENT '幂等键',
+                        `order_id` BIGINT(20) UNSIGNED NOT NULL COMMENT '订单id',
+
+                        UNIQUE (user_id, request_id),
+                        INDEX (order_id),
+                        INDEX (is_del)
+)ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COMMENT = '创建订单幂等表';
\ No newline at end of file
@@ -0,0 +1,23 @@
+CREATE TABLE `xx_outbox`(
+                        `id` BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '主键',
+                        `create_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+                        `create_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                        `update_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+                        `update_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                        `version` SMALLINT(5) UNSIGNED NOT NULL DEFAULT '0' COMMENT '乐观锁版本号',
+                        `is_del` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '是否删除：0正常1删除',
+
+                        `topic` VARCHAR(128) NOT NULL DEFAULT '' COMMENT '消息topic',
+                        `sharding_key` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '顺序投递的key',
+                        `body` TEXT NOT NULL COMMENT '消息体',
+                        `properties` VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '消息属性（json）',
+                        `deliver_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '期望送达时间',
+                        `status` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '状态：0待投递 1已投递 2投递失败 3已取消',
+                        `retry_count` INT UNSIGNED NOT NULL DEFAULT '0' COMMENT '重试次数',
+                        `next_retry_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '下次投递时间，被中继取走时为租约到期时间',
+                        `last_error` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '最后一次投递失败原因',
+
+                        INDEX (status, next_retry_at),
+                        INDEX (sharding_key, status, id),
+                        INDEX (is_del)
+)ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COMMENT = '本地消息表';
\ No newline at end of file
@@ -0,0 +1,23 @@
+CREATE TABLE `xx_payment`(
+                        `id` BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '主键',
+                        `create_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+                        `create_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                        `update_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+                        `update_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                        `version` SMALLINT(5) UNSIGNED NOT NULL DEFAULT '0' COMMENT '乐观锁版本号',
+                        `is_del` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '是否删除：0正常1删除',
+
+                        `pay_id` BIGINT(20) UNSIGNED NOT NULL COMMENT '支付单id',
+                        `order_id` BIGINT(20) UNSIGNED NOT NULL COMMENT '订单id',
+                        `user_id` BIGINT(20) UNSIGNED NOT NULL COMMENT '用户id',
+                        `pay_amount` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '支付金额（分）',
+                        `pay_channel` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '支付方式',
+                        `trade_id` VARCHAR(128) NOT NULL DEFAULT '' COMMENT '交易单号',
+                        `status` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '支付状态：0待支付 1成功 2失败 3已付款待退款',
+                        `pay_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '支付时间',
+
+                        UNIQUE (pay_id),
+                        INDEX (order_id),
+                        INDEX (trade_id),
+                        INDEX (is_del)
+)ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COMMENT = '支付单表';
\ No newline at end of file
@@ -0,0 +1,23 @@
+CREATE TABLE `xx_refund`(
+                        `id` BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '主键',
+                        `create_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+                        `create_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                        `update_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+                        `update_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                        `version` SMALLINT(5) UNSIGNED NOT NULL DEFAULT '0' COMMENT '乐观锁版本号',
+                        `is_del` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '是否删除：0正常1删除',
+
+                        `refund_id` BIGINT(20) UNSIGNED NOT NULL COMMENT '售后单id',
+                        `order_id` BIGINT(20) UNSIGNED NOT NULL COMMENT '订单id',
+                        `user_id` BIGINT(20) UNSIGNED NOT NULL COMMENT '用户id',
+                        `refund_type` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '退款类型：1仅退款 2退货退款',
+                        `refund_amount` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '退款金额（分）',
+                        `reason` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '退款原因',
+                        `status` INT UNSIGNED NOT NULL DEFAULT '0' COMMENT '售后状态:10待审核 20已拒绝 30待退货 40待收货 50退款中 60退款成功 70退款失败 80已关闭',
+                        `order_status` INT UNSIGNED NOT NULL DEFAULT '0' COMMENT '申请售后前的订单状态',
+
+                        UNIQUE (refund_id),
+                        INDEX (order_id),
+                        INDEX (user_id),
+                        INDEX (is_del)
+)ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COMMENT = '售后单表';
\ No newline at end of file
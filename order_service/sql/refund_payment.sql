@@ -0,0 +1,21 @@
+CREATE TABLE `xx_refund_payment`(
+                        `id` BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '主键',
+                        `create_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+                        `create_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                        `update_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+                        `update_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                        `version` SMALLINT(5) UNSIGNED NOT NULL DEFAULT '0' COMMENT '乐观锁版本号',
+                        `is_del` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '是否删除：0正常1删除',
+
+                        `refund_id` BIGINT(20) UNSIGNED NOT NULL COMMENT '售后单id',
+                        `order_id` BIGINT(20) UNSIGNED NOT NULL COMMENT '订单id',
+                        `pay_channel` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '支付方式',
+                        `trade_id` VARCHAR(128) NOT NULL DEFAULT '' COMMENT '原支付交易单号',
+                        `refund_trade_id` VARCHAR(128) NOT NULL DEFAULT '' COMMENT '渠道退款单号',
+                        `refund_amount` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '退款金额（分）',
+                        `status` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '退款状态：0处理中 1成功 2失败',
+                        `err_msg` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '失败原因',
+
+                        INDEX (refund_id),
+                        INDEX (is_del)
+)ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COMMENT = '退款执行记录表';
\ No newline at end of file
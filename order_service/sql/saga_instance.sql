@@ -0,0 +1,20 @@
+CREATE TABLE `xx_saga_instance`(
+                        `id` BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '主键',
+                        `create_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+                        `create_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                        `update_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+                        `update_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                        `version` SMALLINT(5) UNSIGNED NOT NULL DEFAULT '0' COMMENT '乐观锁版本号',
+                        `is_del` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '是否删除：0正常1删除',
+
+                        `saga_id` BIGINT(20) UNSIGNED NOT NULL COMMENT 'saga id',
+                        `name` VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'saga 定义的名字',
+                        `status` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '状态：0执行中 1补偿中 2成功 3补偿完成',
+                        `current_step` INT NOT NULL DEFAULT '0' COMMENT '当前步骤',
+                        `data` TEXT NOT NULL COMMENT '步骤数据（json）',
+                        `last_error` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '最后一次失败原因',
+
+                        UNIQUE (saga_id),
+                        INDEX (status, update_at),
+                        INDEX (is_del)
+)ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COMMENT = 'saga实例表';
\ No newline at end of file
@@ -0,0 +1,20 @@
+CREATE TABLE `xx_saga_step_log`(
+                        `id` BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '主键',
+                        `create_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+                        `create_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                        `update_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+                        `update_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                        `version` SMALLINT(5) UNSIGNED NOT NULL DEFAULT '0' COMMENT '乐观锁版本号',
+                        `is_del` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '是否删除：0正常1删除',
+
+                        `saga_id` BIGINT(20) UNSIGNED NOT NULL COMMENT 'saga id',
+                        `step_index` INT UNSIGNED NOT NULL DEFAULT '0' COMMENT '步骤序号',
+                        `step_name` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '步骤名',
+                        `action` tinyint(4) UNSIGNED NOT NULL DEFAULT '1' COMMENT '操作：1正向 2补偿',
+                        `status` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '结果：1成功 2失败',
+                        `retry` INT UNSIGNED NOT NULL DEFAULT '0' COMMENT '重试次数',
+                        `error` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '失败原因',
+
+                        INDEX (saga_id),
+                        INDEX (is_del)
+)ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COMMENT = 'saga步骤日志表';
\ No newline at end of file
@@ -0,0 +1,112 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/idMiFeng/order_service/config"
+
+	"go.uber.org/zap"
+)
+
+// ChannelMock 本地模拟支付渠道
+const ChannelMock = 1
+
+// mock 本地模拟支付渠道，用于联调
+// 发起支付后延迟一段时间回调 notify_url，回调结果由配置决定
+type mock struct {
+	secret    string
+	notifyURL string
+	result    string        // success/fail/none
+	delay     time.Duration // 回调延迟
+	refund    string        // 退款结果 success/fail
+	client    *http.Client
+}
+
+var _ Provider = (*mock)(nil)
+
+func newMock(cfg *config.PaymentConfig) *mock {
+	return &mock{
+		secret:    cfg.Secret,
+		notifyURL: cfg.NotifyURL,
+		result:    cfg.Mock.Result,
+		delay:     cfg.Mock.Delay,
+		refund:    cfg.Mock.RefundResult,
+		client:    &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (m *mock) Name() string {
+	return "mock"
+}
+
+func (m *mock) Channel() int32 {
+	return ChannelMock
+}
+
+// Pay 生成渠道交易单号，按配置异步回调支付结果
+func (m *mock) Pay(ctx context.Context, param *PayParam) (*PayResult, error) {
+	tradeId := fmt.Sprintf("MOCK%d", param.PayId)
+	n := &Notify{
+		OrderId:    param.OrderId,
+		PayId:      param.PayId,
+		TradeId:    tradeId,
+		PayAmount:  param.PayAmount,
+		PayChannel: ChannelMock,
+	}
+	switch m.result {
+	case "success":
+		n.Status = NotifySuccess
+		go m.notify(n)
+	case "fail":
+		n.Status = NotifyFailed
+		go m.notify(n)
+	default:
+		// 不回调，模拟用户未支付
+	}
+	return &PayResult{
+		TradeId: tradeId,
+		PayUrl:  fmt.Sprintf("mock://pay?tradeId=%s", tradeId),
+	}, nil
+}
+
+// Refund 按配置返回退款结果，模拟渠道同步退款
+func (m *mock) Refund(ctx context.Context, param *RefundParam) (*RefundResult, error) {
+	if m.refund == "fail" {
+		return nil, errors.New("mock refund failed")
+	}
+	return &RefundResult{RefundTradeId: fmt.Sprintf("MOCKR%d", param.RefundId)}, nil
+}
+
+func (m *mock) Verify(n *Notify, sign string) bool {
+	return VerifySign(m.secret, n.Params(), sign)
+}
+
+// notify 模拟渠道回调
+func (m *mock) notify(n *Notify) {
+	time.Sleep(m.delay)
+	n.Timestamp = time.Now().Unix()
+	body := map[string]interface{}{
+		"orderId":    n.OrderId,
+		"payId":      n.PayId,
+		"tradeId":    n.TradeId,
+		"payAmount":  n.PayAmount,
+		"payChannel": n.PayChannel,
+		"status":     n.Status,
+		"timestamp":  n.Timestamp,
+		"sign":       Sign(m.secret, n.Params()),
+	}
+	b, _ := json.Marshal(body)
+	resp, err := m.client.Post(m.notifyURL, "application/json", bytes.NewReader(b))
+	if err != nil {
+		zap.L().Error("mock pay notify failed", zap.Int64("pay_id", n.PayId), zap.Error(err))
+		return
+	}
+	defer resp.Body.Close()
+	zap.L().Info("mock pay notify done", zap.Int64("pay_id", n.PayId), zap.Int("code", resp.StatusCode))
+}
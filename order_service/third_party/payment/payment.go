@@ -0,0 +1,119 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/idMiFeng/order_service/config"
+)
+
+// 支付渠道的抽象，对接真实的支付渠道（支付宝、微信等）时实现 Provider 接口即可
+
+// 回调中的支付结果
+const (
+	NotifySuccess = 1 // 支付成功
+	NotifyFailed  = 2 // 支付失败
+)
+
+// PayParam 发起支付的参数
+type PayParam struct {
+	PayId     int64
+	OrderId   int64
+	PayAmount int64 // 支付金额（分）
+}
+
+// PayResult 发起支付的结果
+type PayResult struct {
+	TradeId string // 渠道交易单号
+	PayUrl  string // 拉起支付的地址
+}
+
+// RefundParam 退款参数
+type RefundParam struct {
+	RefundId     int64 // 售后单id，渠道侧用于退款幂等
+	OrderId      int64
+	TradeId      string // 原支付交易单号
+	PayAmount    int64  // 原支付金额（分）
+	RefundAmount int64  // 退款金额（分）
+}
+
+// RefundResult 退款结果
+type RefundResult struct {
+	RefundTradeId string // 渠道退款单号
+}
+
+// Notify 支付渠道的异步回调通知
+type Notify struct {
+	OrderId    int64
+	PayId      int64
+	TradeId    string
+	PayAmount  int64
+	PayChannel int32
+	Status     int32
+	Timestamp  int64
+}
+
+// Params 参与签名的字段
+func (n *Notify) Params() map[string]string {
+	return map[string]string{
+		"orderId":    strconv.FormatInt(n.OrderId, 10),
+		"payId":      strconv.FormatInt(n.PayId, 10),
+		"tradeId":    n.TradeId,
+		"payAmount":  strconv.FormatInt(n.PayAmount, 10),
+		"payChannel": strconv.FormatInt(int64(n.PayChannel), 10),
+		"status":     strconv.FormatInt(int64(n.Status), 10),
+		"timestamp":  strconv.FormatInt(n.Timestamp, 10),
+	}
+}
+
+// Provider 支付渠道
+type Provider interface {
+	// 渠道名称
+	Name() string
+	// 渠道编号，对应订单表的 pay_channel
+	Channel() int32
+	// 创建支付
+	Pay(ctx context.Context, param *PayParam) (*PayResult, error)
+	// 退款，同一个售后单重复调用只退一次
+	Refund(ctx context.Context, param *RefundParam) (*RefundResult, error)
+	// 校验回调签名
+	Verify(n *Notify, sign string) bool
+}
+
+var (
+	providers       = make(map[int32]Provider)
+	defaultProvider Provider
+)
+
+// Init 根据配置初始化支付渠道
+func Init(cfg *config.PaymentConfig) error {
+	if cfg == nil {
+		return errors.New("invalid payment config")
+	}
+	Register(newMock(cfg))
+	for _, p := range providers {
+		if p.Name() == cfg.Provider {
+			defaultProvider = p
+		}
+	}
+	if defaultProvider == nil {
+		return fmt.Errorf("unknown payment provider: %s", cfg.Provider)
+	}
+	return nil
+}
+
+// Register 注册支付渠道
+func Register(p Provider) {
+	providers[p.Channel()] = p
+}
+
+// Get 根据渠道编号获取支付渠道，channel为0时返回默认渠道
+func Get(channel int32) (Provider, bool) {
+	if channel == 0 {
+		return defaultProvider, defaultProvider != nil
+	}
+	p, ok := providers[channel]
+	return p, ok
+}
@@ -0,0 +1,35 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// Sign 按字段名排序拼接成 k1=v1&k2=v2 后计算 HMAC-SHA256 签名
+func Sign(secret string, params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte('&')
+		}
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(params[k])
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(b.String()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySign 校验签名
+func VerifySign(secret string, params map[string]string, sign string) bool {
+	return hmac.Equal([]byte(Sign(secret, params)), []byte(sign))
+}
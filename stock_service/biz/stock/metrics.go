@@ -0,0 +1,53 @@
+package stock
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/idMiFeng/stock_service/dao/mysql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// collectTimeout 每次采集查询库存的超时时间
+const collectTimeout = 3 * time.Second
+
+var (
+	availableDesc = prometheus.NewDesc("stock_available", "商品的可售库存", []string{"goods_id"}, nil)
+	lockedDesc    = prometheus.NewDesc("stock_locked", "商品的预扣库存", []string{"goods_id"}, nil)
+)
+
+// watchCollector 采集时查询关注商品的库存，不需要在扣减库存时更新
+type watchCollector struct {
+	goodsIds []int64
+}
+
+// WatchStock 注册关注商品的库存指标，goodsIds 为空时不注册
+func WatchStock(goodsIds []int64) error {
+	if len(goodsIds) == 0 {
+		return nil
+	}
+	return prometheus.Register(&watchCollector{goodsIds: goodsIds})
+}
+
+func (c *watchCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- availableDesc
+	ch <- lockedDesc
+}
+
+func (c *watchCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+	data, err := mysql.BatchGetStockByGoodsId(ctx, c.goodsIds)
+	if err != nil {
+		zap.L().Warn("collect stock metrics failed", zap.Error(err))
+		return
+	}
+	for _, s := range *data {
+		id := strconv.FormatInt(s.GoodsId, 10)
+		ch <- prometheus.MustNewConstMetric(availableDesc, prometheus.GaugeValue, float64(s.Num), id)
+		ch <- prometheus.MustNewConstMetric(lockedDesc, prometheus.GaugeValue, float64(s.Lock), id)
+	}
+}
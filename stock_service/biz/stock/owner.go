@@ -0,0 +1,24 @@
+package stock
+
+import (
+	"context"
+
+	goodsv1 "github.com/idMiFeng/api/shop/goods/v1"
+	"github.com/idMiFeng/stock_service/rpc"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MerchantOf 商品所属的商家，从商品服务查询，商品不存在时返回0
+// 注册到认证中，商家只能设置和查看自己商品的库存
+func MerchantOf(ctx context.Context, goodsId int64) (int64, error) {
+	goods, err := rpc.GoodsCli.GetGoodsDetail(ctx, &goodsv1.GetGoodsDetailReq{GoodsId: goodsId})
+	if status.Code(err) == codes.NotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return goods.GetMerchantId(), nil
+}
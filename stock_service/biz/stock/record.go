@@ -0,0 +1,52 @@
+package stock
+
+import (
+	"context"
+
+	stockv1 "github.com/idMiFeng/api/shop/stock/v1"
+	"github.com/idMiFeng/stock_service/dao/mysql"
+	"github.com/idMiFeng/stock_service/model"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListStockRecord 分页查询库存流水
+func ListStockRecord(ctx context.Context, req *stockv1.ListStockRecordReq) (*stockv1.StockRecordList, error) {
+	pageNum, pageSize := int(req.GetPageNum()), int(req.GetPageSize())
+	if pageNum <= 0 {
+		pageNum = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+	cond := model.StockRecord{
+		TxId:    req.GetTxId(),
+		OrderId: req.GetOrderId(),
+		GoodsId: req.GetGoodsId(),
+		Status:  req.GetStatus(),
+	}
+	data, total, err := mysql.ListStockRecord(ctx, cond, (pageNum-1)*pageSize, pageSize)
+	if err != nil {
+		zap.L().Error("mysql.ListStockRecord failed", zap.Error(err))
+		return nil, status.Error(codes.Internal, "query stock record failed")
+	}
+	resp := &stockv1.StockRecordList{
+		Total: int32(total),
+		Data:  make([]*stockv1.StockRecordInfo, 0, len(data)),
+	}
+	for _, d := range data {
+		resp.Data = append(resp.Data, &stockv1.StockRecordInfo{
+			Id:       int64(d.ID),
+			TxId:     d.TxId,
+			OrderId:  d.OrderId,
+			GoodsId:  d.GoodsId,
+			Num:      d.Num,
+			Status:   d.Status,
+			CreateAt: d.CreateAt.UnixMilli(),
+			UpdateAt: d.UpdateAt.UnixMilli(),
+		})
+	}
+	return resp, nil
+}
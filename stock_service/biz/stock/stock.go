@@ -2,25 +2,31 @@ package stock
 
 import (
 	"context"
+	stockv1 "github.com/idMiFeng/api/shop/stock/v1"
 	"github.com/idMiFeng/stock_service/dao/mysql"
-	"github.com/idMiFeng/stock_service/proto"
+	"github.com/idMiFeng/stock_service/model"
 )
 
 // biz层业务代码
 // biz -> dao
 
-func GetStockByGoodsId(ctx context.Context, goodsId int64) (*proto.GoodsStockInfo, error) {
+func GetStockByGoodsId(ctx context.Context, goodsId int64) (*stockv1.GoodsStockInfo, error) {
 	// 先查MySQL数据库
 	data, err := mysql.GetStockByGoodsId(ctx, goodsId)
 	if err != nil {
 		return nil, err
 	}
 	// 拼装数据
-	return &proto.GoodsStockInfo{GoodsId: goodsId, Num: data.Num}, nil
+	return &stockv1.GoodsStockInfo{GoodsId: goodsId, Num: data.Num}, nil
+}
+
+// SetStock 设置库存
+func SetStock(ctx context.Context, goodsId, num int64) error {
+	return mysql.SetStock(ctx, goodsId, num)
 }
 
 // 批量查询库存
-func BatchGetStockByGoodsId(ctx context.Context, req *proto.StockInfoList) (*proto.StockInfoList, error) {
+func BatchGetStockByGoodsId(ctx context.Context, req *stockv1.StockInfoList) (*stockv1.StockInfoList, error) {
 	goodsIds := make([]int64, 0)
 	for _, stockInfo := range req.Data {
 		// 获取每个 *GoodsStockInfo 中的 GoodsId 字段并添加到切片中
@@ -31,21 +37,21 @@ func BatchGetStockByGoodsId(ctx context.Context, req *proto.StockInfoList) (*pro
 	if err != nil {
 		return nil, err
 	}
-	data := make([]*proto.GoodsStockInfo, 0)
+	data := make([]*stockv1.GoodsStockInfo, 0)
 	for _, stock := range *stockList {
-		GoodsStockInfo := proto.GoodsStockInfo{
+		GoodsStockInfo := stockv1.GoodsStockInfo{
 			GoodsId: stock.GoodsId,
 			Num:     stock.Num,
 		}
 		data = append(data, &GoodsStockInfo)
 	}
-	stockInfoList := &proto.StockInfoList{Data: data}
+	stockInfoList := &stockv1.StockInfoList{Data: data}
 	return stockInfoList, nil
 
 }
 
 // 批量扣库存
-func BatchReduceStock(ctx context.Context, req []*proto.GoodsStockInfo) (*proto.StockInfoList, error) {
+func BatchReduceStock(ctx context.Context, req []*stockv1.GoodsStockInfo) (*stockv1.StockInfoList, error) {
 	goodsIds := make([]int64, 0)
 	nums := make([]int64, 0)
 	for _, GoodsStockInfo := range req {
@@ -58,15 +64,15 @@ func BatchReduceStock(ctx context.Context, req []*proto.GoodsStockInfo) (*proto.
 	if err != nil {
 		return nil, err
 	}
-	res := make([]*proto.GoodsStockInfo, 0)
+	res := make([]*stockv1.GoodsStockInfo, 0)
 	for _, d := range *data {
-		GoodsStockInfo := proto.GoodsStockInfo{
+		GoodsStockInfo := stockv1.GoodsStockInfo{
 			GoodsId: d.GoodsId,
 			Num:     d.Num,
 		}
 		res = append(res, &GoodsStockInfo)
 	}
-	stockInfoList := proto.StockInfoList{Data: res}
+	stockInfoList := stockv1.StockInfoList{Data: res}
 	return &stockInfoList, nil
 
 }
@@ -79,6 +85,16 @@ func ReduceStockByGoodsId(ctx context.Context, goodsId, num int64, orderId int64
 }
 
 // RollbackStock 回滚库存
-func RollbackStock(ctx context.Context, data []*proto.GoodsStockInfo) error {
+func RollbackStock(ctx context.Context, data []*stockv1.GoodsStockInfo) error {
 	return mysql.RollbackStock(ctx, data)
 }
+
+// ReturnStock 售后退货归还库存
+func ReturnStock(ctx context.Context, req *stockv1.ReturnStockReq) error {
+	return mysql.ReturnStock(ctx, model.StockReturn{
+		RefundId: req.RefundId,
+		OrderId:  req.OrderId,
+		GoodsId:  req.GoodsId,
+		Num:      req.Num,
+	})
+}
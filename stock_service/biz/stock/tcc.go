@@ -0,0 +1,104 @@
+package stock
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	stockv1 "github.com/idMiFeng/api/shop/stock/v1"
+	"github.com/idMiFeng/stock_service/dao/mysql"
+	"github.com/idMiFeng/stock_service/errno"
+	"github.com/idMiFeng/stock_service/model"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TryReserve TCC Try 预扣库存
+func TryReserve(ctx context.Context, req *stockv1.ReserveReq) error {
+	err := mysql.TryReserve(ctx, recordOf(req))
+	if err != nil {
+		zap.L().Warn("mysql.TryReserve failed", zap.String("tx_id", req.TxId), zap.Int64("goods_id", req.GoodsId), zap.Error(err))
+	}
+	return tccError(err)
+}
+
+// ConfirmReserve TCC Confirm 确认扣减
+func ConfirmReserve(ctx context.Context, req *stockv1.ReserveReq) error {
+	err := mysql.ConfirmReserve(ctx, recordOf(req))
+	if err != nil {
+		zap.L().Warn("mysql.ConfirmReserve failed", zap.String("tx_id", req.TxId), zap.Int64("goods_id", req.GoodsId), zap.Error(err))
+	}
+	return tccError(err)
+}
+
+// CancelReserve TCC Cancel 归还预扣的库存
+func CancelReserve(ctx context.Context, req *stockv1.ReserveReq) error {
+	err := mysql.CancelReserve(ctx, recordOf(req))
+	if err != nil {
+		zap.L().Warn("mysql.CancelReserve failed", zap.String("tx_id", req.TxId), zap.Int64("goods_id", req.GoodsId), zap.Error(err))
+	}
+	return tccError(err)
+}
+
+// RollbackByMsg 订单服务投递的库存回滚消息，按 Cancel 处理，全局事务id为订单号
+// 订单服务扣减库存的结果不确定时也会投递回滚消息，这时扣减请求可能还没有到达，Cancel 记录空回滚，之后到达的 Try 被拒绝
+// 订单已经支付、库存已经确认扣减时不再回滚，消息直接确认
+func RollbackByMsg(ctx context.Context, data model.OrderGoodsStockInfo) error {
+	err := mysql.CancelReserve(ctx, recordOfMsg(data))
+	if errors.Is(err, errno.ErrTxConfirmed) {
+		zap.L().Warn("rollback confirmed stock ignored", zap.Int64("order_id", data.OrderId), zap.Int64("goods_id", data.GoodsId))
+		return nil
+	}
+	return err
+}
+
+// ConfirmByMsg 支付成功消息，按 Confirm 处理，全局事务id为订单号
+// 没有预扣或者已经回滚时没有可以确认的库存，记录日志后确认消息，由对账处理
+func ConfirmByMsg(ctx context.Context, data model.OrderGoodsStockInfo) error {
+	err := mysql.ConfirmReserve(ctx, recordOfMsg(data))
+	if errors.Is(err, errno.ErrTxNotTried) || errors.Is(err, errno.ErrTxCancelled) {
+		zap.L().Error("confirm stock not reserved", zap.Int64("order_id", data.OrderId), zap.Int64("goods_id", data.GoodsId), zap.Error(err))
+		return nil
+	}
+	return err
+}
+
+func recordOfMsg(data model.OrderGoodsStockInfo) model.StockRecord {
+	return model.StockRecord{
+		TxId:    strconv.FormatInt(data.OrderId, 10),
+		OrderId: data.OrderId,
+		GoodsId: data.GoodsId,
+		Num:     data.Num,
+	}
+}
+
+func recordOf(req *stockv1.ReserveReq) model.StockRecord {
+	return model.StockRecord{
+		TxId:    req.TxId,
+		OrderId: req.OrderId,
+		GoodsId: req.GoodsId,
+		Num:     req.Num,
+	}
+}
+
+// tccError 把事务状态相关的错误转换成对应的状态码，事务协调者据此判断是否需要重试
+func tccError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, errno.ErrUnderstock):
+		return status.Error(codes.ResourceExhausted, "库存不足")
+	case errors.Is(err, errno.ErrTxCancelled):
+		return status.Error(codes.Aborted, "事务已回滚")
+	case errors.Is(err, errno.ErrTxConfirmed):
+		return status.Error(codes.FailedPrecondition, "事务已确认，不能回滚")
+	case errors.Is(err, errno.ErrTxNumMismatch):
+		return status.Error(codes.InvalidArgument, "与已预扣的数量不一致")
+	case errors.Is(err, errno.ErrTxNotTried):
+		return status.Error(codes.FailedPrecondition, "没有预扣库存")
+	default:
+		return status.Error(codes.Internal, "内部错误")
+	}
+}
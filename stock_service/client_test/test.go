@@ -3,7 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
-	"github.com/idMiFeng/stock_service/proto"
+	stockv1 "github.com/idMiFeng/api/shop/stock/v1"
 	"sync"
 
 	"google.golang.org/grpc"
@@ -12,7 +12,7 @@ import (
 
 var (
 	conn   *grpc.ClientConn
-	client proto.StockClient
+	client stockv1.StockClient
 )
 
 func init() {
@@ -21,11 +21,11 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
-	client = proto.NewStockClient(conn)
+	client = stockv1.NewStockClient(conn)
 }
 
 func TestGetStock() {
-	param := &proto.GoodsStockInfo{
+	param := &stockv1.GoodsStockInfo{
 		GoodsId: 1,
 		Num:     1,
 	}
@@ -35,7 +35,7 @@ func TestGetStock() {
 
 func TestReduceStock(wg *sync.WaitGroup) {
 	defer wg.Done()
-	param := &proto.GoodsStockInfo{
+	param := &stockv1.GoodsStockInfo{
 		GoodsId: 1,
 		Num:     1,
 	}
@@ -2,11 +2,12 @@ package mysql
 
 import (
 	"context"
-	"fmt"
+	"strconv"
+
+	stockv1 "github.com/idMiFeng/api/shop/stock/v1"
 	"github.com/idMiFeng/stock_service/dao/redis"
 	"github.com/idMiFeng/stock_service/errno"
 	"github.com/idMiFeng/stock_service/model"
-	"github.com/idMiFeng/stock_service/proto"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -30,6 +31,32 @@ func GetStockByGoodsId(ctx context.Context, goodsId int64) (*model.Stock, error)
 	return &data, nil
 }
 
+// SetStock 设置商品的可售库存，没有库存记录时新建
+// 与扣减库存使用同一把分布式锁，预扣库存不受影响
+func SetStock(ctx context.Context, goodsId, num int64) error {
+	mutex, err := redis.LockStock(ctx, goodsId)
+	if err != nil {
+		return err
+	}
+	defer mutex.Unlock()
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var data model.Stock
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Model(&model.Stock{}).
+			Where("goods_id = ?", goodsId).
+			First(&data).Error
+		if err == gorm.ErrRecordNotFound {
+			return tx.Create(&model.Stock{GoodsId: goodsId, Num: num}).Error
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Model(&model.Stock{}).
+			Where("goods_id = ?", goodsId).
+			Update("num", num).Error
+	})
+}
+
 // BatchGetStockByGoodsId 根据goodsIds切片返回商品切片
 func BatchGetStockByGoodsId(ctx context.Context, goodsIds []int64) (*[]model.Stock, error) {
 	var data []model.Stock
@@ -111,58 +138,46 @@ func ReduceStockByGoodsId(ctx context.Context, goodsId, num int64) error {
 	return nil
 }
 
-// RollbackStock 监听rocketmq消息进行库存回滚
-func RollbackStockByMsg(ctx context.Context, data model.OrderGoodsStockInfo) error {
-	// 先查询库存数据，需要放到事务操作中
-	db.Transaction(func(tx *gorm.DB) error {
-		var sr model.StockRecord
-		err := tx.WithContext(ctx).
-			Model(&model.StockRecord{}).
-			Where("order_id = ? and goods_id = ? and status = 1", data.OrderId, data.GoodsId).
-			First(&sr).Error
-		// 没找到记录
-		// 压根就没记录或者已经回滚过 不需要后续操作
-		if err == gorm.ErrRecordNotFound {
-			return nil
-		}
+// ReturnStock 售后退货归还库存
+// 与 ReduceStock 使用同一把分布式锁，避免 Save 整行时覆盖掉对方的修改
+func ReturnStock(ctx context.Context, data model.StockReturn) error {
+	mutex, err := redis.LockStock(ctx, data.GoodsId)
+	if err != nil {
+		return errno.ErrReturnstockFailed
+	}
+	defer mutex.Unlock()
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cnt int64
+		err := tx.Model(&model.StockReturn{}).
+			Where("refund_id = ? and goods_id = ?", data.RefundId, data.GoodsId).
+			Count(&cnt).Error
 		if err != nil {
-			zap.L().Error("query stock_record by order_id failed", zap.Error(err), zap.Int64("order_id", data.OrderId), zap.Int64("goods_id", data.GoodsId))
 			return err
 		}
-		// 开始归还库存
+		// 已经归还过
+		if cnt > 0 {
+			return nil
+		}
 		var s model.Stock
-		err = tx.WithContext(ctx).
-			Model(&model.Stock{}).
+		err = tx.Model(&model.Stock{}).
 			Where("goods_id = ?", data.GoodsId).
 			First(&s).Error
 		if err != nil {
 			zap.L().Error("query stock by goods_id failed", zap.Error(err), zap.Int64("goods_id", data.GoodsId))
 			return err
 		}
-		s.Num += data.Num  // 库存加上
-		s.Lock -= data.Num // 锁定的库存减掉
-		if s.Lock < 0 {    // 预扣库存不能为负
-			return errno.ErrRollbackstockFailed
-		}
-		err = tx.WithContext(ctx).Save(&s).Error
-		if err != nil {
-			zap.L().Warn("RollbackStock stock save failed", zap.Int64("goods_id", s.GoodsId), zap.Error(err))
-			return err
-		}
-		// 将库存扣减记录的状态变更为已回滚
-		sr.Status = 3
-		err = tx.WithContext(ctx).Save(&sr).Error
+		s.Num += data.Num
+		err = tx.Save(&s).Error
 		if err != nil {
-			zap.L().Warn("RollbackStock stock_record save failed", zap.Int64("goods_id", s.GoodsId), zap.Error(err))
+			zap.L().Warn("ReturnStock stock save failed", zap.Int64("goods_id", s.GoodsId), zap.Error(err))
 			return err
 		}
-		return nil
+		return tx.Create(&data).Error
 	})
-	return nil
 }
 
 // RollbackStock 回滚库存
-func RollbackStock(ctx context.Context, data []*proto.GoodsStockInfo) error {
+func RollbackStock(ctx context.Context, data []*stockv1.GoodsStockInfo) error {
 	// 先查询库存数据，需要放到事务操作中
 	db.Transaction(func(tx *gorm.DB) error {
 		for _, item := range data {
@@ -191,19 +206,16 @@ func RollbackStock(ctx context.Context, data []*proto.GoodsStockInfo) error {
 func ReduceStock(ctx context.Context, goodsId, num, orderId int64) (*model.Stock, error) {
 	// 1. 查询现有库存
 	var data model.Stock
-	// 创建key
-	mutexname := fmt.Sprintf("xx-stock-%d", goodsId)
-	// 创建锁
-	mutex := redis.Rs.NewMutex(mutexname)
 	// 获取锁
-	if err := mutex.Lock(); err != nil {
+	mutex, err := redis.LockStock(ctx, goodsId)
+	if err != nil {
 		return nil, errno.ErrReducestockFailed
 	}
 	// 此时data可能都是旧数据 data.num = 99  实际上数据库中num=97
 	defer mutex.Unlock() // 释放锁
 	// 获取锁成功
 	// 开启事务
-	db.Transaction(func(tx *gorm.DB) error {
+	err = db.Transaction(func(tx *gorm.DB) error {
 		err := tx.WithContext(ctx).
 			Model(&model.Stock{}).
 			Where("goods_id = ?", goodsId).
@@ -230,6 +242,7 @@ func ReduceStock(ctx context.Context, goodsId, num, orderId int64) (*model.Stock
 		}
 		// 创建库存记录表
 		stockRecord := model.StockRecord{
+			TxId:    strconv.FormatInt(orderId, 10), // 没有全局事务时使用订单号
 			OrderId: orderId,
 			GoodsId: goodsId,
 			Num:     num,
@@ -244,5 +257,9 @@ func ReduceStock(ctx context.Context, goodsId, num, orderId int64) (*model.Stock
 		}
 		return nil
 	})
+	// 库存不足或者写库存流水失败时事务已经回滚，需要返回错误，不能当作扣减成功
+	if err != nil {
+		return nil, err
+	}
 	return &data, nil
 }
@@ -0,0 +1,34 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/idMiFeng/stock_service/model"
+)
+
+// ListStockRecord 分页查询库存流水，cond 中为零值的条件不过滤
+func ListStockRecord(ctx context.Context, cond model.StockRecord, offset, limit int) ([]*model.StockRecord, int64, error) {
+	query := db.WithContext(ctx).Model(&model.StockRecord{})
+	if cond.GoodsId > 0 {
+		query = query.Where("goods_id = ?", cond.GoodsId)
+	}
+	if cond.OrderId > 0 {
+		query = query.Where("order_id = ?", cond.OrderId)
+	}
+	if len(cond.TxId) > 0 {
+		query = query.Where("tx_id = ?", cond.TxId)
+	}
+	if cond.Status > 0 {
+		query = query.Where("status = ?", cond.Status)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var data []*model.StockRecord
+	err := query.Order("id desc").
+		Offset(offset).
+		Limit(limit).
+		Find(&data).Error
+	return data, total, err
+}
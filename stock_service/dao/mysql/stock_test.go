@@ -0,0 +1,33 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/idMiFeng/stock_service/errno"
+	"github.com/idMiFeng/stock_service/model"
+)
+
+// 事务回滚时返回错误，不能当作扣减成功
+func TestReduceStockReturnsTxError(t *testing.T) {
+	d := setup(t)
+	ctx := context.Background()
+	if _, err := ReduceStock(ctx, 1, 11, 100); !errors.Is(err, errno.ErrUnderstock) {
+		t.Fatalf("ReduceStock = %v, want ErrUnderstock", err)
+	}
+	assertStock(t, d, 10, 0)
+	if _, err := ReduceStock(ctx, 1, 3, 100); err != nil {
+		t.Fatalf("ReduceStock: %v", err)
+	}
+	// 同一个订单重复扣减时库存流水的唯一索引冲突，事务回滚
+	if _, err := ReduceStock(ctx, 1, 3, 100); err == nil {
+		t.Fatal("duplicate ReduceStock succeeded")
+	}
+	assertStock(t, d, 7, 3)
+	var n int64
+	d.Model(&model.StockRecord{}).Where("order_id = ?", 100).Count(&n)
+	if n != 1 {
+		t.Fatalf("stock records = %d, want 1", n)
+	}
+}
@@ -0,0 +1,129 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/idMiFeng/stock_service/dao/redis"
+	"github.com/idMiFeng/stock_service/errno"
+	"github.com/idMiFeng/stock_service/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TCC 方式扣减库存，xx_stock_record 作为事务日志，按 全局事务id+商品id 记录事务状态
+// Try: 可用库存转为预扣库存    num -> lock
+// Confirm: 预扣库存转为实际扣减 lock -> 0
+// Cancel: 预扣库存归还         lock -> num
+// 三个操作都与 ReduceStock 使用同一把分布式锁，同一个商品的操作串行执行
+
+// withStockLock 加分布式锁后在事务中执行 fn，fn 中查询到的库存记录为空时 sr 为 nil
+func withStockLock(ctx context.Context, data model.StockRecord, fn func(tx *gorm.DB, s *model.Stock, sr *model.StockRecord) error) error {
+	mutex, err := redis.LockStock(ctx, data.GoodsId)
+	if err != nil {
+		return errno.ErrReducestockFailed
+	}
+	defer mutex.Unlock()
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var s model.Stock
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Model(&model.Stock{}).
+			Where("goods_id = ?", data.GoodsId).
+			First(&s).Error
+		if err != nil {
+			return err
+		}
+		var sr model.StockRecord
+		err = tx.Model(&model.StockRecord{}).
+			Where("tx_id = ? and goods_id = ?", data.TxId, data.GoodsId).
+			First(&sr).Error
+		if err == gorm.ErrRecordNotFound {
+			return fn(tx, &s, nil)
+		}
+		if err != nil {
+			return err
+		}
+		return fn(tx, &s, &sr)
+	})
+}
+
+// TryReserve 预扣库存
+// 已经执行过 Try 时直接返回，数量不一致时拒绝，事务已经回滚（包括空回滚）时拒绝执行，防止悬挂
+func TryReserve(ctx context.Context, data model.StockRecord) error {
+	return withStockLock(ctx, data, func(tx *gorm.DB, s *model.Stock, sr *model.StockRecord) error {
+		if sr != nil {
+			if sr.Status == model.StockRecordCancelled {
+				return errno.ErrTxCancelled
+			}
+			if sr.Num != data.Num {
+				return errno.ErrTxNumMismatch
+			}
+			return nil
+		}
+		if s.Num < data.Num {
+			return errno.ErrUnderstock
+		}
+		s.Num -= data.Num
+		s.Lock += data.Num
+		if err := tx.Save(s).Error; err != nil {
+			zap.L().Warn("TryReserve stock save failed", zap.Int64("goods_id", s.GoodsId), zap.Error(err))
+			return err
+		}
+		data.Status = model.StockRecordReserved
+		return tx.Create(&data).Error
+	})
+}
+
+// ConfirmReserve 确认扣减，已经确认过时直接返回
+func ConfirmReserve(ctx context.Context, data model.StockRecord) error {
+	return withStockLock(ctx, data, func(tx *gorm.DB, s *model.Stock, sr *model.StockRecord) error {
+		if sr == nil {
+			return errno.ErrTxNotTried
+		}
+		switch sr.Status {
+		case model.StockRecordConfirmed:
+			return nil
+		case model.StockRecordCancelled:
+			return errno.ErrTxCancelled
+		}
+		s.Lock -= sr.Num
+		if s.Lock < 0 {
+			return errno.ErrConfirmstockFailed
+		}
+		if err := tx.Save(s).Error; err != nil {
+			zap.L().Warn("ConfirmReserve stock save failed", zap.Int64("goods_id", s.GoodsId), zap.Error(err))
+			return err
+		}
+		return tx.Model(sr).Update("status", model.StockRecordConfirmed).Error
+	})
+}
+
+// CancelReserve 归还预扣的库存，已经回滚过时直接返回
+// 没有执行过 Try 时记录一条已回滚的记录（空回滚），之后到达的 Try 会被拒绝
+// 空回滚只占用 全局事务id+商品id，不影响同一订单以其他事务扣减库存
+func CancelReserve(ctx context.Context, data model.StockRecord) error {
+	return withStockLock(ctx, data, func(tx *gorm.DB, s *model.Stock, sr *model.StockRecord) error {
+		if sr == nil {
+			data.Num = 0 // 没有预扣任何库存
+			data.Status = model.StockRecordCancelled
+			return tx.Create(&data).Error
+		}
+		switch sr.Status {
+		case model.StockRecordCancelled:
+			return nil
+		case model.StockRecordConfirmed:
+			return errno.ErrTxConfirmed
+		}
+		s.Num += sr.Num
+		s.Lock -= sr.Num
+		if s.Lock < 0 {
+			return errno.ErrRollbackstockFailed
+		}
+		if err := tx.Save(s).Error; err != nil {
+			zap.L().Warn("CancelReserve stock save failed", zap.Int64("goods_id", s.GoodsId), zap.Error(err))
+			return err
+		}
+		return tx.Model(sr).Update("status", model.StockRecordCancelled).Error
+	})
+}
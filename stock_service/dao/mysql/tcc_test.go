@@ -0,0 +1,137 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/idMiFeng/stock_service/dao/redis"
+	"github.com/idMiFeng/stock_service/errno"
+	"github.com/idMiFeng/stock_service/model"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis/v8"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setup 使用SQLite和miniredis，商品1的库存为10
+// 唯一索引与 sql/stock_record.sql 保持一致
+func setup(t *testing.T) *gorm.DB {
+	d, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := d.DB()
+	t.Cleanup(func() { sqlDB.Close() })
+	if err = d.AutoMigrate(&model.Stock{}, &model.StockRecord{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = d.Exec("CREATE UNIQUE INDEX uk_tx_goods ON xx_stock_record (tx_id, goods_id)").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err = d.Create(&model.Stock{GoodsId: 1, Num: 10}).Error; err != nil {
+		t.Fatal(err)
+	}
+	SetDB(d)
+	mr := miniredis.RunT(t)
+	redis.SetClient(goredis.NewClient(&goredis.Options{Addr: mr.Addr()}))
+	return d
+}
+
+func assertStock(t *testing.T, d *gorm.DB, num, lock int64) {
+	t.Helper()
+	var s model.Stock
+	if err := d.Where("goods_id = ?", 1).First(&s).Error; err != nil {
+		t.Fatal(err)
+	}
+	if s.Num != num || s.Lock != lock {
+		t.Fatalf("stock = %d/%d, want %d/%d", s.Num, s.Lock, num, lock)
+	}
+}
+
+func reserve(txId string, num int64) model.StockRecord {
+	return model.StockRecord{TxId: txId, OrderId: 100, GoodsId: 1, Num: num}
+}
+
+// 重复的 Try/Confirm/Cancel 只生效一次
+func TestTccIdempotent(t *testing.T) {
+	d := setup(t)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := TryReserve(ctx, reserve("tx1", 3)); err != nil {
+			t.Fatalf("TryReserve: %v", err)
+		}
+	}
+	assertStock(t, d, 7, 3)
+	for i := 0; i < 2; i++ {
+		if err := ConfirmReserve(ctx, reserve("tx1", 0)); err != nil {
+			t.Fatalf("ConfirmReserve: %v", err)
+		}
+	}
+	assertStock(t, d, 7, 0)
+	if err := CancelReserve(ctx, reserve("tx1", 0)); !errors.Is(err, errno.ErrTxConfirmed) {
+		t.Fatalf("CancelReserve after confirm = %v, want ErrTxConfirmed", err)
+	}
+
+	if err := TryReserve(ctx, reserve("tx2", 2)); err != nil {
+		t.Fatalf("TryReserve: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := CancelReserve(ctx, reserve("tx2", 0)); err != nil {
+			t.Fatalf("CancelReserve: %v", err)
+		}
+	}
+	assertStock(t, d, 7, 0)
+}
+
+// 重复的 Try 数量不一致时拒绝，不会按新的数量扣减
+func TestTryReserveNumMismatch(t *testing.T) {
+	d := setup(t)
+	ctx := context.Background()
+	if err := TryReserve(ctx, reserve("tx1", 3)); err != nil {
+		t.Fatalf("TryReserve: %v", err)
+	}
+	if err := TryReserve(ctx, reserve("tx1", 5)); !errors.Is(err, errno.ErrTxNumMismatch) {
+		t.Fatalf("TryReserve = %v, want ErrTxNumMismatch", err)
+	}
+	assertStock(t, d, 7, 3)
+}
+
+// Cancel 先于 Try 到达时记录空回滚，之后的 Try 被拒绝（防悬挂）
+func TestEmptyRollbackRejectsSuspendedTry(t *testing.T) {
+	d := setup(t)
+	ctx := context.Background()
+	if err := CancelReserve(ctx, reserve("tx1", 3)); err != nil {
+		t.Fatalf("CancelReserve: %v", err)
+	}
+	assertStock(t, d, 10, 0)
+	if err := TryReserve(ctx, reserve("tx1", 3)); !errors.Is(err, errno.ErrTxCancelled) {
+		t.Fatalf("TryReserve = %v, want ErrTxCancelled", err)
+	}
+	assertStock(t, d, 10, 0)
+	if err := ConfirmReserve(ctx, reserve("tx1", 0)); !errors.Is(err, errno.ErrTxCancelled) {
+		t.Fatalf("ConfirmReserve = %v, want ErrTxCancelled", err)
+	}
+}
+
+// 空回滚的记录不影响同一订单以其他事务扣减库存
+func TestEmptyRollbackKeepsOrderFree(t *testing.T) {
+	d := setup(t)
+	ctx := context.Background()
+	if err := CancelReserve(ctx, reserve("saga-100", 3)); err != nil {
+		t.Fatalf("CancelReserve: %v", err)
+	}
+	if _, err := ReduceStock(ctx, 1, 3, 100); err != nil {
+		t.Fatalf("ReduceStock: %v", err)
+	}
+	assertStock(t, d, 7, 3)
+}
+
+func TestConfirmNotTried(t *testing.T) {
+	setup(t)
+	if err := ConfirmReserve(context.Background(), reserve("tx1", 0)); !errors.Is(err, errno.ErrTxNotTried) {
+		t.Fatalf("ConfirmReserve = %v, want ErrTxNotTried", err)
+	}
+}
@@ -0,0 +1,45 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/idMiFeng/common/tracing"
+
+	"github.com/go-redsync/redsync/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var (
+	lockWait = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "redsync_lock_wait_seconds",
+		Help:    "获取分布式锁的等待时间，包括获取失败的",
+		Buckets: []float64{.001, .005, .01, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"lock"})
+	lockFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "redsync_lock_failures_total",
+		Help: "获取分布式锁失败的次数",
+	}, []string{"lock"})
+)
+
+// LockStock 获取商品库存的分布式锁，扣减、归还和设置库存都使用这把锁
+// 成功时调用方负责 Unlock
+func LockStock(ctx context.Context, goodsId int64) (*redsync.Mutex, error) {
+	name := fmt.Sprintf("xx-stock-%d", goodsId)
+	_, span := otel.Tracer("github.com/idMiFeng/stock_service/dao/redis").Start(ctx, "redsync.Lock")
+	span.SetAttributes(attribute.String("lock.name", name))
+	mutex := Rs.NewMutex(name)
+	start := time.Now()
+	err := mutex.Lock()
+	lockWait.WithLabelValues("stock").Observe(time.Since(start).Seconds())
+	tracing.End(span, err)
+	if err != nil {
+		lockFailures.WithLabelValues("stock").Inc()
+		return nil, err
+	}
+	return mutex, nil
+}
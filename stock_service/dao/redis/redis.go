@@ -2,7 +2,8 @@ package redis
 
 import (
 	"context"
-	"fmt"
+
+	commonredis "github.com/idMiFeng/common/redis"
 	"github.com/idMiFeng/stock_service/config"
 
 	"github.com/go-redis/redis/v8"
@@ -18,20 +19,32 @@ var (
 )
 
 func Init(cfg *config.RedisConfig) error {
-	rc = redis.NewClient(&redis.Options{
-		Addr:     fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
-		Password: cfg.Password, // 密码
-		DB:       cfg.DB,       // 数据库
-		PoolSize: cfg.PoolSize, // 连接池大小
-	})
-	err := rc.Ping(context.Background()).Err()
+	c, err := commonredis.Open(cfg)
 	if err != nil {
 		return err
 	}
+	SetClient(c)
+	return nil
+}
+
+// SetClient 使用已经建立的连接，单元测试中替换为miniredis
+func SetClient(c *redis.Client) {
+	rc = c
 	pool := goredis.NewPool(rc) // or, pool := redigo.NewPool(...)
 
 	// Create an instance of redisync to be used to obtain a mutual exclusion
 	// lock.
 	Rs = redsync.New(pool)
-	return nil
+}
+
+// Ping 检查Redis是否可用
+func Ping(ctx context.Context) error {
+	return rc.Ping(ctx).Err()
+}
+
+func Close() error {
+	if rc == nil {
+		return nil
+	}
+	return rc.Close()
 }
@@ -1,12 +1,25 @@
 package errno
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/idMiFeng/common/errno"
+)
 
 var (
-	ErrQueryFailed = errors.New("query db failed")
-	ErrQueryEmpty  = errors.New("query empty") // 查询结果为空
+	// 通用错误定义在common/errno
+	ErrQueryFailed = errno.ErrQueryFailed
+	ErrQueryEmpty  = errno.ErrQueryEmpty
+	ErrPoisonMsg   = errno.ErrPoisonMsg
 
-	ErrUnderstock          = errors.New("understock")
+	ErrUnderstock          = errors.New("understock")            // 库存不足
 	ErrReducestockFailed   = errors.New("reduce stock failed")   // 库存扣减失败
 	ErrRollbackstockFailed = errors.New("rollback stock failed") // 回滚库存失败
+	ErrConfirmstockFailed  = errors.New("confirm stock failed")  // 确认扣减库存失败
+	ErrReturnstockFailed   = errors.New("return stock failed")   // 退货归还库存失败
+
+	ErrTxCancelled   = errors.New("transaction cancelled")    // 事务已回滚，拒绝悬挂的Try
+	ErrTxNotTried    = errors.New("transaction not tried")    // 没有执行过Try
+	ErrTxConfirmed   = errors.New("transaction confirmed")    // 事务已确认，不能回滚
+	ErrTxNumMismatch = errors.New("transaction num mismatch") // 重复的Try与已记录的数量不一致
 )
@@ -3,14 +3,15 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	stockv1 "github.com/idMiFeng/api/shop/stock/v1"
+	"github.com/idMiFeng/common/broker"
+	"github.com/idMiFeng/common/logger"
 	"github.com/idMiFeng/stock_service/biz/stock"
-	"github.com/idMiFeng/stock_service/dao/mysql"
+	"github.com/idMiFeng/stock_service/errno"
 	"github.com/idMiFeng/stock_service/model"
-	"github.com/idMiFeng/stock_service/proto"
 
-	"github.com/apache/rocketmq-client-go/v2/consumer"
-	"github.com/apache/rocketmq-client-go/v2/primitive"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -20,17 +21,25 @@ import (
 // RPC的入口
 
 type StockSrv struct {
-	proto.UnimplementedStockServer
+	stockv1.UnimplementedStockServer
 }
 
 // SetStock 设置库存
-func (s *StockSrv) SetStock(context.Context, *proto.GoodsStockInfo) (*emptypb.Empty, error) {
-
-	return nil, nil
+func (s *StockSrv) SetStock(ctx context.Context, req *stockv1.GoodsStockInfo) (*emptypb.Empty, error) {
+	// 参数处理
+	if req.GetGoodsId() <= 0 || req.GetNum() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
+	}
+	err := stock.SetStock(ctx, req.GetGoodsId(), req.GetNum())
+	if err != nil {
+		logger.FromContext(ctx).Error("stock.SetStock failed", zap.Int64("goods_id", req.GetGoodsId()), zap.Error(err))
+		return nil, status.Error(codes.Internal, "内部错误")
+	}
+	return &emptypb.Empty{}, nil
 }
 
 // GetStock 获取商品库存信息
-func (s *StockSrv) GetStock(ctx context.Context, req *proto.GoodsStockInfo) (*proto.GoodsStockInfo, error) {
+func (s *StockSrv) GetStock(ctx context.Context, req *stockv1.GoodsStockInfo) (*stockv1.GoodsStockInfo, error) {
 	// 参数处理
 	if req.GetGoodsId() <= 0 {
 		// 无效的请求
@@ -45,7 +54,7 @@ func (s *StockSrv) GetStock(ctx context.Context, req *proto.GoodsStockInfo) (*pr
 }
 
 // 批量查询库存
-func (s *StockSrv) BatchGetStock(ctx context.Context, req *proto.StockInfoList) (*proto.StockInfoList, error) {
+func (s *StockSrv) BatchGetStock(ctx context.Context, req *stockv1.StockInfoList) (*stockv1.StockInfoList, error) {
 	if len(req.GetData()) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
 	}
@@ -57,9 +66,18 @@ func (s *StockSrv) BatchGetStock(ctx context.Context, req *proto.StockInfoList)
 
 }
 
+// ListStockRecord 查询库存流水
+func (s *StockSrv) ListStockRecord(ctx context.Context, req *stockv1.ListStockRecordReq) (*stockv1.StockRecordList, error) {
+	// 不允许不带条件查询全部流水
+	if req.GetGoodsId() <= 0 && req.GetOrderId() <= 0 && len(req.GetTxId()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
+	}
+	return stock.ListStockRecord(ctx, req)
+}
+
 // ReduceStock 扣减库存
-func (s *StockSrv) ReduceStock(ctx context.Context, req *proto.GoodsStockInfo) (*emptypb.Empty, error) {
-	fmt.Printf("in ReduceStock... req:%#v\n", req)
+func (s *StockSrv) ReduceStock(ctx context.Context, req *stockv1.GoodsStockInfo) (*emptypb.Empty, error) {
+	logger.FromContext(ctx).Debug("ReduceStock", zap.Int64("goods_id", req.GetGoodsId()), zap.Int64("num", req.GetNum()))
 	// 参数处理
 	if req.GetGoodsId() <= 0 {
 		// 无效的请求
@@ -67,6 +85,10 @@ func (s *StockSrv) ReduceStock(ctx context.Context, req *proto.GoodsStockInfo) (
 	}
 	// 扣减库存
 	err := stock.ReduceStockByGoodsId(ctx, req.GetGoodsId(), req.GetNum(), req.GetOrderId())
+	if errors.Is(err, errno.ErrUnderstock) {
+		// 订单服务根据这个状态码判断商品是否已售罄
+		return nil, status.Error(codes.ResourceExhausted, "库存不足")
+	}
 	if err != nil {
 		return nil, status.Error(codes.Internal, "内部错误")
 	}
@@ -74,7 +96,7 @@ func (s *StockSrv) ReduceStock(ctx context.Context, req *proto.GoodsStockInfo) (
 }
 
 // 批量扣库存
-func (s *StockSrv) BatchReduceStock(ctx context.Context, req *proto.StockInfoList) (*proto.StockInfoList, error) {
+func (s *StockSrv) BatchReduceStock(ctx context.Context, req *stockv1.StockInfoList) (*stockv1.StockInfoList, error) {
 	if len(req.GetData()) <= 0 {
 		// 无效的请求
 		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
@@ -86,8 +108,55 @@ func (s *StockSrv) BatchReduceStock(ctx context.Context, req *proto.StockInfoLis
 	return data, nil
 }
 
+// ReturnStock 售后退货归还库存，同一个售后单重复调用只归还一次
+func (s *StockSrv) ReturnStock(ctx context.Context, req *stockv1.ReturnStockReq) (*emptypb.Empty, error) {
+	// 参数处理
+	if req.GetRefundId() <= 0 || req.GetGoodsId() <= 0 || req.GetNum() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
+	}
+	err := stock.ReturnStock(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("stock.ReturnStock failed", zap.Int64("refund_id", req.GetRefundId()), zap.Error(err))
+		return nil, status.Error(codes.Internal, "内部错误")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// TryReserve TCC 预扣库存，同一个全局事务重复调用只扣一次
+func (s *StockSrv) TryReserve(ctx context.Context, req *stockv1.ReserveReq) (*emptypb.Empty, error) {
+	if len(req.GetTxId()) == 0 || req.GetOrderId() <= 0 || req.GetGoodsId() <= 0 || req.GetNum() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
+	}
+	if err := stock.TryReserve(ctx, req); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ConfirmReserve TCC 确认扣减
+func (s *StockSrv) ConfirmReserve(ctx context.Context, req *stockv1.ReserveReq) (*emptypb.Empty, error) {
+	if len(req.GetTxId()) == 0 || req.GetOrderId() <= 0 || req.GetGoodsId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
+	}
+	if err := stock.ConfirmReserve(ctx, req); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// CancelReserve TCC 归还预扣的库存，在 Try 之前调用时记录空回滚
+func (s *StockSrv) CancelReserve(ctx context.Context, req *stockv1.ReserveReq) (*emptypb.Empty, error) {
+	if len(req.GetTxId()) == 0 || req.GetOrderId() <= 0 || req.GetGoodsId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
+	}
+	if err := stock.CancelReserve(ctx, req); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
 // RollbackStock 批量归还库存
-// func (s *StockSrv) RollbackStock(ctx context.Context, req *proto.StockInfoList) (*emptypb.Empty, error) {
+// func (s *StockSrv) RollbackStock(ctx context.Context, req *stockv1.StockInfoList) (*emptypb.Empty, error) {
 // 	// 参数校验
 // 	if len(req.GetData()) < 1 {
 // 		// 无效的请求
@@ -102,22 +171,34 @@ func (s *StockSrv) BatchReduceStock(ctx context.Context, req *proto.StockInfoLis
 // }
 
 // RollbackMsghandle 监听rocketmq消息进行库存回滚的处理函数
-// 需考虑重复归还的问题（幂等性）
-// 添加库存扣减记录表
-func RollbackMsghandle(ctx context.Context, msgs ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
-	for i := range msgs {
-		var data model.OrderGoodsStockInfo
-		err := json.Unmarshal(msgs[i].Body, &data)
-		if err != nil {
-			zap.L().Error("json.Unmarshal RollbackMsg failed", zap.Error(err))
-			continue
-		}
-		// 将库存回滚
-		err = mysql.RollbackStockByMsg(ctx, data)
-		if err != nil {
-			return consumer.ConsumeRetryLater, nil
-		}
-		return consumer.ConsumeSuccess, nil
-	}
-	return consumer.ConsumeSuccess, nil
+// 按 订单号+商品id 的库存记录幂等，还没有扣减时记录空回滚，之后到达的扣减被拒绝
+func RollbackMsghandle(ctx context.Context, msg *broker.Message) error {
+	var data model.OrderGoodsStockInfo
+	err := json.Unmarshal(msg.Body, &data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errno.ErrPoisonMsg, err)
+	}
+	// 将库存回滚
+	err = stock.RollbackByMsg(ctx, data)
+	if err != nil {
+		logger.FromContext(ctx).Error("stock.RollbackByMsg failed", zap.Int64("order_id", data.OrderId), zap.Int64("goods_id", data.GoodsId), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// PaySuccessMsghandle 监听支付成功消息，确认扣减预扣的库存
+// 按 订单号+商品id 的库存记录幂等，重复消息不会重复扣减
+func PaySuccessMsghandle(ctx context.Context, msg *broker.Message) error {
+	var data model.OrderGoodsStockInfo
+	err := json.Unmarshal(msg.Body, &data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errno.ErrPoisonMsg, err)
+	}
+	err = stock.ConfirmByMsg(ctx, data)
+	if err != nil {
+		logger.FromContext(ctx).Error("stock.ConfirmByMsg failed", zap.Int64("order_id", data.OrderId), zap.Error(err))
+		return err
+	}
+	return nil
 }
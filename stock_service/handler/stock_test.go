@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	stockv1 "github.com/idMiFeng/api/shop/stock/v1"
+	"github.com/idMiFeng/common/broker"
+	"github.com/idMiFeng/stock_service/dao/mysql"
+	"github.com/idMiFeng/stock_service/dao/redis"
+	"github.com/idMiFeng/stock_service/model"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis/v8"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	topicStockRollback = "xx_stock_rollback"
+	topicPaySuccess    = "xx_pay_success"
+)
+
+// setup 使用SQLite、miniredis和内存消息中间件，商品1的库存为10
+func setup(t *testing.T) (*gorm.DB, *broker.Memory) {
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	t.Cleanup(func() { sqlDB.Close() })
+	if err = db.AutoMigrate(&model.Stock{}, &model.StockRecord{}); err != nil {
+		t.Fatal(err)
+	}
+	// 与 sql/stock_record_tx_id.sql 的唯一索引一致
+	if err = db.Exec("CREATE UNIQUE INDEX uk_tx_goods ON xx_stock_record (tx_id, goods_id)").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Create(&model.Stock{GoodsId: 1, Num: 10}).Error; err != nil {
+		t.Fatal(err)
+	}
+	mysql.SetDB(db)
+	mr := miniredis.RunT(t)
+	redis.SetClient(goredis.NewClient(&goredis.Options{Addr: mr.Addr()}))
+
+	m := broker.NewMemory(nil)
+	m.Subscribe(topicStockRollback, RollbackMsghandle)
+	m.Subscribe(topicPaySuccess, PaySuccessMsghandle)
+	m.Start()
+	broker.MQ = m
+	t.Cleanup(func() { m.Shutdown() })
+	return db, m
+}
+
+func stockOf(t *testing.T, db *gorm.DB, goodsId int64) model.Stock {
+	var s model.Stock
+	if err := db.Where("goods_id = ?", goodsId).First(&s).Error; err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// waitStock 等待消息消费后库存变为 num/lock
+func waitStock(t *testing.T, db *gorm.DB, num, lock int64) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		s := stockOf(t, db, 1)
+		if s.Num == num && s.Lock == lock {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("stock = %d/%d, want %d/%d", s.Num, s.Lock, num, lock)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// 订单超时后收到回滚消息，预扣的库存归还，重复的消息不会重复归还
+func TestReduceThenRollbackByMsg(t *testing.T) {
+	db, m := setup(t)
+	ctx := context.Background()
+
+	_, err := (&StockSrv{}).ReduceStock(ctx, &stockv1.GoodsStockInfo{OrderId: 100, GoodsId: 1, Num: 3})
+	if err != nil {
+		t.Fatalf("ReduceStock: %v", err)
+	}
+	if s := stockOf(t, db, 1); s.Num != 7 || s.Lock != 3 {
+		t.Fatalf("after reduce stock = %d/%d, want 7/3", s.Num, s.Lock)
+	}
+
+	b, _ := json.Marshal(model.OrderGoodsStockInfo{OrderId: 100, GoodsId: 1, Num: 3})
+	for i := 0; i < 2; i++ {
+		if err = m.Publish(ctx, &broker.Message{Topic: topicStockRollback, Body: b}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitStock(t, db, 10, 0)
+	time.Sleep(50 * time.Millisecond)
+	if s := stockOf(t, db, 1); s.Num != 10 || s.Lock != 0 {
+		t.Fatalf("after duplicate rollback stock = %d/%d, want 10/0", s.Num, s.Lock)
+	}
+	if dead := m.Dead(); len(dead) != 0 {
+		t.Fatalf("dead letters: %d", len(dead))
+	}
+}
+
+func publish(t *testing.T, m *broker.Memory, topic string, data model.OrderGoodsStockInfo) {
+	b, _ := json.Marshal(data)
+	if err := m.Publish(context.Background(), &broker.Message{Topic: topic, Body: b}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// waitRecord 等待消息消费后订单的库存记录变为 status
+func waitRecord(t *testing.T, db *gorm.DB, orderId int64, status int32) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var sr model.StockRecord
+		err := db.Where("tx_id = ? and goods_id = ?", strconv.FormatInt(orderId, 10), 1).First(&sr).Error
+		if err == nil && sr.Status == status {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("stock record = %+v, %v, want status %d", sr, err, status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// 订单服务扣减库存超时后投递回滚消息，回滚先于扣减请求到达时记录空回滚，之后到达的扣减被拒绝
+func TestRollbackMsgBeforeTry(t *testing.T) {
+	db, m := setup(t)
+	publish(t, m, topicStockRollback, model.OrderGoodsStockInfo{OrderId: 100, GoodsId: 1, Num: 3})
+	waitRecord(t, db, 100, model.StockRecordCancelled)
+
+	_, err := (&StockSrv{}).TryReserve(context.Background(), &stockv1.ReserveReq{TxId: "100", OrderId: 100, GoodsId: 1, Num: 3})
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("TryReserve = %v, want Aborted", err)
+	}
+	if s := stockOf(t, db, 1); s.Num != 10 || s.Lock != 0 {
+		t.Fatalf("stock = %d/%d, want 10/0", s.Num, s.Lock)
+	}
+}
+
+// 支付成功后确认扣减，之后的回滚消息不再归还库存，也不进入死信
+func TestRollbackMsgAfterConfirm(t *testing.T) {
+	db, m := setup(t)
+	ctx := context.Background()
+	_, err := (&StockSrv{}).TryReserve(ctx, &stockv1.ReserveReq{TxId: "100", OrderId: 100, GoodsId: 1, Num: 3})
+	if err != nil {
+		t.Fatalf("TryReserve: %v", err)
+	}
+	publish(t, m, topicPaySuccess, model.OrderGoodsStockInfo{OrderId: 100, GoodsId: 1, Num: 3})
+	waitStock(t, db, 7, 0)
+	publish(t, m, topicStockRollback, model.OrderGoodsStockInfo{OrderId: 100, GoodsId: 1, Num: 3})
+	time.Sleep(100 * time.Millisecond)
+	if s := stockOf(t, db, 1); s.Num != 7 || s.Lock != 0 {
+		t.Fatalf("stock = %d/%d, want 7/0", s.Num, s.Lock)
+	}
+	if dead := m.Dead(); len(dead) != 0 {
+		t.Fatalf("dead letters: %d", len(dead))
+	}
+}
+
+// 没有预扣的订单收到支付成功消息时记录日志后确认，不进入死信
+func TestConfirmMsgNotTried(t *testing.T) {
+	db, m := setup(t)
+	publish(t, m, topicPaySuccess, model.OrderGoodsStockInfo{OrderId: 100, GoodsId: 1, Num: 3})
+	time.Sleep(100 * time.Millisecond)
+	if s := stockOf(t, db, 1); s.Num != 10 || s.Lock != 0 {
+		t.Fatalf("stock = %d/%d, want 10/0", s.Num, s.Lock)
+	}
+	if dead := m.Dead(); len(dead) != 0 {
+		t.Fatalf("dead letters: %d", len(dead))
+	}
+}
+
+// 消息体无法解析时直接进入死信
+func TestRollbackPoisonMsg(t *testing.T) {
+	_, m := setup(t)
+	if err := m.Publish(context.Background(), &broker.Message{Topic: topicStockRollback, Body: []byte("{")}); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(m.Dead()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("poison message not dead-lettered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
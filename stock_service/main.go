@@ -1,148 +1,72 @@
 package main
 
 import (
-	"context"
-	"flag"
-	"fmt"
-	"log"
-	"net"
-	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
-
+	stockv1 "github.com/idMiFeng/api/shop/stock/v1"
+	"github.com/idMiFeng/common/auth"
+	"github.com/idMiFeng/common/bootstrap"
+	"github.com/idMiFeng/common/broker"
+	"github.com/idMiFeng/common/healthcheck"
+	"github.com/idMiFeng/stock_service/biz/stock"
 	"github.com/idMiFeng/stock_service/config"
 	"github.com/idMiFeng/stock_service/dao/mysql"
 	"github.com/idMiFeng/stock_service/dao/redis"
 	"github.com/idMiFeng/stock_service/handler"
-	"github.com/idMiFeng/stock_service/logger"
-	"github.com/idMiFeng/stock_service/proto"
-	"github.com/idMiFeng/stock_service/registry"
+	"github.com/idMiFeng/stock_service/rpc"
 
-	"github.com/apache/rocketmq-client-go/v2"
-	"github.com/apache/rocketmq-client-go/v2/consumer"
-	"github.com/apache/rocketmq-client-go/v2/primitive"
-	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime" // !!!!
 	"go.uber.org/zap"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/health"
-	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func main() {
-	// 可以把启动时的一些列操作放到 bootstap/init 包
-
-	var cfn string
-	// 0.从命令行获取可能的conf路径
-	// stock_service -conf="./conf/config_qa.yaml"
-	// stock_service -conf="./conf/config_online.yaml"
-	flag.StringVar(&cfn, "conf", "./conf/config.yaml", "指定配置文件路径")
-	flag.Parse()
-	// 1. 加载配置文件
-	err := config.Init(cfn)
-	if err != nil {
-		panic(err) // 程序启动时加载配置文件失败直接退出
-	}
-	// 2. 加载日志
-	err = logger.Init(config.Conf.LogConfig, config.Conf.Mode)
-	if err != nil {
-		panic(err) // 程序启动时初始化日志模块失败直接退出
-	}
-	// 3. 初始化MySQL
-	err = mysql.Init(config.Conf.MySQLConfig)
-	if err != nil {
-		panic(err) // 程序启动时初始化MySQL失败直接退出
-	}
-	// 4. 初始化Redis
-	err = redis.Init(config.Conf.RedisConfig)
-	if err != nil {
-		panic(err) // 程序启动时初始化MySQL失败直接退出
-	}
-	// 5. 初始化Consul
-	err = registry.Init(config.Conf.ConsulConfig.Addr)
-	if err != nil {
-		panic(err) // 程序启动时初始化注册中心失败直接退出
+	// 加载配置文件、日志，初始化注册中心
+	app := bootstrap.New(config.Conf)
+	// 初始化MySQL
+	bootstrap.Must(mysql.Init(config.Conf.MySQLConfig))
+	app.OnShutdown(mysql.Close)
+	app.AddCheck("mysql", mysql.Ping)
+	// 初始化Redis
+	bootstrap.Must(redis.Init(config.Conf.RedisConfig))
+	app.OnShutdown(redis.Close)
+	app.AddCheck("redis", redis.Ping)
+	// 暴露关注商品的库存指标
+	if config.Conf.MetricsConfig != nil {
+		bootstrap.Must(stock.WatchStock(config.Conf.MetricsConfig.WatchGoods))
 	}
 
+	// 初始化rocketmq，生产者用于投递和重放死信
+	var err error
+	broker.MQ, err = broker.NewRocketMQ(config.Conf.RocketMqConfig.Broker(), nil)
+	bootstrap.Must(err)
+	// 退出时先停止拉取消息，等进行中的消息处理完再关闭生产者
+	app.OnStop(broker.MQ.StopConsume)
+	app.OnShutdown(broker.MQ.Shutdown)
+	app.AddCheck("rocketmq", healthcheck.TCP(config.Conf.RocketMqConfig.Addr))
 	// 监听库存回滚的消息
-	c, _ := rocketmq.NewPushConsumer(
-		consumer.WithGroupName("stock_srv_1"),
-		consumer.WithNsResolver(primitive.NewPassthroughResolver([]string{"192.168.200.107:9876"})),
-	)
-	// 订阅topic
-	err = c.Subscribe("xx_stock_rollback", consumer.MessageSelector{}, handler.RollbackMsghandle)
+	err = broker.MQ.Subscribe(config.Conf.RocketMqConfig.Topic.StockRollback, handler.RollbackMsghandle)
 	if err != nil {
-		fmt.Println(err.Error())
+		zap.L().Error("subscribe stock rollback failed", zap.Error(err))
 	}
-	// Note: start after subscribe
-	err = c.Start()
+	// 监听支付成功的消息，确认扣减库存
+	err = broker.MQ.Subscribe(config.Conf.RocketMqConfig.Topic.PaySuccess, handler.PaySuccessMsghandle)
 	if err != nil {
-		panic(err)
+		zap.L().Error("subscribe pay success failed", zap.Error(err))
 	}
-
-	// 监听端口
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", config.Conf.Port))
+	// 监听死信消息，保存后可以查看和重放
+	err = broker.MQ.Subscribe(config.Conf.RocketMqConfig.Topic.DeadLetter, handler.DeadLetterhandle)
 	if err != nil {
-		panic(err)
-	}
-	// 创建gRPC服务
-	s := grpc.NewServer()
-	// 健康检查
-	grpc_health_v1.RegisterHealthServer(s, health.NewServer())
-	// 库存服务注册RPC服务
-	proto.RegisterStockServer(s, &handler.StockSrv{})
-	// 启动gRPC服务
-	go func() {
-		err = s.Serve(lis)
-		if err != nil {
-			panic(err)
-		}
-	}()
-
-	// 注册服务到consul
-	registry.Reg.RegisterService(config.Conf.Name, config.Conf.IP, config.Conf.Port, nil)
-
-	zap.L().Info("service start...")
-
-	// Create a client connection to the gRPC server we just started
-	// This is where the gRPC-Gateway proxies the requests
-	conn, err := grpc.DialContext( // RPC客户端
-		context.Background(),
-		fmt.Sprintf("%s:%d", config.Conf.IP, config.Conf.Port),
-		grpc.WithBlock(),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
-	if err != nil {
-		log.Fatalln("Failed to dial server:", err)
-	}
-
-	gwmux := runtime.NewServeMux()
-	// Register Greeter
-	err = proto.RegisterGoodsHandler(context.Background(), gwmux, conn)
-	if err != nil {
-		log.Fatalln("Failed to register gateway:", err)
+		zap.L().Error("subscribe dead letter failed", zap.Error(err))
 	}
+	// Note: start after subscribe
+	bootstrap.Must(broker.MQ.Start())
 
-	gwServer := &http.Server{
-		Addr:    ":8092",
-		Handler: gwmux,
-	}
+	// 初始化商品服务的客户端，商家只能操作自己商品的库存
+	goodsConn, err := rpc.InitSrvClient()
+	bootstrap.Must(err)
+	app.OnShutdown(goodsConn.Close)
+	auth.RegisterOwner("goods", stock.MerchantOf)
 
-	zap.L().Info("Serving gRPC-Gateway on http://0.0.0.0:8092")
-	go func() {
-		err := gwServer.ListenAndServe()
-		if err != nil {
-			log.Printf("gwServer.ListenAndServe failed, err: %v", err)
-			return
-		}
-	}()
+	// 库存服务注册RPC服务
+	stockv1.RegisterStockServer(app.Server, &handler.StockSrv{})
+	app.RegisterGateway(stockv1.RegisterStockHandler)
 
-	// 服务退出时要注销服务
-	quit := make(chan os.Signal)
-	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
-	<-quit // 正常会hang在此处
-	// 退出时注销服务
-	serviceId := fmt.Sprintf("%s-%s-%d", config.Conf.Name, config.Conf.IP, config.Conf.Port)
-	registry.Reg.Deregister(serviceId)
+	app.Run()
 }
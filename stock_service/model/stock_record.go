@@ -1,8 +1,17 @@
 package model
 
+// 库存记录状态
+const (
+	StockRecordReserved  = 1 // 预扣减
+	StockRecordConfirmed = 2 // 扣减
+	StockRecordCancelled = 3 // 已回滚
+)
+
+// StockRecord 库存扣减记录，同时作为 TCC 的事务日志
 type StockRecord struct {
 	BaseModel // 嵌入默认的7个字段
 
+	TxId    string // 全局事务id
 	OrderId int64
 	GoodsId int64
 	Num     int64
@@ -0,0 +1,16 @@
+package model
+
+// StockReturn 售后退货归还库存记录，按 售后单id+商品id 保证只归还一次
+type StockReturn struct {
+	BaseModel // 嵌入默认的7个字段
+
+	RefundId int64
+	OrderId  int64
+	GoodsId  int64
+	Num      int64
+}
+
+// TableName 声明表名
+func (StockReturn) TableName() string {
+	return "xx_stock_return"
+}
@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+
+	goodsv1 "github.com/idMiFeng/api/shop/goods/v1"
+	"github.com/idMiFeng/common/client"
+	"github.com/idMiFeng/common/interceptor"
+	"github.com/idMiFeng/common/registry"
+	"github.com/idMiFeng/stock_service/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// 初始化其他服务的RPC客户端
+
+var (
+	GoodsCli goodsv1.GoodsClient // 商品服务
+)
+
+// InitSrvClient 连接商品服务，返回的连接在退出时关闭
+func InitSrvClient() (*grpc.ClientConn, error) {
+	if config.Conf.GoodsService == nil || len(config.Conf.GoodsService.Name) == 0 {
+		return nil, errors.New("invalid GoodsService.Name")
+	}
+	// 通过注册中心实现服务发现，resolver监听服务实例的变化，实例上下线后自动更新连接
+	opts, err := dialOptions(goodsv1.Goods_ServiceDesc.ServiceName, config.Conf.GoodsService.ClientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("goods_srv client config: %w", err)
+	}
+	goodsConn, err := grpc.Dial(
+		registry.Target(config.Conf.GoodsService.Name),
+		opts...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("dial goods_srv: %w", err)
+	}
+	GoodsCli = goodsv1.NewGoodsClient(goodsConn)
+	return goodsConn, nil
+}
+
+// dialOptions 连接其他服务的公共选项，service 为proto中的服务全名
+func dialOptions(service string, cfg *config.ClientConfig) ([]grpc.DialOption, error) {
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+	// 传递请求id，记录调用耗时
+	opts = append(opts, interceptor.DialOptions()...)
+	// round_robin负载均衡，按配置设置超时、重试、对冲和熔断
+	policy, err := client.DialOptions(service, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return append(opts, policy...), nil
+}
@@ -0,0 +1,19 @@
+CREATE TABLE `xx_dead_letter`(
+                           `id` BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '主键',
+                           `create_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+                           `create_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                           `update_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+                           `update_by` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建者',
+                           `version` SMALLINT(5) UNSIGNED NOT NULL DEFAULT '0' COMMENT '乐观锁版本号',
+                           `is_del` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '是否删除：0正常1删除',
+
+                           `topic` VARCHAR(128) NOT NULL DEFAULT '' COMMENT '原始topic',
+                           `msg_id` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '原始消息id',
+                           `body` TEXT NOT NULL COMMENT '消息体',
+                           `reconsume_times` INT UNSIGNED NOT NULL DEFAULT '0' COMMENT '重试次数',
+                           `reason` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '进入死信的原因',
+                           `status` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '状态：0待处理 1已重放',
+                           UNIQUE (msg_id),
+                           INDEX (topic, status),
+                           INDEX (is_del)
+)ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COMMENT = '死信消息表';
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- 库存记录按 全局事务id+商品id 幂等，老记录没有全局事务id，按订单号回填后再建唯一索引
+-- 空回滚的记录与同一订单的扣减记录并存，订单id+商品id 改为普通索引
+ALTER TABLE `xx_stock_record`
+    ADD COLUMN `tx_id` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '全局事务id' AFTER `is_del`;
+UPDATE `xx_stock_record` SET `tx_id` = CAST(`order_id` AS CHAR) WHERE `tx_id` = '';
+ALTER TABLE `xx_stock_record`
+    DROP INDEX `order_id`,
+    ADD INDEX (`order_id`, `goods_id`),
+    ADD UNIQUE (`tx_id`, `goods_id`);